@@ -0,0 +1,41 @@
+import json
+import sys
+
+
+def wrapped_displacement(from_x, from_y, to_x, to_y, width, height):
+    """The shortest displacement between two positions, across the battlefield edges."""
+    dx = (to_x - from_x + width / 2) % width - width / 2
+    dy = (to_y - from_y + height / 2) % height - height / 2
+    return dx, dy
+
+
+def main():
+    width, height = 0, 0
+    for line in sys.stdin:
+        request = json.loads(line)
+        if request["type"] == "end":
+            break
+        if request["type"] == "start":
+            width, height = request["width"], request["height"]
+
+        actions = []
+        if request["type"] == "update":
+            spaceship = request["spaceship"]
+            actions.append(["setEngineThrust", 100, 0, 0])
+            if spaceship["energy"] > 50 and any(is_in_range(spaceship, other, width, height) for other in request["gameObjects"]):
+                actions.append(["fireLaser"])
+
+        print(json.dumps({"tick": request["tick"], "actions": actions}), flush=True)
+
+
+def is_in_range(spaceship, other, width, height):
+    if other["type"] != "spaceship" or other["name"] == spaceship["name"] or not other["enabled"]:
+        return False
+    dx, dy = wrapped_displacement(
+        spaceship["position"]["x"], spaceship["position"]["y"], other["position"]["x"], other["position"]["y"], width, height
+    )
+    return (dx * dx + dy * dy) ** 0.5 < 400
+
+
+if __name__ == "__main__":
+    main()
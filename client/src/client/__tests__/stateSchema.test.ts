@@ -0,0 +1,97 @@
+import * as fs from "fs";
+import * as path from "path";
+import * as ts from "typescript";
+
+// Checks spaceships/types.ts against the JSON Schema generated from the kernel,
+// regenerate it with: go generate ./kernel/game
+type JSONSchema = {
+  type?: string;
+  $ref?: string;
+  enum?: unknown[];
+  const?: unknown;
+  properties?: Record<string, JSONSchema>;
+  required?: string[];
+};
+
+const spaceshipsDir = path.resolve(__dirname, "../../../../spaceships");
+const schema = JSON.parse(
+  fs.readFileSync(path.join(spaceshipsDir, "state.schema.json"), "utf8")
+) as JSONSchema & { $defs: Record<string, JSONSchema> };
+
+const typesPath = path.join(spaceshipsDir, "types.ts");
+const program = ts.createProgram([typesPath], { strict: true, noEmit: true });
+const checker = program.getTypeChecker();
+const source = program.getSourceFile(typesPath)!;
+
+const getType = (name: string): ts.Type => {
+  const declaration = source.statements.find(
+    (statement): statement is ts.TypeAliasDeclaration =>
+      ts.isTypeAliasDeclaration(statement) && statement.name.text === name
+  );
+  if (!declaration) {
+    throw new Error(`type ${name} not found in types.ts`);
+  }
+  return checker.getTypeAtLocation(declaration.name);
+};
+
+const schemaKind = (property: JSONSchema): string => {
+  const resolved = property.$ref
+    ? schema.$defs[property.$ref.replace("#/$defs/", "")]
+    : property;
+  if (resolved.enum) {
+    return typeof resolved.enum[0];
+  }
+  if (resolved.const !== undefined) {
+    return typeof resolved.const;
+  }
+  return resolved.type === "integer" ? "number" : resolved.type!;
+};
+
+const typeKind = (type: ts.Type): string => {
+  if (type.flags & ts.TypeFlags.NumberLike) return "number";
+  if (type.flags & ts.TypeFlags.StringLike) return "string";
+  if (type.flags & ts.TypeFlags.BooleanLike) return "boolean";
+  if (type.isUnion()) return typeKind(type.types[0]);
+  if (checker.typeToString(type).endsWith("[]")) return "array";
+  return "object";
+};
+
+describe("client / stateSchema", () => {
+  test.each([
+    ["GameState", "State"],
+    ["Asteroid", "AsteroidState"],
+    ["Laser", "ProjectileState"],
+    ["Rocket", "ProjectileState"],
+    ["Spaceship", "SpaceshipState"],
+    ["Explosion", "ExplosionState"],
+    ["Log", "LogState"],
+    ["Rules", "Rules"],
+    ["Team", "TeamState"],
+    ["Hill", "HillState"],
+    ["Flag", "FlagState"],
+  ])("%s matches %s of state.schema.json", (typeName, definitionName) => {
+    const definition =
+      definitionName === "State" ? schema : schema.$defs[definitionName];
+    const properties = new Map(
+      checker
+        .getPropertiesOfType(getType(typeName))
+        .map((symbol) => [symbol.name, symbol])
+    );
+
+    // Every property of the type is in the schema
+    properties.forEach((_, name) =>
+      expect([name, name in definition.properties!]).toEqual([name, true])
+    );
+
+    Object.entries(definition.properties!).forEach(([name, property]) => {
+      const symbol = properties.get(name);
+      if (!symbol) {
+        // Only the optional values could be left out of the type
+        expect([name, definition.required?.includes(name)]).not.toEqual([name, true]);
+        return;
+      }
+      const kind = typeKind(checker.getTypeOfSymbolAtLocation(symbol, source));
+      expect([name, kind]).toEqual([name, schemaKind(property)]);
+    });
+  });
+});
@@ -1,5 +1,9 @@
 import { isUndefined, reverse, shuffle } from "lodash/fp";
-import { isSetEngineThrustAction } from "../../../spaceships/spaceshipAction";
+import {
+  isSetEngineThrustAction,
+  isSetRotationThrustAction,
+  isSetShieldAction,
+} from "../../../spaceships/spaceshipAction";
 import { loop as createLoop } from "./loop";
 import { observable } from "./observable";
 import {
@@ -10,8 +14,13 @@ import {
 } from "./render";
 import { drawBackground } from "./render/drawBackground";
 import { drawExplosion } from "./render/drawExplosion";
+import { drawFlag } from "./render/drawFlag";
+import { drawHill } from "./render/drawHill";
 import { drawLaser } from "./render/drawLaser";
+import { drawMine } from "./render/drawMine";
+import { drawPowerUp } from "./render/drawPowerUp";
 import { drawRocket } from "./render/drawRocket";
+import { drawSafeZone } from "./render/drawSafeZone";
 import { drawSpaceship } from "./render/drawSpaceship";
 import { getScoreboard, getSpaceship } from "./utils";
 import { getCanvas, getStartLocations } from "./utils";
@@ -22,13 +31,21 @@ import type { GameState, SpaceshipManager } from "../../../spaceships";
 import spaceshipFactories, {
   isAsteroid,
   isExplosion,
+  isFlag,
+  isHill,
   isLaser,
+  isMine,
+  isPowerUp,
   isRocket,
   isSpaceship,
 } from "../../../spaceships";
 
 const TILE_SIZE = 48;
 
+// The scoreboard ranks by the game mode and the respawn rule
+const scoreboardOf = ({ gameObjects, rules }: GameState) =>
+  getScoreboard(gameObjects, rules.mode, rules.respawn);
+
 type EngineProps = {
   canvasId: string;
   width: number;
@@ -89,7 +106,7 @@ export const engine = async ({ canvasId, width, height, fps }: EngineProps) => {
     );
     onStateChanged.broadcast(gameState.status);
     onLogsChanged.broadcast(reverse(gameState.logs));
-    onScoreboardChanged.broadcast(getScoreboard(gameState.gameObjects));
+    onScoreboardChanged.broadcast(scoreboardOf(gameState));
   };
 
   const reset = (startLocations: Vector2[]) => {
@@ -110,7 +127,11 @@ export const engine = async ({ canvasId, width, height, fps }: EngineProps) => {
       spaceWars.reset();
       spaceWars.start();
     } else {
-      spaceWars.fromState(loadedRawGameState);
+      const { error, warnings } = spaceWars.fromState(loadedRawGameState);
+      warnings.forEach((warning) => console.warn("state", warning));
+      if (error) {
+        console.error("failed to load the state", error);
+      }
     }
 
     spaceshipNameIndexMap.clear();
@@ -128,7 +149,7 @@ export const engine = async ({ canvasId, width, height, fps }: EngineProps) => {
     );
     onStateChanged.broadcast(gameState.status);
     onLogsChanged.broadcast(reverse(gameState.logs));
-    onScoreboardChanged.broadcast(getScoreboard(gameState.gameObjects));
+    onScoreboardChanged.broadcast(scoreboardOf(gameState));
   };
 
   const onUpdate = (deltaTimeMs: number, forced: boolean = false) => {
@@ -164,6 +185,10 @@ export const engine = async ({ canvasId, width, height, fps }: EngineProps) => {
               action[2],
               action[3]
             );
+          } else if (isSetRotationThrustAction(action)) {
+            spaceWars.action(action[0], spaceshipManager.name, action[1]);
+          } else if (isSetShieldAction(action)) {
+            spaceWars.action(action[0], spaceshipManager.name, action[1]);
           } else {
             spaceWars.action(action[0], spaceshipManager.name);
           }
@@ -183,7 +208,7 @@ export const engine = async ({ canvasId, width, height, fps }: EngineProps) => {
       if (gameState.logs.length > logSize) {
         onStateChanged.broadcast(gameState.status);
         onLogsChanged.broadcast(reverse(gameState.logs));
-        onScoreboardChanged.broadcast(getScoreboard(gameState.gameObjects));
+        onScoreboardChanged.broadcast(scoreboardOf(gameState));
         logSize = gameState.logs.length;
       }
 
@@ -195,6 +220,8 @@ export const engine = async ({ canvasId, width, height, fps }: EngineProps) => {
         height,
       });
 
+      drawSafeZone({ render, gameState });
+
       for (let i = 0; i < gameState.gameObjects.length; i++) {
         const gameObject = gameState.gameObjects[i];
         if (!gameObject.enabled) {
@@ -237,12 +264,28 @@ export const engine = async ({ canvasId, width, height, fps }: EngineProps) => {
             scale: 0.75,
             showCollider,
           });
+        } else if (isMine(gameObject)) {
+          drawMine({
+            render,
+            mine: gameObject,
+            triggerRadius: gameState.rules.mineTriggerRadius,
+          });
+        } else if (isPowerUp(gameObject)) {
+          drawPowerUp({ render, powerUp: gameObject });
         } else if (isExplosion(gameObject)) {
           drawExplosion({
             render,
             explosion: gameObject,
             sprite: explosionsSprite,
           });
+        } else if (isHill(gameObject)) {
+          drawHill({ render, hill: gameObject });
+        } else if (isFlag(gameObject)) {
+          drawFlag({
+            render,
+            flag: gameObject,
+            baseRadius: gameState.rules.flagRadius,
+          });
         }
       }
     } catch (error) {
@@ -348,7 +391,7 @@ export const engine = async ({ canvasId, width, height, fps }: EngineProps) => {
       gameState = spaceWars.state();
       onStateChanged.broadcast("paused");
       onLogsChanged.broadcast(gameState.logs.reverse());
-      onScoreboardChanged.broadcast(getScoreboard(gameState.gameObjects));
+      onScoreboardChanged.broadcast(scoreboardOf(gameState));
     },
     step: () => {
       onUpdate(50, true);
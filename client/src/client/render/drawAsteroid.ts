@@ -1,8 +1,12 @@
 import { Render } from "./render";
 import { Sprite } from "./Sprite";
 import { COLOR_COLLIDER } from ".";
+import { rotateVector2, translateVector2 } from "../utils";
 import type { Asteroid } from "../../../../spaceships/types";
 
+const COLOR_ROCK = "#6B5E55";
+const COLOR_ROCK_EDGE = "#A39384";
+
 export const drawAsteroid = ({
   render,
   asteroid,
@@ -16,16 +20,34 @@ export const drawAsteroid = ({
   elapsedTimeMs: number;
   showCollider: boolean;
 }) => {
-  render.drawSprite(
-    sprite,
-    asteroid.position.x,
-    asteroid.position.y,
-    asteroid.radius * 2,
-    asteroid.radius * 2,
-    asteroid.position.x + elapsedTimeMs / 4000 + asteroid.radius * 2
-  );
+  if (asteroid.vertices.length > 0) {
+    const points = asteroid.vertices.map((vertex) =>
+      translateVector2(rotateVector2(vertex, asteroid.rotation), asteroid.position)
+    );
+    render.drawPolygonFilled(COLOR_ROCK, points);
+    render.drawPolygon(COLOR_ROCK_EDGE, 2, points);
+  } else {
+    render.drawSprite(
+      sprite,
+      asteroid.position.x,
+      asteroid.position.y,
+      asteroid.radius * 2,
+      asteroid.radius * 2,
+      asteroid.startPosition.x + elapsedTimeMs / 4000 + asteroid.radius * 2 + asteroid.rotation
+    );
+  }
 
-  if (showCollider) {
+  if (!showCollider) {
+    return;
+  }
+  if (asteroid.collider.type === "polygon") {
+    const { position, rotation } = asteroid.collider;
+    render.drawPolygon(
+      COLOR_COLLIDER,
+      1,
+      asteroid.collider.vertices.map((vertex) => translateVector2(rotateVector2(vertex, rotation), position))
+    );
+  } else {
     render.drawCircle(
       COLOR_COLLIDER,
       1,
@@ -0,0 +1,28 @@
+import type { Flag } from "../../../../spaceships/types";
+import { COLOR_TEXT, TEXT_INFO } from ".";
+import { Render } from "./render";
+
+const COLOR_FLAG = "#FF9F1C";
+const FLAG_RADIUS = 6;
+
+// The base is drawn at the flag radius of the rules, how close the spaceships capture
+export const drawFlag = ({
+  render,
+  flag,
+  baseRadius,
+}: {
+  render: Render;
+  flag: Flag;
+  baseRadius: number;
+}) => {
+  render.drawCircle(COLOR_FLAG, 1, flag.base.x, flag.base.y, baseRadius);
+  render.drawCircleFilled(COLOR_FLAG, flag.position.x, flag.position.y, FLAG_RADIUS);
+  render.drawText(
+    TEXT_INFO,
+    COLOR_TEXT,
+    flag.side,
+    flag.position.x,
+    flag.position.y - FLAG_RADIUS - 4,
+    true
+  );
+};
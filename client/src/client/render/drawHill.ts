@@ -0,0 +1,26 @@
+import type { Hill } from "../../../../spaceships/types";
+import { COLOR_TEXT, TEXT_INFO } from ".";
+import { Render } from "./render";
+
+const COLOR_HILL = "#FFD23B";
+const COLOR_HILL_CONTROLLED = "#3BFF6E";
+
+export const drawHill = ({ render, hill }: { render: Render; hill: Hill }) => {
+  render.drawCircle(
+    hill.controller ? COLOR_HILL_CONTROLLED : COLOR_HILL,
+    2,
+    hill.position.x,
+    hill.position.y,
+    hill.radius
+  );
+  if (hill.controller) {
+    render.drawText(
+      TEXT_INFO,
+      COLOR_TEXT,
+      hill.controller,
+      hill.position.x,
+      hill.position.y - hill.radius - 4,
+      true
+    );
+  }
+};
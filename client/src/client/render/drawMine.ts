@@ -0,0 +1,28 @@
+import type { Mine } from "../../../../spaceships/types";
+import { Render } from "./render";
+
+const COLOR_MINE = "#FF3B3B";
+const COLOR_MINE_DISARMED = "#7F7F7F";
+const MINE_RADIUS = 5;
+
+// The trigger radius is drawn once the mine is armed
+export const drawMine = ({
+  render,
+  mine,
+  triggerRadius,
+}: {
+  render: Render;
+  mine: Mine;
+  triggerRadius: number;
+}) => {
+  const armed = mine.armingTimerSec <= 0;
+  render.drawCircleFilled(
+    armed ? COLOR_MINE : COLOR_MINE_DISARMED,
+    mine.position.x,
+    mine.position.y,
+    MINE_RADIUS
+  );
+  if (armed) {
+    render.drawCircle(COLOR_MINE, 1, mine.position.x, mine.position.y, triggerRadius);
+  }
+};
@@ -0,0 +1,35 @@
+import type { PowerUp } from "../../../../spaceships/types";
+import { Render } from "./render";
+
+const COLORS_POWER_UP: Record<PowerUp["kind"], string> = {
+  rockets: "#FF9F1C",
+  health: "#2EC4B6",
+  energy: "#FFE66D",
+  damageBoost: "#E71D36",
+};
+const LETTERS_POWER_UP: Record<PowerUp["kind"], string> = {
+  rockets: "R",
+  health: "H",
+  energy: "E",
+  damageBoost: "D",
+};
+const TEXT_POWER_UP = "bold 10px Arial";
+const COLOR_POWER_UP_TEXT = "#000000";
+
+// Colored and lettered by the kind, the circle is the collider
+export const drawPowerUp = ({ render, powerUp }: { render: Render; powerUp: PowerUp }) => {
+  render.drawCircleFilled(
+    COLORS_POWER_UP[powerUp.kind],
+    powerUp.position.x,
+    powerUp.position.y,
+    powerUp.collider.radius
+  );
+  render.drawText(
+    TEXT_POWER_UP,
+    COLOR_POWER_UP_TEXT,
+    LETTERS_POWER_UP[powerUp.kind],
+    powerUp.position.x,
+    powerUp.position.y + 4,
+    true
+  );
+};
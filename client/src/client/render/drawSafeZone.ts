@@ -0,0 +1,39 @@
+import type { GameState } from "../../../../spaceships/types";
+import { Render } from "./render";
+
+const COLOR_SAFE_ZONE = "#FF3B3B";
+
+// Radius of the safe zone during the shrinking zone sudden death, null otherwise,
+// see GameManager.SafeZoneRadius in kernel/game/game_manager.go
+export const getSafeZoneRadius = ({ rules, elapsedMs, size }: GameState): number | null => {
+  if (
+    rules.suddenDeath !== "shrinkingZone" ||
+    rules.timeLimitSec <= 0 ||
+    elapsedMs < rules.timeLimitSec * 1000
+  ) {
+    return null;
+  }
+  const progress = (elapsedMs / 1000 - rules.timeLimitSec) / rules.suddenDeathDurationSec;
+  return (Math.hypot(size.width, size.height) / 2) * Math.max(1 - progress, 0);
+};
+
+export const drawSafeZone = ({
+  render,
+  gameState,
+}: {
+  render: Render;
+  gameState: GameState;
+}) => {
+  const radius = getSafeZoneRadius(gameState);
+  if (radius === null) {
+    return;
+  }
+
+  render.drawCircle(
+    COLOR_SAFE_ZONE,
+    2,
+    gameState.size.width / 2,
+    gameState.size.height / 2,
+    radius
+  );
+};
@@ -14,6 +14,9 @@ import type { Spaceship } from "../../../../spaceships/types";
 const MAIN_THRUST_SIZE = 24;
 const SIDE_THRUST_SIZE = 18;
 const THRUST_SPRITE_DURATION_MS = 100;
+const COLOR_INVULNERABLE = "#FFFFFF";
+const INVULNERABLE_BLINK_MS = 200;
+const COLOR_SHIELD = "#29cfff";
 
 const thrustSize = (baseSize: number, thrust: number) => {
   return baseSize / 2 + ((baseSize / 2) * thrust) / 100;
@@ -49,6 +52,30 @@ export const drawSpaceship = ({
     spaceship.rotation + Math.PI / 2
   );
 
+  // Blinks while the respawned spaceship takes no damage
+  if (
+    spaceship.invulnerableTimerSec > 0 &&
+    Math.floor(elapsedTimeMs / INVULNERABLE_BLINK_MS) % 2 === 0
+  ) {
+    render.drawCircle(
+      COLOR_INVULNERABLE,
+      1,
+      spaceship.position.x,
+      spaceship.position.y,
+      spaceship.collider.radius + 4
+    );
+  }
+
+  if (spaceship.shield) {
+    render.drawCircle(
+      COLOR_SHIELD,
+      2,
+      spaceship.position.x,
+      spaceship.position.y,
+      spaceship.collider.radius + 6
+    );
+  }
+
   let thrustPosition: Vector2;
   const thrustSpriteFrame = Math.floor(
     (elapsedTimeMs / THRUST_SPRITE_DURATION_MS) % thrustSprite.sprites.length
@@ -139,6 +139,20 @@ export const render = (
     context.stroke();
   },
 
+  drawPolygonFilled: (color: string | CanvasGradient | CanvasPattern, points: Vector2[]) => {
+    context.beginPath();
+    const [start, ...rest] = points
+
+    context.moveTo(start.x, start.y);
+    for (const point of rest) {
+      context.lineTo(point.x, point.y);
+    }
+
+    context.closePath();
+    context.fillStyle = color
+    context.fill();
+  },
+
   drawText: (font: string, color: string, text: string, x: number, y: number, centered = false) => {
     context.font = font
     context.fillStyle = color
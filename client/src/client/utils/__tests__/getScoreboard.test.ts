@@ -49,3 +49,34 @@ test("client / utils / getScoreboard", () => {
   expect(scoreboard[0].kills).toBe(3);
   expect(scoreboard[0].destroyed).toBe(false);
 });
+
+test("client / utils / getScoreboard / game mode", () => {
+  const gameObjects: GameObject[] = [
+    {
+      id: 1,
+      name: "Spaceship 1 (destroyed)",
+      score: 300,
+      kills: 3,
+      objective: 0,
+      destroyed: true,
+      type: "spaceship",
+    } as Spaceship,
+    {
+      id: 2,
+      name: "Spaceship 2",
+      score: 400,
+      kills: 1,
+      objective: 2,
+      destroyed: false,
+      type: "spaceship",
+    } as Spaceship,
+  ];
+
+  // The destroyed respawn, the kills are the objective
+  expect(
+    getScoreboard(gameObjects, "deathmatch").map((entry) => entry.name)
+  ).toEqual(["Spaceship 1 (destroyed)", "Spaceship 2"]);
+  expect(
+    getScoreboard(gameObjects, "captureTheFlag").map((entry) => entry.objective)
+  ).toEqual([2, 0]);
+});
@@ -1,6 +1,6 @@
 export { rotateVector2, translateVector2 } from "./vector2";
 export { getCanvas } from "./canvas";
-export { isCollisionLog, isGameStateLog, isKillLog, isDamageLog } from "./log";
+export { isCollisionLog, isGameStateLog, isKillLog, isDamageLog, isPowerUpLog } from "./log";
 export { getStartLocations } from "./startLocations";
 export { getScoreboard } from "./scoreboard";
 export { getSpaceship } from "./getSpaceship";
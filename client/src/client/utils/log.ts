@@ -1,4 +1,11 @@
-import type { CollisionLog, DamageLog, GameStateLog, KillLog, Log } from "../../../../spaceships";
+import type {
+  CollisionLog,
+  DamageLog,
+  GameStateLog,
+  KillLog,
+  Log,
+  PowerUpLog,
+} from "../../../../spaceships";
 
 export const isCollisionLog = (log: Log): log is CollisionLog => {
   return log.logType === "collision";
@@ -15,3 +22,7 @@ export const isKillLog = (log: Log): log is KillLog => {
 export const isDamageLog = (log: Log): log is DamageLog => {
   return log.logType === "damage";
 };
+
+export const isPowerUpLog = (log: Log): log is PowerUpLog => {
+  return log.logType === "power_up";
+};
@@ -1,36 +1,75 @@
 import { isSpaceship } from "../../../../spaceships";
-import type { GameObject } from "../../../../spaceships";
+import type { GameObject, Mode, Spaceship } from "../../../../spaceships";
 
 
 export type ScoreboardEntry = {
   id: number;
   name: string;
+  // Progress towards the objective of the game mode
+  objective: number;
   score: number;
+  health: number;
+  damageDealt: number;
   kills: number;
+  deaths: number;
+  respawns: number;
   destroyed: boolean;
 };
 
-export const getScoreboard = (gameObjects: GameObject[]): ScoreboardEntry[] => {
+// See GameMode.Objective in kernel/game/game_mode.go
+const getObjective = (spaceship: Spaceship, mode: Mode): number => {
+  switch (mode) {
+    case "lastStanding":
+      return 0;
+    case "deathmatch":
+      return spaceship.kills;
+    default:
+      return spaceship.objective;
+  }
+};
+
+export const getScoreboard = (
+  gameObjects: GameObject[],
+  mode: Mode = "lastStanding",
+  respawn: boolean = false
+): ScoreboardEntry[] => {
+  // The destroyed spaceships respawn in the other modes, or with the respawn rule
+  const eliminates = mode === "lastStanding" && !respawn;
   const states: ScoreboardEntry[] = [];
   for (const gameObject of gameObjects) {
     if (isSpaceship(gameObject)) {
       states.push({
         id: gameObject.id,
         name: gameObject.name,
+        objective: getObjective(gameObject, mode),
         score: gameObject.score,
+        health: gameObject.health,
+        damageDealt: gameObject.damageDealt,
         kills: gameObject.kills,
+        deaths: gameObject.deaths,
+        respawns: gameObject.respawns,
         destroyed: gameObject.destroyed,
       });
     }
   }
 
   states.sort((a, b) => {
-    if (a.destroyed && !b.destroyed) {
+    if (eliminates && a.destroyed && !b.destroyed) {
       return 1;
-    } else if (!a.destroyed && b.destroyed) {
+    } else if (eliminates && !a.destroyed && b.destroyed) {
       return -1;
     }
-    return b.score - a.score;
+    // The objective and the tiebreakers, see kernel/game/scoreboard.go
+    if (a.objective !== b.objective) {
+      return b.objective - a.objective;
+    }
+    if (a.score !== b.score) {
+      return b.score - a.score;
+    }
+    if (a.health !== b.health) {
+      return b.health - a.health;
+    }
+    return b.damageDealt - a.damageDealt;
   });
 
   return states;
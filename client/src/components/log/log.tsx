@@ -2,7 +2,7 @@ import classNames from "classnames";
 import styles from "./log.module.css";
 import { LogProps } from "./types";
 import { Trans } from "react-i18next";
-import { isCollisionLog, isDamageLog, isKillLog } from "../../client/utils";
+import { isCollisionLog, isDamageLog, isKillLog, isPowerUpLog } from "../../client/utils";
 import { spaceshipColorClassName } from "./spaceshipColorClassName";
 import type { Log } from "../../../../spaceships";
 
@@ -57,6 +57,20 @@ const LogMessage = ({ log }: LogProps): JSX.Element => {
         />
       );
     }
+    if (isPowerUpLog(log)) {
+      return (
+        <Trans
+          i18nKey="views.battlefield.log.powerUp"
+          components={{
+            1: <span className={classNames(spaceshipColorClassName(log.meta.who, 10))} />,
+          }}
+          values={{
+            who: log.meta.who,
+            powerUp: log.meta.powerUp,
+          }}
+        />
+      );
+    }
     return log.message;
   };
 
@@ -44,6 +44,7 @@ export default {
           "<1>{{who}}</1> did {{damage}} damage to <2>{{whom}}</2> with {{damageType}}",
         collision: "<1>{{who}}</1> collided with <2>{{with}}</2>",
         killed: "<1>{{who}}</1> was killed by <2>{{whom}}</2>",
+        powerUp: "<1>{{who}}</1> collected {{powerUp}}",
       },
       gameOver: {
         title: "Battle Concluded",
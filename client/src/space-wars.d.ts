@@ -2,19 +2,24 @@ declare namespace spaceWars {
   function init(
     width: number,
     height: number,
-    seed?: number
+    seed?: number,
+    rules?: string
   ): void;
   function tick(deltaTimeMs: number): void;
   function start(): void;
   function pause(): void;
   function reset(): void;
   function state(): import('../../spaceships').GameState;
-  function fromState(state: string): void;
+  function fromState(
+    state: string,
+    strict?: boolean
+  ): { error: string | null; warnings: string[] };
   function addSpaceship(
     name: string,
     x: number,
     y: number,
-    rotation: number
+    rotation: number,
+    team?: string
   ): void;
   function action(
     action: "setEngineThrust",
@@ -23,6 +28,20 @@ declare namespace spaceWars {
     leftEngineThrust: number,
     rightEngineThrust: number
   ): void;
-  function action(action: "fireLaser" | "fireRocket", shipName: string): void;
+  function action(
+    action: "setRotationThrust",
+    shipName: string,
+    rotationThrust: number
+  ): void;
+  function action(action: "fireLaser" | "fireRocket" | "dropMine", shipName: string): void;
+  // 1 raises the shield, 0 lowers it
+  function action(action: "setShield", shipName: string, shield: 0 | 1): void;
   function action(action: "setStartPosition", shipName: string, x: number, y: number, rotation: number): void;
+  function wrappedDisplacement(
+    fromX: number,
+    fromY: number,
+    toX: number,
+    toY: number
+  ): { x: number; y: number; distance: number };
+  function observe(shipName: string): import('../../spaceships').Observation | null;
 }
@@ -10,7 +10,7 @@ import { Engine } from "../../client/engine";
 import type { ScoreboardEntry } from "../../client/utils/scoreboard";
 import { cloneDeep, get } from "lodash/fp";
 import { spaceshipColorClassName } from "../../components/log/spaceshipColorClassName";
-import { GameState } from "../../../../spaceships";
+import { GameState, Mode, Rules } from "../../../../spaceships";
 import { useSearchParams } from "react-router-dom";
 import { Log } from "../../components/log";
 import styles from "./battlefield.module.css";
@@ -34,7 +34,17 @@ const BattlefieldView = (): JSX.Element => {
   const [overallScoreboard, setOverallScoreboard] = useState<
     Map<
       string,
-      Omit<ScoreboardEntry, "id" | "name" | "destroyed"> & { destroyed: number }
+      Omit<
+        ScoreboardEntry,
+        | "id"
+        | "name"
+        | "destroyed"
+        | "health"
+        | "damageDealt"
+        | "objective"
+        | "deaths"
+        | "respawns"
+      > & { destroyed: number }
     >
   >(new Map());
   const [overallKills, setOverallKills] = useState<[string, number][]>([]);
@@ -75,6 +85,19 @@ const BattlefieldView = (): JSX.Element => {
       const seed = searchParams.has("seed")
         ? parseInt(searchParams.get("seed")!)
         : undefined;
+      // The game mode, the respawn modes need the time or the objective limit
+      const rules: Partial<Rules> = {};
+      if (searchParams.has("mode")) {
+        rules.mode = searchParams.get("mode") as Mode;
+      }
+      if (searchParams.has("timeLimitSec")) {
+        rules.timeLimitSec = parseFloat(searchParams.get("timeLimitSec")!);
+      }
+      if (searchParams.has("objectiveLimit")) {
+        rules.objectiveLimit = parseFloat(
+          searchParams.get("objectiveLimit")!
+        );
+      }
 
       try {
         const go = new Go();
@@ -84,11 +107,8 @@ const BattlefieldView = (): JSX.Element => {
         );
         go.run(result.instance);
 
-        if (seed) {
-          spaceWars.init(width, height, seed);
-        } else {
-          spaceWars.init(width, height);
-        }
+        // An invalid seed is left to the kernel to pick
+        spaceWars.init(width, height, seed || undefined, JSON.stringify(rules));
 
         engine = await createEngine({
           canvasId: CANVAS_ID,
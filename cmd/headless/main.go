@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/runner"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to the match configuration file (JSON)")
+	rulesPath := flag.String("rules", "", "path to the rules file (JSON), replaces the rules of the configuration")
+	replayPath := flag.String("replay", "", "write the replay of the match to this file")
+	verifyPath := flag.String("verify", "", "re-run the replay from this file and check its final state")
+	pretty := flag.Bool("pretty", false, "indent the JSON output")
+	flag.Parse()
+
+	var result *runner.Result
+	var err error
+	switch {
+	case *verifyPath != "":
+		result, err = verify(*verifyPath)
+	case *configPath != "":
+		result, err = run(*configPath, *rulesPath, *replayPath)
+	default:
+		fmt.Fprintln(os.Stderr, "missing -config or -verify")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	if *pretty {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(result); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string, rulesPath string, replayPath string) (*runner.Result, error) {
+	config, err := runner.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if rulesPath != "" {
+		if config.Rules, err = game.LoadRules(rulesPath); err != nil {
+			return nil, fmt.Errorf("%s: %w", rulesPath, err)
+		}
+	}
+
+	result, err := runner.Run(config, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	if replayPath != "" {
+		file, err := os.Create(replayPath)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		if err := game.WriteReplay(file, result.Replay); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func verify(replayPath string) (*runner.Result, error) {
+	file, err := os.Open(replayPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	replay, err := game.ReadReplay(file)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Verify(replay)
+}
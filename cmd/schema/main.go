@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+)
+
+func main() {
+	outputPath := flag.String("o", "", "write the schema to this file instead of stdout")
+	flag.Parse()
+
+	schema, err := json.MarshalIndent(game.StateSchema(), "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	schema = append(schema, '\n')
+
+	if *outputPath == "" {
+		os.Stdout.Write(schema)
+		return
+	}
+	if err := os.WriteFile(*outputPath, schema, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build js && wasm
+
 package main
 
 import (
@@ -22,6 +24,11 @@ func JsFuncIn(fn func(args []js.Value)) js.Func {
 		return nil
 	})
 }
+func JsFuncInOut(fn func(args []js.Value) any) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return fn(args)
+	})
+}
 
 func Method(methodName string, args []js.Value) struct {
 	StringArg func(int, string) (string, error)
@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+const testBotEnv = "SPACE_WARS_TEST_BOT"
+
+// TestMain turns the test binary into a bot when started by the tests,
+// see testBotCommand.
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(testBotEnv); mode != "" {
+		runTestBot(mode)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// testBotCommand returns the command starting the test binary as a bot
+// with the given behavior:
+//   - thrust: full main thrust and fires the laser every tick
+//   - lazy: acknowledges the start, never replies to updates
+//   - crash: acknowledges the start, exits on the first update
+//   - invalid: replies with an invalid message
+//   - stale: replies twice, first to the previous tick
+//   - count: main thrust of the number of the game objects it received
+func testBotCommand(t *testing.T, mode string) []string {
+	t.Setenv(testBotEnv, mode)
+	return []string{os.Args[0]}
+}
+
+func runTestBot(mode string) {
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		request := Request{}
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			os.Exit(1)
+		}
+		if request.Type == MessageTypeEnd {
+			return
+		}
+		if request.Type == MessageTypeStart {
+			if mode == "invalid" {
+				fmt.Println("invalid")
+				continue
+			}
+			fmt.Printf(`{"tick": %d, "actions": []}`+"\n", request.Tick)
+			continue
+		}
+
+		switch mode {
+		case "thrust":
+			fmt.Printf(`{"tick": %d, "actions": [["setEngineThrust", 100, 0, 0], ["fireLaser"], ["setStartPosition", 0, 0, 0]]}`+"\n", request.Tick)
+		case "lazy":
+		case "crash":
+			os.Exit(1)
+		case "count":
+			fmt.Printf(`{"tick": %d, "actions": [["setEngineThrust", %d, 0, 0]]}`+"\n", request.Tick, len(request.GameObjects))
+		case "stale":
+			fmt.Printf(`{"tick": %d, "actions": [["setEngineThrust", 10, 0, 0]]}`+"\n", request.Tick-1)
+			fmt.Printf(`{"tick": %d, "actions": [["setEngineThrust", 50, 0, 0]]}`+"\n", request.Tick)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+)
+
+const (
+	DefaultTimeout      = 100 * time.Millisecond
+	DefaultStartTimeout = 2 * time.Second
+	DefaultMaxTimeouts  = 10
+	closeGracePeriod    = 500 * time.Millisecond
+)
+
+type Options struct {
+	// Timeout is the time a bot has to reply to a single tick.
+	Timeout time.Duration
+	// StartTimeout is the time a bot has to acknowledge the start of the match.
+	StartTimeout time.Duration
+	// MaxTimeouts is the number of late replies after which a bot is disqualified,
+	// 0 disables the disqualification.
+	MaxTimeouts int
+	// Stderr receives the stderr of all the bots, discarded when nil.
+	Stderr io.Writer
+	// FogOfWar sends each bot the observation of its spaceship, see game.Game.Observe,
+	// instead of all the game objects.
+	FogOfWar bool
+}
+
+// Report summarizes how a bot behaved during the match.
+type Report struct {
+	Name         string `json:"name"`
+	Timeouts     int    `json:"timeouts"`
+	Disqualified bool   `json:"disqualified"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+type Bot struct {
+	name    string
+	process *Process
+	report  Report
+}
+
+// Controller drives out-of-process bots, one per spaceship.
+//
+// Penalties:
+//   - A late reply drops the bot's actions for that tick and counts as a timeout.
+//   - Reaching Options.MaxTimeouts, crashing, or replying with an invalid message
+//     disqualifies the bot, its process is killed and its spaceship destroyed.
+type Controller struct {
+	options Options
+	bots    []*Bot
+}
+
+func NewController(options Options) *Controller {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.StartTimeout <= 0 {
+		options.StartTimeout = DefaultStartTimeout
+	}
+	return &Controller{
+		options: options,
+		bots:    []*Bot{},
+	}
+}
+
+// Add spawns the bot process controlling the spaceship with the given name.
+func (controller *Controller) Add(name string, command []string) error {
+	process, err := Start(command, controller.options.Stderr)
+	if err != nil {
+		return fmt.Errorf("failed to start bot %s: %w", name, err)
+	}
+	controller.bots = append(controller.bots, &Bot{
+		name:    name,
+		process: process,
+		report:  Report{Name: name},
+	})
+	return nil
+}
+
+// Start sends the start message to all the bots and waits for them to be ready.
+func (controller *Controller) Start(instance *game.Game) {
+	state := instance.Serialize()
+	size := state["size"].(map[string]interface{})
+	controller.exchange(instance, 0, controller.options.StartTimeout, func(bot *Bot) Request {
+		return Request{
+			Type:      MessageTypeStart,
+			Tick:      0,
+			Width:     size["width"].(float64),
+			Height:    size["height"].(float64),
+			Rules:     state["rules"].(map[string]interface{}),
+			Spaceship: findSpaceship(state, bot.name),
+		}
+	})
+}
+
+// Update sends the current state to all the bots and applies their actions.
+func (controller *Controller) Update(instance *game.Game, tick int64, deltaTimeMs float64) {
+	state := instance.Serialize()
+	gameObjects := state["gameObjects"].([]interface{})
+	controller.exchange(instance, tick, controller.options.Timeout, func(bot *Bot) Request {
+		request := Request{
+			Type:        MessageTypeUpdate,
+			Tick:        tick,
+			DeltaTimeMs: deltaTimeMs,
+			Spaceship:   findSpaceship(state, bot.name),
+			GameObjects: gameObjects,
+		}
+		if controller.options.FogOfWar {
+			// The bots play the spaceships of the match, the observation cannot fail
+			observation, _ := instance.Observe(bot.name)
+			request.Spaceship = observation.Spaceship
+			request.GameObjects = observation.GameObjects
+		}
+		return request
+	})
+}
+
+// Close notifies the bots the match is over and stops their processes.
+func (controller *Controller) Close(tick int64, status game.Status) {
+	var wait sync.WaitGroup
+	for _, bot := range controller.active() {
+		wait.Add(1)
+		go func(bot *Bot) {
+			defer wait.Done()
+			bot.process.Send(Request{Type: MessageTypeEnd, Tick: tick, Status: string(status)})
+			bot.process.Close(closeGracePeriod)
+		}(bot)
+	}
+	wait.Wait()
+}
+
+func (controller *Controller) Reports() []Report {
+	reports := make([]Report, len(controller.bots))
+	for i, bot := range controller.bots {
+		reports[i] = bot.report
+	}
+	return reports
+}
+
+// exchange sends the requests to all the active bots in parallel and then
+// handles the replies sequentially, in the order the bots were added,
+// so the actions are applied deterministically.
+func (controller *Controller) exchange(instance *game.Game, tick int64, timeout time.Duration, request func(bot *Bot) Request) {
+	bots := controller.active()
+	replies := make([]Reply, len(bots))
+	errs := make([]error, len(bots))
+
+	var wait sync.WaitGroup
+	for i, bot := range bots {
+		wait.Add(1)
+		go func(i int, bot *Bot, request Request) {
+			defer wait.Done()
+			if errs[i] = bot.process.Send(request); errs[i] != nil {
+				return
+			}
+			replies[i], errs[i] = bot.process.Receive(tick, timeout)
+		}(i, bot, request(bot))
+	}
+	wait.Wait()
+
+	for i, bot := range bots {
+		if errs[i] != nil {
+			controller.penalize(instance, bot, tick, errs[i])
+			continue
+		}
+		for _, action := range replies[i].Actions {
+			if !allowedActions[action.Type] {
+				continue
+			}
+			// Errors such as a laser still cooling down are part of the game
+			instance.ApplyAction(bot.name, action)
+		}
+	}
+}
+
+func (controller *Controller) penalize(instance *game.Game, bot *Bot, tick int64, err error) {
+	if tick > 0 && (errors.Is(err, ErrTimeout) || errors.Is(err, ErrUnresponsive)) {
+		bot.report.Timeouts++
+		if controller.options.MaxTimeouts == 0 || bot.report.Timeouts < controller.options.MaxTimeouts {
+			return
+		}
+		err = fmt.Errorf("exceeded %d timeouts", controller.options.MaxTimeouts)
+	}
+
+	bot.report.Disqualified = true
+	bot.report.Reason = fmt.Sprintf("tick %d: %v", tick, err)
+	bot.process.Close(0)
+	instance.Disqualify(bot.name)
+}
+
+func (controller *Controller) active() []*Bot {
+	bots := make([]*Bot, 0, len(controller.bots))
+	for _, bot := range controller.bots {
+		if !bot.report.Disqualified {
+			bots = append(bots, bot)
+		}
+	}
+	return bots
+}
+
+func findSpaceship(state map[string]interface{}, name string) map[string]interface{} {
+	for _, gameObject := range state["gameObjects"].([]interface{}) {
+		object := gameObject.(map[string]interface{})
+		if object["type"] == "spaceship" && object["name"] == name {
+			return object
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGame(names ...string) *game.Game {
+	instance := game.NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, game.DefaultRules())
+	for i, name := range names {
+		instance.AddSpaceship(name, physics.Vector2{X: 100 + float64(i)*300, Y: 400}, 0, "")
+	}
+	instance.Start()
+	return instance
+}
+
+func spaceshipState(instance *game.Game, name string) map[string]interface{} {
+	return findSpaceship(instance.Serialize(), name)
+}
+
+func TestNewController_Defaults(t *testing.T) {
+	controller := NewController(Options{})
+
+	assert.Equal(t, DefaultTimeout, controller.options.Timeout)
+	assert.Equal(t, DefaultStartTimeout, controller.options.StartTimeout)
+}
+
+func TestController_Add_InvalidCommand(t *testing.T) {
+	controller := NewController(Options{})
+	err := controller.Add("ship", []string{})
+	assert.ErrorContains(t, err, "failed to start bot ship")
+}
+
+func TestController_Update(t *testing.T) {
+	instance := newTestGame("thrust")
+	controller := NewController(Options{Timeout: time.Second})
+	assert.NoError(t, controller.Add("thrust", testBotCommand(t, "thrust")))
+	defer controller.Close(1, game.Ended)
+
+	controller.Start(instance)
+	controller.Update(instance, 1, 50)
+
+	spaceship := spaceshipState(instance, "thrust")
+	assert.Equal(t, 100.0, spaceship["engine"].(map[string]interface{})["mainThrust"])
+	// setStartPosition is not allowed for the bots
+	assert.Equal(t, 100.0, spaceship["startPosition"].(map[string]interface{})["x"])
+	// Laser fired
+	assert.Len(t, instance.Serialize()["gameObjects"], 2)
+	assert.Equal(t, []Report{{Name: "thrust"}}, controller.Reports())
+}
+
+func TestController_FogOfWar(t *testing.T) {
+	for _, test := range []struct {
+		fogOfWar bool
+		expected float64
+	}{
+		{false, 2},
+		{true, 0},
+	} {
+		rules := game.DefaultRules()
+		rules.RadarRange = 100
+		instance := game.NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, rules)
+		instance.AddSpaceship("count", physics.Vector2{X: 100, Y: 400}, 0, "")
+		instance.AddSpaceship("other", physics.Vector2{X: 400, Y: 400}, 0, "")
+		instance.Start()
+		controller := NewController(Options{Timeout: time.Second, FogOfWar: test.fogOfWar})
+		assert.NoError(t, controller.Add("count", testBotCommand(t, "count")))
+
+		controller.Start(instance)
+		controller.Update(instance, 1, 50)
+		controller.Close(1, game.Ended)
+
+		spaceship := spaceshipState(instance, "count")
+		assert.Equal(t, test.expected, spaceship["engine"].(map[string]interface{})["mainThrust"])
+	}
+}
+
+func TestController_Crash(t *testing.T) {
+	instance := newTestGame("crash", "other")
+	controller := NewController(Options{Timeout: time.Second})
+	assert.NoError(t, controller.Add("crash", testBotCommand(t, "crash")))
+
+	controller.Start(instance)
+	controller.Update(instance, 1, 50)
+	controller.Close(1, game.Ended)
+
+	report := controller.Reports()[0]
+	assert.True(t, report.Disqualified)
+	assert.Contains(t, report.Reason, "tick 1: bot process exited")
+	assert.Equal(t, true, spaceshipState(instance, "crash")["destroyed"])
+	assert.Equal(t, false, spaceshipState(instance, "other")["destroyed"])
+}
+
+func TestController_InvalidStart(t *testing.T) {
+	instance := newTestGame("invalid", "other")
+	controller := NewController(Options{Timeout: time.Second})
+	assert.NoError(t, controller.Add("invalid", testBotCommand(t, "invalid")))
+
+	controller.Start(instance)
+	controller.Close(0, game.Ended)
+
+	report := controller.Reports()[0]
+	assert.True(t, report.Disqualified)
+	assert.Contains(t, report.Reason, "tick 0: invalid reply")
+	assert.Equal(t, true, spaceshipState(instance, "invalid")["destroyed"])
+}
+
+func TestController_Timeouts(t *testing.T) {
+	instance := newTestGame("lazy", "other")
+	controller := NewController(Options{Timeout: 10 * time.Millisecond, MaxTimeouts: 2})
+	assert.NoError(t, controller.Add("lazy", testBotCommand(t, "lazy")))
+
+	controller.Start(instance)
+	controller.Update(instance, 1, 50)
+
+	report := controller.Reports()[0]
+	assert.Equal(t, 1, report.Timeouts)
+	assert.False(t, report.Disqualified)
+	assert.Equal(t, false, spaceshipState(instance, "lazy")["destroyed"])
+
+	controller.Update(instance, 2, 50)
+	controller.Close(2, game.Ended)
+
+	report = controller.Reports()[0]
+	assert.Equal(t, 2, report.Timeouts)
+	assert.True(t, report.Disqualified)
+	assert.Equal(t, "tick 2: exceeded 2 timeouts", report.Reason)
+	assert.Equal(t, true, spaceshipState(instance, "lazy")["destroyed"])
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+)
+
+var (
+	ErrTimeout      = errors.New("bot did not reply in time")
+	ErrExited       = errors.New("bot process exited")
+	ErrUnresponsive = errors.New("bot is not reading its input")
+)
+
+// maxLineSize is the maximum size of a single reply line.
+const maxLineSize = 1024 * 1024
+
+// Process is a bot running as a subprocess, exchanging one JSON line
+// per message over its stdin and stdout.
+type Process struct {
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	outgoing chan []byte
+	lines    chan []byte
+	exited   chan struct{}
+	err      error
+}
+
+// Start spawns the bot process. The stderr of the bot is forwarded to the
+// given writer, or discarded when nil.
+func Start(command []string, stderr io.Writer) (*Process, error) {
+	if len(command) == 0 {
+		return nil, errors.New("bot command must not be empty")
+	}
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stderr = stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	process := &Process{
+		cmd:      cmd,
+		stdin:    stdin,
+		outgoing: make(chan []byte, 16),
+		lines:    make(chan []byte, 16),
+		exited:   make(chan struct{}),
+	}
+	go process.write()
+	go process.read(stdout)
+	return process, nil
+}
+
+// Send queues the message to be written to the bot. It never blocks,
+// a bot that does not read its input fails with ErrUnresponsive.
+func (process *Process) Send(request Request) error {
+	select {
+	case <-process.exited:
+		return ErrExited
+	default:
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case process.outgoing <- append(data, '\n'):
+		return nil
+	default:
+		return ErrUnresponsive
+	}
+}
+
+// Receive waits for the reply to the given tick, discarding replies to older ticks.
+func (process *Process) Receive(tick int64, timeout time.Duration) (Reply, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case line, ok := <-process.lines:
+			if !ok {
+				return Reply{}, fmt.Errorf("%w: %v", ErrExited, process.err)
+			}
+			reply := Reply{}
+			if err := json.Unmarshal(line, &reply); err != nil {
+				return Reply{}, fmt.Errorf("invalid reply: %w", err)
+			}
+			if reply.Tick != tick {
+				continue
+			}
+			return reply, nil
+		case <-timer.C:
+			return Reply{}, ErrTimeout
+		}
+	}
+}
+
+// Close closes the bot input and waits for the process to exit,
+// the process is killed if it does not exit within the grace period.
+func (process *Process) Close(grace time.Duration) {
+	close(process.outgoing)
+	// Drain the replies so the reader is never blocked on a chatty bot
+	go func() {
+		for range process.lines {
+		}
+	}()
+
+	select {
+	case <-process.exited:
+	case <-time.After(grace):
+		process.cmd.Process.Kill()
+		<-process.exited
+	}
+}
+
+func (process *Process) write() {
+	for data := range process.outgoing {
+		if _, err := process.stdin.Write(data); err != nil {
+			break
+		}
+	}
+	process.stdin.Close()
+	// Drain the queue so Close never blocks on a dead bot
+	for range process.outgoing {
+	}
+}
+
+func (process *Process) read(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := make([]byte, len(scanner.Bytes()))
+		copy(line, scanner.Bytes())
+		process.lines <- line
+	}
+
+	process.err = process.cmd.Wait()
+	if process.err == nil {
+		process.err = scanner.Err()
+	}
+	close(process.lines)
+	close(process.exited)
+}
@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStart_EmptyCommand(t *testing.T) {
+	_, err := Start([]string{}, nil)
+	assert.EqualError(t, err, "bot command must not be empty")
+}
+
+func TestStart_InvalidCommand(t *testing.T) {
+	_, err := Start([]string{"./does-not-exist"}, nil)
+	assert.Error(t, err)
+}
+
+func TestProcess_SendReceive(t *testing.T) {
+	process, err := Start(testBotCommand(t, "thrust"), nil)
+	assert.NoError(t, err)
+	defer process.Close(time.Second)
+
+	assert.NoError(t, process.Send(Request{Type: MessageTypeUpdate, Tick: 5}))
+	reply, err := process.Receive(5, time.Second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), reply.Tick)
+	assert.Equal(t, game.Action{Type: game.ActionSetEngineThrust, Args: []float64{100, 0, 0}}, reply.Actions[0])
+	assert.Equal(t, game.Action{Type: game.ActionFireLaser}, reply.Actions[1])
+}
+
+func TestProcess_Receive_DiscardsStaleReplies(t *testing.T) {
+	process, err := Start(testBotCommand(t, "stale"), nil)
+	assert.NoError(t, err)
+	defer process.Close(time.Second)
+
+	assert.NoError(t, process.Send(Request{Type: MessageTypeUpdate, Tick: 3}))
+	reply, err := process.Receive(3, time.Second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{50, 0, 0}, reply.Actions[0].Args)
+}
+
+func TestProcess_Receive_Timeout(t *testing.T) {
+	process, err := Start(testBotCommand(t, "lazy"), nil)
+	assert.NoError(t, err)
+	defer process.Close(time.Second)
+
+	assert.NoError(t, process.Send(Request{Type: MessageTypeUpdate, Tick: 1}))
+	_, err = process.Receive(1, 20*time.Millisecond)
+
+	assert.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestProcess_Receive_Exited(t *testing.T) {
+	process, err := Start(testBotCommand(t, "crash"), nil)
+	assert.NoError(t, err)
+	defer process.Close(time.Second)
+
+	assert.NoError(t, process.Send(Request{Type: MessageTypeUpdate, Tick: 1}))
+	_, err = process.Receive(1, time.Second)
+
+	assert.ErrorIs(t, err, ErrExited)
+	assert.ErrorIs(t, process.Send(Request{Type: MessageTypeUpdate, Tick: 2}), ErrExited)
+}
+
+func TestProcess_Receive_InvalidReply(t *testing.T) {
+	process, err := Start(testBotCommand(t, "invalid"), nil)
+	assert.NoError(t, err)
+	defer process.Close(time.Second)
+
+	assert.NoError(t, process.Send(Request{Type: MessageTypeStart}))
+	_, err = process.Receive(0, time.Second)
+
+	assert.ErrorContains(t, err, "invalid reply")
+}
@@ -0,0 +1,46 @@
+package bot
+
+import "github.com/davidhorak/space-wars/kernel/game"
+
+type MessageType string
+
+const (
+	MessageTypeStart  MessageType = "start"
+	MessageTypeUpdate MessageType = "update"
+	MessageTypeEnd    MessageType = "end"
+)
+
+// Request is a single JSON line sent from the kernel to the bot.
+//
+//   - start: sent once before the first tick, the bot must reply to acknowledge it's ready.
+//   - update: sent every tick, the bot replies with the actions to perform.
+//   - end: sent when the match is over, no reply is expected.
+type Request struct {
+	Type        MessageType            `json:"type"`
+	Tick        int64                  `json:"tick"`
+	DeltaTimeMs float64                `json:"deltaTimeMs,omitempty"`
+	Width       float64                `json:"width,omitempty"`
+	Height      float64                `json:"height,omitempty"`
+	Status      string                 `json:"status,omitempty"`
+	Rules       map[string]interface{} `json:"rules,omitempty"`
+	Spaceship   map[string]interface{} `json:"spaceship,omitempty"`
+	GameObjects []interface{}          `json:"gameObjects,omitempty"`
+}
+
+// Reply is a single JSON line sent from the bot to the kernel.
+// The tick must match the tick of the request, replies to older ticks are discarded.
+type Reply struct {
+	Tick    int64         `json:"tick"`
+	Actions []game.Action `json:"actions"`
+}
+
+// allowedActions are the actions a bot is allowed to perform,
+// the same set as the client's SpaceshipAction.
+var allowedActions = map[game.ActionType]bool{
+	game.ActionSetEngineThrust:   true,
+	game.ActionSetRotationThrust: true,
+	game.ActionFireLaser:         true,
+	game.ActionFireRocket:        true,
+	game.ActionDropMine:          true,
+	game.ActionSetShield:         true,
+}
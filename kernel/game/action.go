@@ -0,0 +1,127 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+type ActionType string
+
+const (
+	ActionSetEngineThrust   ActionType = "setEngineThrust"
+	ActionSetRotationThrust ActionType = "setRotationThrust"
+	ActionSetStartPosition  ActionType = "setStartPosition"
+	ActionFireLaser         ActionType = "fireLaser"
+	ActionFireRocket        ActionType = "fireRocket"
+	ActionDropMine          ActionType = "dropMine"
+	ActionSetShield         ActionType = "setShield"
+)
+
+// Action is a data representation of a spaceship action.
+// It is serialized as a tuple, the same way as the client does,
+// e.g. ["setEngineThrust", 100, 0, 0] or ["fireLaser"].
+type Action struct {
+	Type ActionType
+	Args []float64
+}
+
+func (action Action) MarshalJSON() ([]byte, error) {
+	tuple := make([]interface{}, 0, len(action.Args)+1)
+	tuple = append(tuple, action.Type)
+	for _, arg := range action.Args {
+		tuple = append(tuple, arg)
+	}
+	return json.Marshal(tuple)
+}
+
+func (action *Action) UnmarshalJSON(data []byte) error {
+	tuple := []json.RawMessage{}
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	if len(tuple) == 0 {
+		return errors.New("action must not be empty")
+	}
+
+	var actionType string
+	if err := json.Unmarshal(tuple[0], &actionType); err != nil {
+		return fmt.Errorf("invalid action type: %s", tuple[0])
+	}
+
+	var args []float64
+	if len(tuple) > 1 {
+		args = make([]float64, len(tuple)-1)
+	}
+	for i, raw := range tuple[1:] {
+		if err := json.Unmarshal(raw, &args[i]); err != nil {
+			return fmt.Errorf("%s invalid argument %d value: %s, expected number", actionType, i, raw)
+		}
+	}
+
+	action.Type = ActionType(actionType)
+	action.Args = args
+	return nil
+}
+
+// Apply performs the action on the spaceship.
+func (action Action) Apply(spaceShip *Spaceship, gameManager *GameManager) error {
+	switch action.Type {
+	case ActionSetEngineThrust:
+		if err := action.requireArgs(3); err != nil {
+			return err
+		}
+		return spaceShip.SetEngineThrust(action.Args[0], action.Args[1], action.Args[2])
+	case ActionSetRotationThrust:
+		if err := action.requireArgs(1); err != nil {
+			return err
+		}
+		return spaceShip.SetRotationThrust(action.Args[0])
+	case ActionSetStartPosition:
+		if err := action.requireArgs(3); err != nil {
+			return err
+		}
+		spaceShip.SetStartPosition(physics.Vector2{X: action.Args[0], Y: action.Args[1]})
+		spaceShip.SetStartRotation(action.Args[2])
+		return nil
+	case ActionFireLaser:
+		return spaceShip.FireLaser(gameManager)
+	case ActionFireRocket:
+		return spaceShip.FireRocket(gameManager)
+	case ActionDropMine:
+		return spaceShip.DropMine(gameManager)
+	case ActionSetShield:
+		if err := action.requireArgs(1); err != nil {
+			return err
+		}
+		return spaceShip.SetShield(action.Args[0] != 0)
+	default:
+		return fmt.Errorf("invalid action: %s", action.Type)
+	}
+}
+
+func (action Action) requireArgs(count int) error {
+	if len(action.Args) < count {
+		return fmt.Errorf("%s() expects %d arguments, got %d", action.Type, count, len(action.Args))
+	}
+	return nil
+}
+
+// ApplyAction performs the action on the spaceship with the given name.
+func (game *Game) ApplyAction(name string, action Action) error {
+	var actionErr error
+	err := game.spaceshipAction(name, func(spaceShip *Spaceship, gameManager *GameManager) {
+		actionErr = action.Apply(spaceShip, gameManager)
+	})
+	if err != nil {
+		return err
+	}
+	if actionErr != nil {
+		return actionErr
+	}
+	// Failed actions do not change the state, only the applied ones are recorded
+	game.record(ReplayEvent{Type: ReplayEventAction, Ship: name, Action: action})
+	return nil
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAction_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Action{Type: ActionSetEngineThrust, Args: []float64{100, 0, 50}})
+	assert.NoError(t, err)
+	assert.Equal(t, `["setEngineThrust",100,0,50]`, string(data))
+
+	data, err = json.Marshal(Action{Type: ActionFireLaser})
+	assert.NoError(t, err)
+	assert.Equal(t, `["fireLaser"]`, string(data))
+}
+
+func TestAction_UnmarshalJSON(t *testing.T) {
+	action := Action{}
+	err := json.Unmarshal([]byte(`["setEngineThrust", 100, 0, 50]`), &action)
+	assert.NoError(t, err)
+	assert.Equal(t, Action{Type: ActionSetEngineThrust, Args: []float64{100, 0, 50}}, action)
+
+	var tests = []struct {
+		data        string
+		expectedErr string
+	}{
+		{`[]`, "action must not be empty"},
+		{`[1]`, "invalid action type: 1"},
+		{`["setEngineThrust", "100"]`, `setEngineThrust invalid argument 0 value: "100", expected number`},
+	}
+
+	for _, test := range tests {
+		err := json.Unmarshal([]byte(test.data), &action)
+		assert.EqualError(t, err, test.expectedErr)
+	}
+}
+
+func TestAction_Apply(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, DefaultRules())
+
+	err := Action{Type: ActionSetEngineThrust, Args: []float64{100, 50, 25}}.Apply(ship, &gameManager)
+	assert.NoError(t, err)
+	assert.Equal(t, Engine{mainThrust: 100, leftThrust: 50, rightThrust: 25}, ship.engine)
+
+	err = Action{Type: ActionSetRotationThrust, Args: []float64{-50}}.Apply(ship, &gameManager)
+	assert.NoError(t, err)
+	assert.Equal(t, -50.0, ship.engine.rotationThrust)
+
+	err = Action{Type: ActionSetRotationThrust}.Apply(ship, &gameManager)
+	assert.EqualError(t, err, "setRotationThrust() expects 1 arguments, got 0")
+
+	err = Action{Type: ActionSetStartPosition, Args: []float64{10, 20, 1}}.Apply(ship, &gameManager)
+	assert.NoError(t, err)
+	assert.Equal(t, physics.Vector2{X: 10, Y: 20}, ship.startPosition)
+	assert.Equal(t, 1.0, ship.startRotation)
+
+	err = Action{Type: ActionFireLaser}.Apply(ship, &gameManager)
+	assert.NoError(t, err)
+	err = Action{Type: ActionFireRocket}.Apply(ship, &gameManager)
+	assert.NoError(t, err)
+	err = Action{Type: ActionDropMine}.Apply(ship, &gameManager)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, gameManager.GameObjectSize())
+
+	err = Action{Type: ActionSetShield, Args: []float64{1}}.Apply(ship, &gameManager)
+	assert.NoError(t, err)
+	assert.True(t, ship.shield)
+	err = Action{Type: ActionSetShield, Args: []float64{0}}.Apply(ship, &gameManager)
+	assert.NoError(t, err)
+	assert.False(t, ship.shield)
+
+	err = Action{Type: ActionSetEngineThrust, Args: []float64{100}}.Apply(ship, &gameManager)
+	assert.EqualError(t, err, "setEngineThrust() expects 3 arguments, got 1")
+
+	err = Action{Type: "unknown"}.Apply(ship, &gameManager)
+	assert.EqualError(t, err, "invalid action: unknown")
+}
+
+func TestGame_ApplyAction(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0, "")
+
+	err := game.ApplyAction("test", Action{Type: ActionSetEngineThrust, Args: []float64{100, 0, 0}})
+	assert.NoError(t, err)
+
+	spaceship, _ := game.manager.GetSpaceship("test")
+	assert.Equal(t, 100.0, spaceship.engine.mainThrust)
+
+	err = game.ApplyAction("test", Action{Type: ActionSetEngineThrust, Args: []float64{200, 0, 0}})
+	assert.EqualError(t, err, "main thrust must be between 0 and 100")
+
+	err = game.ApplyAction("unknown", Action{Type: ActionFireLaser})
+	assert.EqualError(t, err, "space ship not found: unknown")
+}
@@ -1,28 +1,53 @@
 package game
 
 import (
+	"math"
+
 	"github.com/davidhorak/space-wars/kernel/physics"
 	"github.com/davidhorak/space-wars/kernel/physics/collider"
 )
 
 type Asteroid struct {
-	id       int64
-	enabled  bool
-	position physics.Vector2
-	radius   float64
-	collider collider.CircleCollider
+	id              int64
+	enabled         bool
+	position        physics.Vector2
+	startPosition   physics.Vector2
+	radius          float64
+	rotation        float64
+	velocity        physics.Vector2
+	startVelocity   physics.Vector2 // The physical collisions push the asteroids, see Game.bounce
+	angularVelocity float64         // Radians per second
+	damage          float64         // Taken from the projectiles, see Rules.AsteroidHealth
+	fragment        bool            // Split off another asteroid, removed on reset
+	// Relative to the position, unrotated. Empty for the circles.
+	vertices []physics.Vector2
+	collider collider.Collider
 }
 
 func NewAsteroid(id int64, position physics.Vector2, radius float64) *Asteroid {
 	return &Asteroid{
-		id:       id,
-		enabled:  true,
-		position: position,
-		radius:   radius,
-		collider: *collider.NewCircleCollider(position, radius),
+		id:            id,
+		enabled:       true,
+		position:      position,
+		startPosition: position,
+		radius:        radius,
+		vertices:      []physics.Vector2{},
+		collider:      collider.NewCircleCollider(position, radius),
 	}
 }
 
+// NewPolygonAsteroid creates an irregular asteroid, the vertices are clockwise ordered,
+// relative to the position. The radius is of the circle around the vertices.
+func NewPolygonAsteroid(id int64, position physics.Vector2, vertices []physics.Vector2) *Asteroid {
+	asteroid := NewAsteroid(id, position, 0)
+	for _, vertex := range vertices {
+		asteroid.radius = math.Max(asteroid.radius, vertex.Magnitude())
+	}
+	asteroid.vertices = vertices
+	asteroid.collider = collider.NewPolygonCollider(position, 0, physics.Polygon{Vertices: vertices})
+	return asteroid
+}
+
 func (asteroid *Asteroid) ID() int64 {
 	return asteroid.id
 }
@@ -41,17 +66,132 @@ func (asteroid *Asteroid) Position() physics.Vector2 {
 
 func (asteroid *Asteroid) SetPosition(position physics.Vector2) {
 	asteroid.position = position
+	asteroid.collider.SetPosition(position)
+}
+
+// SetMotion sets the velocity, per second, and the spin of the asteroid.
+func (asteroid *Asteroid) SetMotion(velocity physics.Vector2, angularVelocity float64) {
+	asteroid.velocity = velocity
+	asteroid.startVelocity = velocity
+	asteroid.angularVelocity = angularVelocity
+}
+
+// Reset brings the asteroid back to its start position, the fragments are removed instead,
+// see GameManager.Reset.
+func (asteroid *Asteroid) Reset() {
+	asteroid.enabled = true
+	asteroid.SetPosition(asteroid.startPosition)
+	asteroid.velocity = asteroid.startVelocity
+	asteroid.rotation = 0
+	asteroid.collider.SetRotation(0)
+	asteroid.damage = 0
+}
+
+// Area returns the area of the circle or of the polygon of the asteroid.
+func (asteroid *Asteroid) Area() float64 {
+	if len(asteroid.vertices) == 0 {
+		return math.Pi * asteroid.radius * asteroid.radius
+	}
+	polygon := physics.Polygon{Vertices: asteroid.vertices}
+	return polygon.Area()
 }
 
-func (asteroid *Asteroid) Update(deltaTimeMs float64, gameManager *GameManager) {}
+func (asteroid *Asteroid) Update(deltaTimeMs float64, gameManager *GameManager) {
+	deltaTimeSec := deltaTimeMs / 1000
+	asteroid.rotation = math.Remainder(asteroid.rotation+asteroid.angularVelocity*deltaTimeSec, 2*math.Pi)
+	asteroid.collider.SetRotation(asteroid.rotation)
+	asteroid.SetPosition(asteroid.position.Add(asteroid.velocity.Multiply(deltaTimeSec)))
+}
 
 func (asteroid *Asteroid) Collider() collider.Collider {
-	return &asteroid.collider
+	return asteroid.collider
 }
 
-func (asteroid *Asteroid) OnCollision(other GameObject, gameManager *GameManager, order int) {}
+// OnCollision takes the hits of the projectiles: the rockets split the asteroid with the split rule,
+// the damage destroys it with the asteroid health rule. The asteroids pass through each other.
+func (asteroid *Asteroid) OnCollision(other GameObject, gameManager *GameManager, order int) {
+	projectile, ok := other.(*Projectile)
+	if !ok {
+		return
+	}
+
+	rules := gameManager.rules
+	if projectile.damageType == DamageTypeRocket && rules.AsteroidSplit {
+		asteroid.split(projectile.velocity, gameManager)
+		return
+	}
+	if rules.AsteroidHealth <= 0 {
+		return
+	}
+	asteroid.damage += projectile.damage
+	if asteroid.damage >= rules.AsteroidHealth {
+		asteroid.destroy(gameManager)
+	}
+}
+
+// split breaks the asteroid into two fragments of half its area and of its shape, pushed apart across
+// the direction of the impact. The fragments smaller than the minimum asteroid size are not created.
+func (asteroid *Asteroid) split(impact physics.Vector2, gameManager *GameManager) {
+	asteroid.destroy(gameManager)
+	radius := asteroid.radius / math.Sqrt2
+	if radius < gameManager.rules.MinAsteroidSize {
+		return
+	}
+
+	across := physics.Vector2{X: 1, Y: 0}
+	if impact.X != 0 || impact.Y != 0 {
+		across = impact.Normalize()
+	}
+	across = across.Rotate(math.Pi / 2)
+	for _, side := range []float64{1, -1} {
+		offset := across.Multiply(side * radius)
+		push := across.Multiply(side * gameManager.rules.AsteroidSplitVelocitySec)
+		position := physics.Wrap(asteroid.position.Add(offset), gameManager.Size())
+		var fragment *Asteroid
+		if len(asteroid.vertices) > 0 {
+			fragment = NewPolygonAsteroid(gameManager.NewID(), position, scale(asteroid.vertices, 1/math.Sqrt2))
+		} else {
+			fragment = NewAsteroid(gameManager.NewID(), position, radius)
+		}
+		fragment.rotation = asteroid.rotation
+		fragment.collider.SetRotation(asteroid.rotation)
+		fragment.SetMotion(asteroid.velocity.Add(push), asteroid.angularVelocity*side)
+		fragment.fragment = true
+		gameManager.AddGameObject(fragment)
+	}
+}
+
+func scale(vertices []physics.Vector2, factor float64) []physics.Vector2 {
+	scaled := make([]physics.Vector2, len(vertices))
+	for i, vertex := range vertices {
+		scaled[i] = vertex.Multiply(factor)
+	}
+	return scaled
+}
+
+// destroy disables the asteroid, so it comes back on reset.
+func (asteroid *Asteroid) destroy(gameManager *GameManager) {
+	asteroid.enabled = false
+	gameManager.AddGameObject(NewExplosion(
+		gameManager.NewID(),
+		physics.Vector2{
+			X: asteroid.position.X - asteroid.radius,
+			Y: asteroid.position.Y - asteroid.radius,
+		},
+		asteroid.radius,
+		gameManager.rules.ShipExplosionDurationSec,
+	))
+}
 
 func (asteroid *Asteroid) Serialize() map[string]interface{} {
+	vertices := make([]interface{}, len(asteroid.vertices))
+	for i, vertex := range asteroid.vertices {
+		vertices[i] = map[string]interface{}{
+			"x": vertex.X,
+			"y": vertex.Y,
+		}
+	}
+
 	return map[string]interface{}{
 		"type":    "asteroid",
 		"id":      asteroid.id,
@@ -60,7 +200,24 @@ func (asteroid *Asteroid) Serialize() map[string]interface{} {
 			"x": asteroid.position.X,
 			"y": asteroid.position.Y,
 		},
+		"startPosition": map[string]interface{}{
+			"x": asteroid.startPosition.X,
+			"y": asteroid.startPosition.Y,
+		},
 		"radius":   asteroid.radius,
-		"collider": asteroid.collider.Serialize(),
+		"rotation": asteroid.rotation,
+		"velocity": map[string]interface{}{
+			"x": asteroid.velocity.X,
+			"y": asteroid.velocity.Y,
+		},
+		"startVelocity": map[string]interface{}{
+			"x": asteroid.startVelocity.X,
+			"y": asteroid.startVelocity.Y,
+		},
+		"angularVelocity": asteroid.angularVelocity,
+		"damage":          asteroid.damage,
+		"fragment":        asteroid.fragment,
+		"vertices":        vertices,
+		"collider":        asteroid.collider.Serialize(),
 	}
 }
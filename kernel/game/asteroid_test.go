@@ -1,9 +1,11 @@
 package game
 
 import (
+	"math"
 	"testing"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,7 +57,181 @@ func TestAsteroid_Serialize(t *testing.T) {
 			"x": 10.0,
 			"y": 20.0,
 		},
+		"startPosition": map[string]interface{}{
+			"x": 10.0,
+			"y": 20.0,
+		},
 		"radius":   radius,
-		"collider": asteroid.collider.Serialize(),
+		"rotation": 0.0,
+		"velocity": map[string]interface{}{
+			"x": 0.0,
+			"y": 0.0,
+		},
+		"startVelocity": map[string]interface{}{
+			"x": 0.0,
+			"y": 0.0,
+		},
+		"angularVelocity": 0.0,
+		"damage":          0.0,
+		"fragment":        false,
+		"vertices":        []interface{}{},
+		"collider":        asteroid.collider.Serialize(),
 	}, asteroid.Serialize())
 }
+
+func TestNewPolygonAsteroid(t *testing.T) {
+	vertices := []physics.Vector2{{X: 0, Y: -10}, {X: 8, Y: 6}, {X: 0, Y: 2}, {X: -6, Y: 8}}
+	asteroid := NewPolygonAsteroid(1, physics.Vector2{X: 100, Y: 100}, vertices)
+
+	assert.Equal(t, 10.0, asteroid.radius)
+	assert.IsType(t, &collider.PolygonCollider{}, asteroid.Collider())
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"x": 0.0, "y": -10.0},
+		map[string]interface{}{"x": 8.0, "y": 6.0},
+		map[string]interface{}{"x": 0.0, "y": 2.0},
+		map[string]interface{}{"x": -6.0, "y": 8.0},
+	}, asteroid.Serialize()["vertices"])
+
+	// Collides by its shape, not by the circle around it
+	ship := collider.NewCircleCollider(physics.Vector2{X: 100, Y: 106}, 2)
+	assert.False(t, asteroid.Collider().CollidesWith(ship))
+	ship.SetPosition(physics.Vector2{X: 100, Y: 98})
+	assert.True(t, asteroid.Collider().CollidesWith(ship))
+
+	// Rotates with the asteroid
+	asteroid.SetMotion(physics.Vector2{}, math.Pi)
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1, DefaultRules())
+	asteroid.Update(1000, &game.manager)
+	ship.SetPosition(physics.Vector2{X: 100, Y: 106})
+	assert.True(t, asteroid.Collider().CollidesWith(ship))
+}
+
+func TestAsteroid_Update(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1, DefaultRules())
+	asteroid := NewAsteroid(1, physics.Vector2{X: 10, Y: 20}, 5)
+	asteroid.SetMotion(physics.Vector2{X: 10, Y: -20}, math.Pi)
+
+	asteroid.Update(500, &game.manager)
+
+	assert.Equal(t, physics.Vector2{X: 15, Y: 10}, asteroid.Position())
+	assert.Equal(t, physics.Vector2{X: 15, Y: 10}, asteroid.collider.Position())
+	assert.InDelta(t, math.Pi/2, asteroid.rotation, 1e-9)
+}
+
+func TestAsteroid_Split(t *testing.T) {
+	rules := DefaultRules()
+	rules.AsteroidSplit = true
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1, rules)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 100, Y: 100}, 0, rules)
+	asteroid := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 500, Y: 500}, 30)
+	asteroid.SetMotion(physics.Vector2{X: 5, Y: 0}, 1)
+	game.manager.AddGameObject(asteroid)
+
+	asteroid.OnCollision(NewRocketProjectile(game.manager.NewID(), physics.Vector2{X: 470, Y: 500}, 0, owner), &game.manager, 1)
+
+	assert.False(t, asteroid.Enabled())
+	fragments := []*Asteroid{}
+	for _, gameObject := range game.manager.GameObjects() {
+		if fragment, ok := gameObject.(*Asteroid); ok && fragment.fragment {
+			fragments = append(fragments, fragment)
+		}
+	}
+	assert.Len(t, fragments, 2)
+	for _, fragment := range fragments {
+		assert.InDelta(t, 30/math.Sqrt2, fragment.radius, 1e-9)
+	}
+	// Pushed apart in the opposite directions, keeping the motion of the asteroid
+	assert.InDelta(t, fragments[0].velocity.X+fragments[1].velocity.X, 10, 1e-9)
+	assert.InDelta(t, fragments[0].velocity.Y, -fragments[1].velocity.Y, 1e-9)
+	assert.Equal(t, -fragments[0].angularVelocity, fragments[1].angularVelocity)
+
+	// Too small to split any further
+	small := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 200, Y: 200}, MinAsteroidSize)
+	game.manager.AddGameObject(small)
+	size := game.manager.GameObjectSize()
+	small.OnCollision(NewRocketProjectile(game.manager.NewID(), physics.Vector2{X: 190, Y: 200}, 0, owner), &game.manager, 1)
+	assert.False(t, small.Enabled())
+	assert.Equal(t, size+1, game.manager.GameObjectSize(), "only the explosion")
+
+	// The lasers do not split
+	laser := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 800, Y: 800}, 30)
+	laser.OnCollision(NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 770, Y: 800}, 0, owner), &game.manager, 1)
+	assert.True(t, laser.Enabled())
+}
+
+func TestAsteroid_SplitPolygon(t *testing.T) {
+	rules := DefaultRules()
+	rules.AsteroidSplit = true
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1, rules)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 100, Y: 100}, 0, rules)
+	vertices := []physics.Vector2{{X: 0, Y: -30}, {X: 30, Y: 0}, {X: 0, Y: 30}, {X: -30, Y: 0}}
+	asteroid := NewPolygonAsteroid(game.manager.NewID(), physics.Vector2{X: 500, Y: 500}, vertices)
+	game.manager.AddGameObject(asteroid)
+
+	asteroid.OnCollision(NewRocketProjectile(game.manager.NewID(), physics.Vector2{X: 470, Y: 500}, 0, owner), &game.manager, 1)
+
+	fragment := game.manager.GameObjects()[2].(*Asteroid)
+	assert.True(t, fragment.fragment)
+	assert.InDelta(t, 30/math.Sqrt2, fragment.radius, 1e-9)
+	assert.InDelta(t, 30/math.Sqrt2, fragment.vertices[1].X, 1e-9)
+	assert.IsType(t, &collider.PolygonCollider{}, fragment.Collider())
+}
+
+func TestAsteroid_Health(t *testing.T) {
+	rules := DefaultRules()
+	rules.AsteroidHealth = LaserDamage * 2
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1, rules)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 100, Y: 100}, 0, rules)
+	asteroid := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 500, Y: 500}, 30)
+	game.manager.AddGameObject(asteroid)
+
+	asteroid.OnCollision(NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 470, Y: 500}, 0, owner), &game.manager, 1)
+	assert.True(t, asteroid.Enabled())
+	assert.Equal(t, float64(LaserDamage), asteroid.damage)
+
+	asteroid.OnCollision(NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 470, Y: 500}, 0, owner), &game.manager, 1)
+	assert.False(t, asteroid.Enabled())
+	_, ok := game.manager.GameObjects()[1].(*Explosion)
+	assert.True(t, ok)
+
+	// Indestructible by default
+	asteroid = NewAsteroid(game.manager.NewID(), physics.Vector2{X: 500, Y: 500}, 30)
+	rules.AsteroidHealth = 0
+	game.manager.rules = rules
+	asteroid.OnCollision(NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 470, Y: 500}, 0, owner), &game.manager, 1)
+	assert.True(t, asteroid.Enabled())
+	assert.Equal(t, 0.0, asteroid.damage)
+}
+
+func TestAsteroid_Area(t *testing.T) {
+	asteroid := NewAsteroid(1, physics.Vector2{X: 500, Y: 500}, 10)
+	assert.InDelta(t, math.Pi*100, asteroid.Area(), 1e-9)
+
+	asteroid = NewPolygonAsteroid(2, physics.Vector2{X: 500, Y: 500}, []physics.Vector2{
+		{X: -10, Y: -10}, {X: 10, Y: -10}, {X: 10, Y: 10}, {X: -10, Y: 10},
+	})
+	assert.InDelta(t, 400, asteroid.Area(), 1e-9)
+}
+
+func TestAsteroid_Reset(t *testing.T) {
+	rules := DefaultRules()
+	rules.AsteroidSplit = true
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1, rules)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 100, Y: 100}, 0, rules)
+	asteroid := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 500, Y: 500}, 30)
+	asteroid.SetMotion(physics.Vector2{X: 5, Y: 0}, 1)
+	game.manager.AddGameObject(asteroid)
+
+	game.Start()
+	game.Update(1000)
+	asteroid.OnCollision(NewRocketProjectile(game.manager.NewID(), physics.Vector2{X: 470, Y: 500}, 0, owner), &game.manager, 1)
+	// Pushed by a spaceship in the physical collisions
+	asteroid.velocity = physics.Vector2{X: -20, Y: 3}
+	game.Reset()
+
+	assert.Equal(t, []GameObject{asteroid}, game.manager.GameObjects())
+	assert.True(t, asteroid.Enabled())
+	assert.Equal(t, physics.Vector2{X: 500, Y: 500}, asteroid.Position())
+	assert.Equal(t, 0.0, asteroid.rotation)
+	assert.Equal(t, physics.Vector2{X: 5, Y: 0}, asteroid.velocity)
+}
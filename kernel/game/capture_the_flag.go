@@ -0,0 +1,192 @@
+package game
+
+import (
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// captureTheFlag gives each side a flag at the start positions of its spaceships.
+// A spaceship picks up the enemy flags it touches and captures them by touching its own flag
+// at its base, a destroyed carrier drops the flags. Touching the own dropped flag returns it to its base.
+type captureTheFlag struct {
+	respawning
+}
+
+func (mode *captureTheFlag) Mode() Mode {
+	return ModeCaptureTheFlag
+}
+
+// Setup places the flags of the sides without one, in the middle of the start positions of their spaceships.
+func (mode *captureTheFlag) Setup(gameManager *GameManager) {
+	placed := map[string]bool{}
+	for _, flag := range mode.flags(gameManager) {
+		placed[flag.side] = true
+	}
+
+	sides := []string{}
+	bases := map[string]physics.Vector2{}
+	counts := map[string]float64{}
+	for _, gameObject := range gameManager.GameObjects() {
+		ship, ok := gameObject.(*Spaceship)
+		if !ok || placed[ship.side()] {
+			continue
+		}
+		if _, ok := bases[ship.side()]; !ok {
+			sides = append(sides, ship.side())
+		}
+		base := bases[ship.side()]
+		bases[ship.side()] = base.Add(ship.startPosition)
+		counts[ship.side()]++
+	}
+
+	for _, side := range sides {
+		base := bases[side]
+		base = base.Multiply(1 / counts[side])
+		gameManager.AddGameObject(NewFlag(gameManager.NewID(), side, base))
+	}
+}
+
+func (mode *captureTheFlag) Update(deltaTimeMs float64, gameManager *GameManager) {
+	mode.respawning.Update(deltaTimeMs, gameManager)
+	flags := mode.flags(gameManager)
+	for _, flag := range flags {
+		if flag.carrier != nil {
+			flag.position = flag.carrier.position
+		}
+	}
+
+	for _, gameObject := range gameManager.GameObjects() {
+		ship, ok := gameObject.(*Spaceship)
+		if !ok || !ship.Enabled() {
+			continue
+		}
+		for _, flag := range flags {
+			if flag.carrier != nil || physics.WrappedDistance(ship.position, flag.position, gameManager.Size()) > gameManager.rules.FlagRadius {
+				continue
+			}
+			switch {
+			case flag.side != ship.side():
+				flag.carrier = ship
+			case flag.position != flag.base:
+				flag.position = flag.base
+			default:
+				mode.capture(ship, flags, gameManager)
+			}
+		}
+	}
+}
+
+// capture scores the enemy flags the spaceship brought to its own flag, the flags return to their bases.
+func (mode *captureTheFlag) capture(ship *Spaceship, flags []*Flag, gameManager *GameManager) {
+	for _, flag := range flags {
+		if flag.carrier != ship {
+			continue
+		}
+		flag.carrier = nil
+		flag.position = flag.base
+		ship.objective++
+		ship.AddScore(gameManager.rules.FlagCaptureScore)
+	}
+}
+
+// OnShipDestroyed drops the flags the spaceship carries where it was destroyed.
+func (mode *captureTheFlag) OnShipDestroyed(ship *Spaceship, gameManager *GameManager) {
+	mode.respawning.OnShipDestroyed(ship, gameManager)
+	for _, flag := range mode.flags(gameManager) {
+		if flag.carrier == ship {
+			flag.carrier = nil
+			flag.position = ship.position
+		}
+	}
+}
+
+func (mode *captureTheFlag) HasEnded(gameManager *GameManager) EndReason {
+	return mode.hasEnded(mode, gameManager)
+}
+
+// Objective returns the number of the flags the spaceship captured.
+func (mode *captureTheFlag) Objective(ship *Spaceship) float64 {
+	return ship.objective
+}
+
+func (mode *captureTheFlag) flags(gameManager *GameManager) []*Flag {
+	flags := []*Flag{}
+	for _, gameObject := range gameManager.GameObjects() {
+		if flag, ok := gameObject.(*Flag); ok {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// Flag is the flag of a side in the capture the flag, it does not collide with anything.
+type Flag struct {
+	id       int64
+	enabled  bool
+	side     string
+	base     physics.Vector2
+	position physics.Vector2
+	carrier  *Spaceship
+}
+
+func NewFlag(id int64, side string, base physics.Vector2) *Flag {
+	return &Flag{
+		id:       id,
+		enabled:  true,
+		side:     side,
+		base:     base,
+		position: base,
+	}
+}
+
+func (flag *Flag) ID() int64 {
+	return flag.id
+}
+
+func (flag *Flag) Enabled() bool {
+	return flag.enabled
+}
+
+func (flag *Flag) SetEnabled(enabled bool) {
+	flag.enabled = enabled
+}
+
+func (flag *Flag) Position() physics.Vector2 {
+	return flag.position
+}
+
+func (flag *Flag) SetPosition(position physics.Vector2) {
+	flag.position = position
+}
+
+func (flag *Flag) Update(deltaTimeMs float64, gameManager *GameManager) {}
+
+func (flag *Flag) Collider() collider.Collider {
+	return nil
+}
+
+func (flag *Flag) OnCollision(other GameObject, gameManager *GameManager, order int) {}
+
+func (flag *Flag) Serialize() map[string]interface{} {
+	carrier := int64(0)
+	if flag.carrier != nil {
+		carrier = flag.carrier.ID()
+	}
+
+	return map[string]interface{}{
+		"type":    "flag",
+		"id":      flag.id,
+		"enabled": flag.enabled,
+		"side":    flag.side,
+		"position": map[string]interface{}{
+			"x": flag.position.X,
+			"y": flag.position.Y,
+		},
+		"base": map[string]interface{}{
+			"x": flag.base.X,
+			"y": flag.base.Y,
+		},
+		// ID of the spaceship carrying the flag, 0 when none
+		"carrier": carrier,
+	}
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureTheFlag(t *testing.T) {
+	game := newModeTestGame(ModeCaptureTheFlag, 2)
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 100}, 0, "red")
+	game.AddSpaceship("B", physics.Vector2{X: 900, Y: 860}, 0, "blue")
+	game.AddSpaceship("C", physics.Vector2{X: 900, Y: 940}, 0, "blue")
+	game.Start()
+	shipA, _ := game.manager.GetSpaceship("A")
+	shipB, _ := game.manager.GetSpaceship("B")
+	shipC, _ := game.manager.GetSpaceship("C")
+	flags := (&captureTheFlag{}).flags(&game.manager)
+	assert.Len(t, flags, 2)
+	assert.Equal(t, "red", flags[0].side)
+	assert.Equal(t, physics.Vector2{X: 100, Y: 100}, flags[0].base)
+	assert.Equal(t, "blue", flags[1].side)
+	assert.Equal(t, physics.Vector2{X: 900, Y: 900}, flags[1].base)
+
+	// Pick up
+	shipB.position = physics.Vector2{X: 500, Y: 300}
+	shipC.position = physics.Vector2{X: 500, Y: 700}
+	shipA.position = physics.Vector2{X: 900, Y: 900}
+	game.Update(16)
+	assert.Same(t, shipA, flags[1].carrier)
+
+	// Drop
+	shipA.position = physics.Vector2{X: 600, Y: 600}
+	game.Update(16)
+	shipA.TakeDamage(MaxHealth, DamageTypeUnknown, &game.manager, shipB)
+	assert.Nil(t, flags[1].carrier)
+	assert.Equal(t, physics.Vector2{X: 600, Y: 600}, flags[1].position)
+
+	// Return
+	shipC.position = physics.Vector2{X: 610, Y: 600}
+	game.Update(16)
+	assert.Equal(t, flags[1].base, flags[1].position)
+	assert.Nil(t, flags[1].carrier)
+
+	// Capture
+	for i := 0; i < RespawnDelaySec*1000/16; i++ {
+		game.Update(16)
+	}
+	assert.True(t, shipA.Enabled())
+	shipA.position = physics.Vector2{X: 900, Y: 900}
+	game.Update(16)
+	assert.Same(t, shipA, flags[1].carrier)
+	shipA.position = physics.Vector2{X: 100, Y: 100}
+	game.Update(16)
+	assert.Nil(t, flags[1].carrier)
+	assert.Equal(t, flags[1].base, flags[1].position)
+	assert.Equal(t, float64(1), shipA.objective)
+	assert.Equal(t, float64(FlagCaptureScore), shipA.score)
+	assert.Equal(t, Running, game.Status())
+
+	shipA.position = physics.Vector2{X: 900, Y: 900}
+	game.Update(16)
+	shipA.position = physics.Vector2{X: 100, Y: 100}
+	game.Update(16)
+	assert.Equal(t, Ended, game.Status())
+	assert.Equal(t, EndReasonObjective, game.EndReason())
+	assert.Equal(t, "red", game.Winner())
+}
@@ -0,0 +1,226 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+type collision struct {
+	a GameObject
+	b GameObject
+	// Fraction of the tick (0 to 1) at which the objects collided
+	time float64
+}
+
+// resolveCollisions detects all the collisions of the tick and resolves them in the order
+// they happened, so a projectile hits the first object along its path.
+func (game *Game) resolveCollisions() {
+	// The collisions add and remove game objects, work on a copy.
+	gameObjects := append([]GameObject{}, game.manager.GameObjects()...)
+
+	collisions := []collision{}
+	for _, pair := range game.collisionPairs(gameObjects) {
+		a, b := gameObjects[pair.A], gameObjects[pair.B]
+		if time, hit := game.collide(a, b); hit {
+			collisions = append(collisions, collision{a: a, b: b, time: time})
+		}
+	}
+	sort.SliceStable(collisions, func(i, j int) bool {
+		return collisions[i].time < collisions[j].time
+	})
+
+	for _, collision := range collisions {
+		if !collision.a.Enabled() || !collision.b.Enabled() {
+			continue
+		}
+		for _, gameObject := range []GameObject{collision.a, collision.b} {
+			if projectile, ok := gameObject.(*Projectile); ok {
+				projectile.rewind(collision.time)
+			}
+		}
+		if game.manager.rules.CollisionResponse == CollisionResponsePhysical && game.bounce(collision.a, collision.b) {
+			continue
+		}
+		collision.a.OnCollision(collision.b, &game.manager, 0)
+		collision.b.OnCollision(collision.a, &game.manager, 1)
+	}
+}
+
+// bounce resolves the physical collision of two bodies, a spaceship and another spaceship or an asteroid.
+// The impulse along the contact normal exchanges their momentum by their mass, the spaceships take damage
+// by the impact speed and the bodies are pushed apart, so they do not overlap on the next tick.
+// Returns false for the other collisions, left to OnCollision.
+func (game *Game) bounce(a GameObject, b GameObject) bool {
+	_, shipA := a.(*Spaceship)
+	_, shipB := b.(*Spaceship)
+	massA, velocityA, bodyA := body(a, game.manager.rules)
+	massB, velocityB, bodyB := body(b, game.manager.rules)
+	if !bodyA || !bodyB || (!shipA && !shipB) {
+		return false
+	}
+
+	// Move b next to a, the battlefield wraps around its edges
+	positionA, positionB := a.Position(), b.Position()
+	nearest := positionA.Add(physics.WrappedDisplacement(positionA, positionB, game.size))
+	b.SetPosition(nearest)
+	contact, hit := collider.Collide(a.Collider(), b.Collider())
+	if !hit {
+		b.SetPosition(positionB)
+		return true
+	}
+
+	// The normal points from a to b, the bodies approach when their relative velocity is against it
+	rules := game.manager.rules
+	relativeVelocity := velocityB.Subtract(*velocityA)
+	impactSpeed := -relativeVelocity.Dot(contact.Normal)
+	inverseMassA, inverseMassB := 1/massA, 1/massB
+	if impactSpeed > 0 {
+		impulse := contact.Normal.Multiply((1 + rules.Restitution) * impactSpeed / (inverseMassA + inverseMassB))
+		*velocityA = velocityA.Subtract(impulse.Multiply(inverseMassA))
+		*velocityB = velocityB.Add(impulse.Multiply(inverseMassB))
+	}
+
+	// The lighter body is pushed further
+	separation := contact.Normal.Multiply(contact.Penetration / (inverseMassA + inverseMassB))
+	a.SetPosition(physics.Wrap(positionA.Subtract(separation.Multiply(inverseMassA)), game.size))
+	b.SetPosition(physics.Wrap(nearest.Add(separation.Multiply(inverseMassB)), game.size))
+
+	if impactSpeed > 0 {
+		game.impact(a, b, impactSpeed*rules.CollisionDamageCoefficient)
+	}
+	return true
+}
+
+// impact logs the collision once, by the spaceship, and deals the damage to the spaceships.
+// The teammates deal each other the damage of the friendly fire.
+func (game *Game) impact(a GameObject, b GameObject, damage float64) {
+	if _, ok := a.(*Spaceship); !ok {
+		a, b = b, a
+	}
+	game.manager.Logger().Collision(game.manager.Time(), a.(*Spaceship), b)
+	for _, pair := range [][2]GameObject{{a, b}, {b, a}} {
+		ship, ok := pair[0].(*Spaceship)
+		if !ok {
+			continue
+		}
+		multiplier := 1.0
+		if other, ok := pair[1].(*Spaceship); ok {
+			multiplier = game.manager.DamageMultiplier(other, ship)
+		}
+		ship.TakeDamage(damage*multiplier, DamageTypeUnknown, &game.manager, nil)
+	}
+}
+
+// body returns the mass and the velocity of the bodies of the physical collisions,
+// the spaceships and the asteroids.
+func body(gameObject GameObject, rules *Rules) (float64, *physics.Vector2, bool) {
+	switch gameObject := gameObject.(type) {
+	case *Spaceship:
+		return rules.ShipMass, &gameObject.velocity, true
+	case *Asteroid:
+		return rules.AsteroidDensity * gameObject.Area(), &gameObject.velocity, true
+	default:
+		return 0, nil, false
+	}
+}
+
+// collisionPairs returns the pairs of enabled game objects with overlapping bounds,
+// the candidates for the narrow-phase collision check.
+// The bounds of moving projectiles cover their whole path during the tick.
+func (game *Game) collisionPairs(gameObjects []GameObject) []physics.Pair {
+	game.broadPhase.Clear()
+	for i, gameObject := range gameObjects {
+		if !gameObject.Enabled() || gameObject.Collider() == nil {
+			continue
+		}
+		bounds := gameObject.Collider().Bounds()
+		if displacement := displacement(gameObject); displacement.X != 0 || displacement.Y != 0 {
+			bounds = physics.AABB{
+				Min: physics.Vector2{X: bounds.Min.X - max(displacement.X, 0), Y: bounds.Min.Y - max(displacement.Y, 0)},
+				Max: physics.Vector2{X: bounds.Max.X - min(displacement.X, 0), Y: bounds.Max.Y - min(displacement.Y, 0)},
+			}
+		}
+		game.broadPhase.Insert(i, bounds)
+	}
+	return game.broadPhase.Pairs()
+}
+
+// collide checks the collision of two game objects, sweeping the moving ones along their path.
+// The battlefield wraps around its edges, the objects collide across them.
+func (game *Game) collide(a GameObject, b GameObject) (float64, bool) {
+	if isOwner(a, b) || isOwner(b, a) || game.passThrough(a, b) || passOver(a, b) || passOver(b, a) {
+		return 0, false
+	}
+
+	// Move the collider of b next to a for the check
+	colliderA, colliderB := a.Collider(), b.Collider()
+	positionA, positionB := colliderA.Position(), colliderB.Position()
+	nearest := positionA.Add(physics.WrappedDisplacement(positionA, positionB, game.size))
+	if nearest != positionB {
+		colliderB.SetPosition(nearest)
+		defer colliderB.SetPosition(positionB)
+	}
+
+	displacementA, displacementB := displacement(a), displacement(b)
+	if displacementA == displacementB {
+		if a.Collider().CollidesWith(b.Collider()) {
+			return 1, true
+		}
+		return 0, false
+	}
+
+	// Sweep the faster object relative to the other one
+	if displacementA.Magnitude() >= displacementB.Magnitude() {
+		return collider.Sweep(a.Collider(), displacementA.Subtract(displacementB), b.Collider())
+	}
+	return collider.Sweep(b.Collider(), displacementB.Subtract(displacementA), a.Collider())
+}
+
+// isOwner checks if the game object is the spaceship which launched the projectile,
+// the projectiles do not collide with their launcher.
+func isOwner(gameObject GameObject, projectile GameObject) bool {
+	if projectile, ok := projectile.(*Projectile); ok {
+		return projectile.owner != nil && projectile.owner.ID() == gameObject.ID()
+	}
+	return false
+}
+
+// passOver checks if the game object is a power-up and the other one is not a spaceship,
+// only the spaceships collect the power-ups.
+func passOver(gameObject GameObject, other GameObject) bool {
+	_, powerUp := gameObject.(*PowerUp)
+	_, spaceship := other.(*Spaceship)
+	return powerUp && !spaceship
+}
+
+// passThrough checks if the game objects belong to teammates, which pass through each other
+// with the friendly fire off. The projectiles belong to their owners.
+func (game *Game) passThrough(a GameObject, b GameObject) bool {
+	if game.manager.rules.FriendlyFire != FriendlyFireOff {
+		return false
+	}
+	shipA, shipB := belongsTo(a), belongsTo(b)
+	return shipA != nil && shipB != nil && shipA.IsTeammate(shipB)
+}
+
+func belongsTo(gameObject GameObject) *Spaceship {
+	switch gameObject := gameObject.(type) {
+	case *Spaceship:
+		return gameObject
+	case *Projectile:
+		return gameObject.owner
+	default:
+		return nil
+	}
+}
+
+// displacement returns how far the game object moved during the tick,
+// only the projectiles are fast enough to be swept.
+func displacement(gameObject GameObject) physics.Vector2 {
+	if projectile, ok := gameObject.(*Projectile); ok {
+		return projectile.Displacement()
+	}
+	return physics.Vector2{}
+}
@@ -1,12 +1,32 @@
 package game
 
+import "math"
+
+// The defaults of the Rules, the physics configuration is fixed.
 const (
+	// Physics configuration
+	// Cell size of the collision broad-phase grid, around the size of a ship
+	// and the biggest asteroid.
+	CollisionCellSize = 64
+
 	// Asteroid configuration
 	MinAsteroids          = 2
 	MaxAsteroids          = 7
 	MinAsteroidSize       = 10
 	MaxAsteroidSize       = 30
 	MinAsteroidSeparation = 10 // Minimum distance between asteroids
+	// Static by default
+	MaxAsteroidVelocitySec        = 0
+	MaxAsteroidAngularVelocitySec = 0
+	AsteroidSplit                 = false
+	// Speed the fragments of a split asteroid are pushed apart with
+	AsteroidSplitVelocitySec = 30
+	AsteroidHealth           = 0 // Indestructible
+	AsteroidShapeKind        = AsteroidShapeCircle
+	MinAsteroidVertices      = 7
+	MaxAsteroidVertices      = 12
+	// How far the vertices of the polygon asteroids are pulled in, as a fraction of the size
+	AsteroidRoughness = 0.4
 
 	// Ship configuration
 	ShipSize  = 30
@@ -22,15 +42,35 @@ const (
 	// without any thrust
 	DragCoefficient            = 0.2385 * MaxVelocitySec
 	SideThrustPowerCoefficient = 0.80 // Relative to the main thrust
+	// Half a turn per second
+	MaxAngularVelocitySec = math.Pi
+	// This is tuned to reach almost the max angular velocity in 1 second
+	// with max rotation thrust, the max rotation thrust balances the drag
+	// at the max angular velocity.
+	AngularAccelerationCoefficient = 3 * MaxAngularVelocitySec
+	AngularDragCoefficient         = 3 * MaxAngularVelocitySec
+	// The heading of a moving spaceship follows its velocity, as before the rotation thruster,
+	// the bots not using the rotation thruster keep working
+	HeadingFollowsVelocity = true
 
 	EnergyConsumptionMainThrustSec = MaxEnergy / 8
 	EnergyConsumptionSideThrustSec = MaxEnergy / 12
+	EnergyConsumptionRotationSec   = MaxEnergy / 16
 	EnergyRechargeRateSec          = MaxEnergy / 8
 	ShipExplosionRadius            = 30
 	ShipExplosionDurationSec       = 1
 	ScorePerKill                   = 100
 	ScorePerDamageCoefficient      = 0.5
 
+	// Collision configuration
+	CollisionResponseMode = CollisionResponseDestroy
+	// The physical collisions, an asteroid of the ship size weighs about 3.5 ships
+	ShipMass        = 1000
+	AsteroidDensity = 5 // Mass of a square meter
+	Restitution     = 0.5
+	// Damage per meter per second of the impact speed, a head-on collision at the max velocity is deadly
+	CollisionDamageCoefficient = 0.5
+
 	// Laser configuration
 	LaserReloadSec         = 0.25
 	EnergyConsumptionLaser = 6
@@ -54,4 +94,79 @@ const (
 	RocketDetonateRadius       = 20
 	RocketExplosionRadius      = 30
 	RocketExplosionDurationSec = 1
+	// Damage at the center of the explosion, falls off linearly to 0 at the radius,
+	// the ship hit directly by the rocket takes only the RocketDamage.
+	RocketExplosionDamage = 40
+	// The homing rockets lock onto the nearest enemy within the cone at launch and steer towards it
+	RocketHoming            = false
+	RocketHomingConeAngle   = math.Pi / 6 // Either side of the launch direction
+	RocketHomingTurnRateSec = math.Pi / 2
+
+	// Mine configuration
+	MaxMines                 = 3
+	MineArmingSec            = 1 // After the drop, until the mine could be triggered
+	MineLifespanSec          = 30
+	MineTriggerRadius        = 50 // To the edge of the spaceship
+	MineExplosionRadius      = 80
+	MineExplosionDurationSec = 1
+	// Damage at the center of the explosion, falls off linearly to 0 at the radius
+	MineDamage = 50
+
+	// Power-up configuration
+	PowerUpIntervalSec = 0 // Disabled
+	MaxPowerUps        = 3 // On the battlefield at once
+	PowerUpLifespanSec = 20
+	PowerUpRadius      = 10
+	PowerUpRockets     = 3
+	PowerUpHealth      = MaxHealth * 0.3
+	PowerUpEnergy      = MaxEnergy / 2
+	// The damage dealt by the spaceship which collected the damage boost is multiplied for the duration
+	DamageBoostMultiplier  = 1.5
+	DamageBoostDurationSec = 10
+
+	// Shield configuration
+	// Drains faster than the energy recharges, the shield does not stay up for free
+	EnergyConsumptionShieldSec = MaxEnergy / 5
+	// Fraction of the laser, rocket and rocket explosion damage the shield absorbs, not of the mines
+	ShieldAbsorption  = 0.5
+	ShieldCooldownSec = 3 // After the shield goes down, until it could be raised again
+
+	// Radar configuration, the observations of the spaceships, see Game.Observe
+	RadarRange          = 0 // Unlimited
+	RadarPrecisionRange = 0
+	// Maximum position error per unit of distance beyond the precision range
+	RadarNoiseCoefficient = 0
+	RadarLineOfSight      = false // Whether the asteroids hide the objects behind them
+
+	// Team configuration
+	FriendlyFireMode = FriendlyFireOff
+	// Damage multiplier between teammates with the reduced friendly fire
+	FriendlyFireCoefficient = 0.5
+
+	// Match configuration
+	TimeLimitSec    = 0 // Unlimited, the match ends when a single team or spaceship survives
+	SuddenDeathMode = SuddenDeathOff
+	// How long the sudden death lasts after the time limit, the tiebreakers decide then
+	SuddenDeathDurationSec = 60
+	// Damage per second to the spaceships outside of the shrinking safe zone
+	SafeZoneDamageSec = 10
+
+	// Game mode configuration, see GameMode
+	MatchMode = ModeLastStanding
+	// Kills, seconds on the hill or captured flags of a team or spaceship to win, 0 is unlimited
+	ObjectiveLimit = 0
+	HillRadius     = 100
+	// Score per second alone on the hill
+	HillScoreSec     = 10
+	FlagRadius       = 20 // How close a spaceship picks up, returns or captures a flag
+	FlagCaptureScore = 200
+
+	// Respawn configuration, the last standing mode respawns only with the rule
+	Respawn = false
+	// How long the destroyed spaceships wait to respawn
+	RespawnDelaySec = 3
+	// How long the respawned spaceships take no damage
+	RespawnInvulnerabilitySec = 2
+	// Free space around the start position to respawn there, otherwise at the clearest spawn point
+	SpawnClearance = 100
 )
@@ -2,8 +2,9 @@ package game
 
 import (
 	"fmt"
-	"time"
 
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"math/rand"
 
@@ -19,12 +20,29 @@ const (
 	Ended       Status = "ended"
 )
 
+// EndReason is why the match ended.
+type EndReason string
+
+const (
+	// The match is not over
+	EndReasonNone EndReason = ""
+	// A single team, or spaceship playing on its own, survived or none did
+	EndReasonEliminated EndReason = "eliminated"
+	// The time limit and the sudden death ran out, the tiebreakers decide the winner
+	EndReasonTimeLimit EndReason = "timeLimit"
+	// A team, or spaceship playing on its own, reached the objective limit of the game mode
+	EndReasonObjective EndReason = "objective"
+)
+
 type DamageType string
 
 const (
 	DamageTypeUnknown DamageType = "unknown"
 	DamageTypeLaser   DamageType = "laser"
 	DamageTypeRocket  DamageType = "rocket"
+	DamageTypeMine    DamageType = "mine"
+	// Area damage of the rocket explosions
+	DamageTypeExplosion DamageType = "explosion"
 )
 
 type Game struct {
@@ -33,14 +51,23 @@ type Game struct {
 	size             physics.Size
 	manager          GameManager
 	gracefulEndTimer float64
+	replay           *Replay
+	broadPhase       *physics.SpatialHash
 }
 
-func NewGame(size physics.Size, seed int64) *Game {
+// NewGame creates a game with a copy of the rules, the rules must be valid, see Rules.Validate.
+func NewGame(size physics.Size, seed int64, rules *Rules) *Game {
+	copied := *rules
+	manager := NewGameManager()
+	manager.size = size
+	manager.rules = &copied
+	manager.mode = NewGameMode(&copied)
 	return &Game{
-		status:  Initialized,
-		size:    size,
-		seed:    seed,
-		manager: NewGameManager(),
+		status:     Initialized,
+		size:       size,
+		seed:       seed,
+		manager:    manager,
+		broadPhase: physics.NewSpatialHash(CollisionCellSize, size),
 	}
 }
 
@@ -48,13 +75,31 @@ func (game *Game) Status() Status {
 	return game.status
 }
 
+func (game *Game) Size() physics.Size {
+	return game.size
+}
+
+// Rules returns the rules of the match, they must not be modified once the game is created.
+func (game *Game) Rules() *Rules {
+	return game.manager.rules
+}
+
+// Time returns the simulation time, advanced by every Update.
+func (game *Game) Time() SimulationTime {
+	return game.manager.Time()
+}
+
 func (game *Game) Start() {
 	if game.status == Running {
 		return
 	}
 
+	if game.status == Initialized {
+		game.manager.mode.Setup(&game.manager)
+	}
 	game.status = Running
-	game.manager.Logger().GameState(time.Now(), Running)
+	game.manager.Logger().GameState(game.manager.Time(), Running)
+	game.record(ReplayEvent{Type: ReplayEventStart})
 }
 
 func (game *Game) Pause() {
@@ -63,15 +108,25 @@ func (game *Game) Pause() {
 	}
 
 	game.status = Paused
-	game.manager.Logger().GameState(time.Now(), Paused)
+	game.manager.Logger().GameState(game.manager.Time(), Paused)
+	game.record(ReplayEvent{Type: ReplayEventPause})
 }
 
 func (game *Game) Reset() {
 	game.manager.Reset()
+	game.manager.mode.Setup(&game.manager)
 	game.manager.Logger().Clear()
+	game.record(ReplayEvent{Type: ReplayEventReset})
 }
 
 func (game *Game) Update(deltaTimeMs float64) {
+	suddenDeath := game.manager.SuddenDeath()
+	game.manager.time = game.manager.time.Advance(deltaTimeMs)
+	game.record(ReplayEvent{Type: ReplayEventTick, DeltaTimeMs: deltaTimeMs})
+	if suddenDeath == SuddenDeathOff && game.manager.SuddenDeath() != SuddenDeathOff {
+		game.manager.Logger().SuddenDeath(game.manager.Time(), game.manager.SuddenDeath())
+	}
+
 	for _, gameObject := range game.manager.GameObjects() {
 		if !gameObject.Enabled() {
 			continue
@@ -80,51 +135,52 @@ func (game *Game) Update(deltaTimeMs float64) {
 
 		// Wrap around the screen
 		position := gameObject.Position()
-		if position.X < 0 {
-			gameObject.SetPosition(physics.Vector2{X: game.size.Width - position.X, Y: position.Y})
-		} else if position.X > game.size.Width {
-			gameObject.SetPosition(physics.Vector2{X: position.X - game.size.Width, Y: position.Y})
-		}
-		if position.Y < 0 {
-			gameObject.SetPosition(physics.Vector2{X: position.X, Y: game.size.Height - position.Y})
-		} else if position.Y > game.size.Height {
-			gameObject.SetPosition(physics.Vector2{X: position.X, Y: position.Y - game.size.Height})
+		if wrapped := physics.Wrap(position, game.size); wrapped != position {
+			gameObject.SetPosition(wrapped)
 		}
 	}
 
-	size := game.manager.GameObjectSize()
-	for i := 0; i < size-1; i++ {
-		a := game.manager.GetGameObjectByIndex(i)
-		colliderA := a.Collider()
-		if !a.Enabled() || colliderA == nil {
-			continue
-		}
-		for j := i + 1; j < size; j++ {
-			b := game.manager.GetGameObjectByIndex(j)
-			colliderB := b.Collider()
-			if !b.Enabled() || colliderB == nil {
-				continue
-			}
-
-			if colliderA.CollidesWith(colliderB) {
-				a.OnCollision(b, &game.manager, 0)
-				b.OnCollision(a, &game.manager, 1)
-			}
-		}
-	}
+	game.applySafeZone(deltaTimeMs)
+	game.spawnPowerUps(deltaTimeMs)
+	game.resolveCollisions()
+	game.manager.mode.Update(deltaTimeMs, &game.manager)
 
 	if game.manager.HasEnded(deltaTimeMs) {
 		game.status = Ended
-		game.manager.Logger().GameState(time.Now(), Ended)
+		game.manager.Logger().GameEnded(game.manager.Time(), game.manager.EndReason(), game.Winner())
+	}
+}
+
+// EndReason returns why the match ended, EndReasonNone while it is not over.
+func (game *Game) EndReason() EndReason {
+	return game.manager.EndReason()
+}
+
+// applySafeZone damages the spaceships outside of the shrinking safe zone, see GameManager.SafeZoneRadius.
+func (game *Game) applySafeZone(deltaTimeMs float64) {
+	if game.manager.SuddenDeath() != SuddenDeathShrinkingZone {
+		return
+	}
+
+	center := physics.Vector2{X: game.size.Width / 2, Y: game.size.Height / 2}
+	radius := game.manager.SafeZoneRadius()
+	for _, gameObject := range game.manager.GameObjects() {
+		spaceship, ok := gameObject.(*Spaceship)
+		if !ok || !spaceship.Enabled() || physics.WrappedDistance(spaceship.position, center, game.size) <= radius {
+			continue
+		}
+		spaceship.TakeDamage(game.manager.rules.SafeZoneDamageSec*deltaTimeMs/1000, DamageTypeUnknown, &game.manager, nil)
 	}
 }
 
 func (game *Game) SeedAsteroids() {
-	asteroids := SeedAsteroids(rand.New(rand.NewSource(game.seed)), game.size.Width, game.size.Height, 1000)
+	asteroids := SeedAsteroids(game.manager.ids, rand.New(rand.NewSource(game.seed)), game.manager.rules, game.size.Width, game.size.Height, 1000)
 	game.manager.AddGameObjects(asteroids)
 }
 
-func (game *Game) SpaceshipAction(name string, action func(spaceShip *Spaceship, gameManager *GameManager)) error {
+// spaceshipAction runs the function on the spaceship with the given name. It is not recorded,
+// the actions from the outside go through ApplyAction.
+func (game *Game) spaceshipAction(name string, action func(spaceShip *Spaceship, gameManager *GameManager)) error {
 	spaceShip, err := game.manager.GetSpaceship(name)
 	if err != nil {
 		return err
@@ -133,8 +189,26 @@ func (game *Game) SpaceshipAction(name string, action func(spaceShip *Spaceship,
 	return nil
 }
 
-func (game *Game) AddSpaceship(name string, position physics.Vector2, rotation float64) error {
-	spaceShip := NewSpaceship(NewUUID(), name, position, rotation)
+// Disqualify destroys the spaceship, e.g. when its bot crashed.
+func (game *Game) Disqualify(name string) error {
+	spaceShip, err := game.manager.GetSpaceship(name)
+	if err != nil {
+		return err
+	}
+	if spaceShip.Enabled() {
+		spaceShip.invulnerableTimerSec = 0
+		spaceShip.TakeDamage(spaceShip.rules.MaxHealth, DamageTypeUnknown, &game.manager, nil)
+	}
+	// Out for good, even in the modes with respawns and when waiting to respawn
+	spaceShip.respawnTimerSec = 0
+	game.record(ReplayEvent{Type: ReplayEventDisqualify, Ship: name})
+	return nil
+}
+
+// AddSpaceship adds the spaceship to the team, an empty team for a ship playing on its own.
+func (game *Game) AddSpaceship(name string, position physics.Vector2, rotation float64, team string) error {
+	spaceShip := NewSpaceship(game.manager.NewID(), name, position, rotation, game.manager.rules)
+	spaceShip.team = team
 	return game.manager.AddSpaceship(spaceShip)
 }
 
@@ -148,169 +222,305 @@ func (game *Game) Serialize() map[string]interface{} {
 		gameObjects = append(gameObjects, gameObject.Serialize())
 	}
 
+	teams := make([]interface{}, 0)
+	for _, team := range game.Teams() {
+		teams = append(teams, team.Serialize())
+	}
+
 	logs := make([]interface{}, 0)
 	for _, log := range game.manager.Logger().Logs() {
 		logs = append(logs, log.Serialize())
 	}
 
 	return map[string]interface{}{
-		"status": string(game.Status()),
-		"seed":   game.seed,
+		"schemaVersion": StateVersion,
+		"status":        string(game.Status()),
+		"endReason":     string(game.manager.endReason),
+		"seed":          game.seed,
+		"tick":          game.manager.time.Tick,
+		"elapsedMs":     game.manager.time.ElapsedMs,
 		"size": map[string]interface{}{
 			"width":  game.size.Width,
 			"height": game.size.Height,
 		},
+		"rules":       game.manager.rules.Serialize(),
 		"gameObjects": gameObjects,
+		"teams":       teams,
 		"logs":        logs,
 	}
 }
 
+// StateHash returns the SHA-256 hash of the serialized state,
+// used to verify two runs ended in the same state.
+func (game *Game) StateHash() string {
+	// Map keys are sorted by the encoder, the output is stable
+	serialized, _ := json.Marshal(game.Serialize())
+	hash := sha256.Sum256(serialized)
+	return hex.EncodeToString(hash[:])
+}
+
+// Deserialize restores the game from the serialized state leniently, see DeserializeState.
 func Deserialize(jsonData string) (*Game, error) {
-	data := make(map[string]interface{})
-	err := json.Unmarshal([]byte(jsonData), &data)
-	if err != nil {
-		return nil, err
+	game, _, err := DeserializeState(jsonData, Lenient)
+	return game, err
+}
+
+// DeserializeState restores the game from the serialized state, see Game.Serialize,
+// the states of the older schema versions are migrated. The problems with the state are returned as *StateError naming the offending value,
+// the lenient mode returns the problems it recovered from as warnings.
+func DeserializeState(jsonData string, mode DecodeMode) (*Game, []*StateError, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
+		return nil, nil, err
+	}
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, nil, typeError("", "an object", data)
+	}
+	if err := MigrateState(object); err != nil {
+		return nil, nil, err
 	}
 
-	size := data["size"].(map[string]interface{})
+	decoder := &stateDecoder{mode: mode}
+	state := State{Rules: DefaultRules()}
+	if err := decoder.decode(data, &state, ""); err != nil {
+		return nil, decoder.warnings, err
+	}
+	switch state.Status {
+	case Initialized, Running, Paused, Ended:
+	default:
+		return nil, decoder.warnings, &StateError{Path: "status", Message: fmt.Sprintf("unknown status %q", state.Status)}
+	}
+	switch state.EndReason {
+	case EndReasonNone, EndReasonEliminated, EndReasonTimeLimit, EndReasonObjective:
+	default:
+		return nil, decoder.warnings, &StateError{Path: "endReason", Message: fmt.Sprintf("unknown end reason %q", state.EndReason)}
+	}
+	if state.Size.Width < 0 || state.Size.Height < 0 {
+		return nil, decoder.warnings, &StateError{Path: "size", Message: "width and height must not be negative"}
+	}
+	if err := state.Rules.Validate(); err != nil {
+		return nil, decoder.warnings, &StateError{Path: "rules", Message: err.Error()}
+	}
 
-	game := NewGame(
-		physics.Size{
-			Width:  size["width"].(float64),
-			Height: size["height"].(float64),
-		},
-		int64(data["seed"].(float64)),
-	)
-
-	uuid := int64(0)
-	destroyedShips := 0
-
-	// Game objects
-	for _, gameObject := range data["gameObjects"].([]interface{}) {
-		gameObjectMap := gameObject.(map[string]interface{})
-		id := int64(gameObjectMap["id"].(float64))
-		gameObjectType := gameObjectMap["type"].(string)
-		enabled := gameObjectMap["enabled"].(bool)
-		position := physics.Vector2{
-			X: gameObjectMap["position"].(map[string]interface{})["x"].(float64),
-			Y: gameObjectMap["position"].(map[string]interface{})["y"].(float64),
-		}
+	game := NewGame(physics.Size{Width: state.Size.Width, Height: state.Size.Height}, state.Seed, state.Rules)
+	lastID := int64(0)
 
-		if id > uuid {
-			uuid = id
+	for i, value := range state.GameObjects {
+		gameObject, err := game.restoreGameObject(decoder, value, fmt.Sprintf("gameObjects[%d]", i))
+		if err != nil {
+			if err := decoder.warn(err); err != nil {
+				return nil, decoder.warnings, err
+			}
+			continue
 		}
+		lastID = max(lastID, gameObject.ID())
+	}
 
-		switch gameObjectType {
-		case "asteroid":
-			asteroid := NewAsteroid(
-				id,
-				position,
-				gameObjectMap["radius"].(float64),
-			)
-			asteroid.enabled = enabled
-			game.manager.AddGameObject(asteroid)
-		case "laser":
-			fallthrough
-		case "rocket":
-			owner := game.manager.GetGameObjectByID(int64(gameObjectMap["owner"].(float64)))
-			if owner == nil {
-				fmt.Println("Owner not found")
-				continue
-			}
-			rotation := gameObjectMap["rotation"].(float64)
-
-			var projectile Projectile
-			if gameObjectType == "laser" {
-				projectile = *NewLaserProjectile(
-					id,
-					position,
-					rotation,
-					owner.(*Spaceship),
-				)
-			} else {
-				projectile = *NewRocketProjectile(
-					id,
-					position,
-					rotation,
-					owner.(*Spaceship),
-				)
+	for i, value := range state.Logs {
+		path := fmt.Sprintf("logs[%d]", i)
+		log := LogState{}
+		if err := decoder.decode(value, &log, path); err != nil {
+			if err := decoder.warn(err.(*StateError)); err != nil {
+				return nil, decoder.warnings, err
 			}
+			continue
+		}
+		lastID = max(lastID, log.ID)
+		game.manager.Logger().AddMessage(Message{
+			id:      log.ID,
+			logType: log.LogType,
+			time:    SimulationTime{Tick: log.Tick, ElapsedMs: log.ElapsedMs},
+			message: log.Message,
+			meta:    log.Meta,
+		})
+	}
 
-			projectile.enabled = enabled
-			projectile.velocity = physics.Vector2{
-				X: gameObjectMap["velocity"].(map[string]interface{})["x"].(float64),
-				Y: gameObjectMap["velocity"].(map[string]interface{})["y"].(float64),
-			}
-			projectile.lifespanSec = gameObjectMap["lifespanSec"].(float64)
-			projectile.damage = gameObjectMap["damage"].(float64)
-			game.manager.AddGameObject(&projectile)
-		case "spaceship":
-			spaceship := NewSpaceship(
-				id,
-				gameObjectMap["name"].(string),
-				position,
-				gameObjectMap["rotation"].(float64),
-			)
-			spaceship.enabled = enabled
-			spaceship.velocity = physics.Vector2{
-				X: gameObjectMap["velocity"].(map[string]interface{})["x"].(float64),
-				Y: gameObjectMap["velocity"].(map[string]interface{})["y"].(float64),
-			}
-			spaceship.health = gameObjectMap["health"].(float64)
-			spaceship.energy = gameObjectMap["energy"].(float64)
-			spaceship.engine.mainThrust = gameObjectMap["engine"].(map[string]interface{})["mainThrust"].(float64)
-			spaceship.engine.leftThrust = gameObjectMap["engine"].(map[string]interface{})["leftThrust"].(float64)
-			spaceship.engine.rightThrust = gameObjectMap["engine"].(map[string]interface{})["rightThrust"].(float64)
-			spaceship.rockets = int32(gameObjectMap["rockets"].(float64))
-			spaceship.kills = int32(gameObjectMap["kills"].(float64))
-			spaceship.score = gameObjectMap["score"].(float64)
-			spaceship.laserReloadTimerSec = gameObjectMap["laserReloadTimerSec"].(float64)
-			spaceship.rocketReloadTimerSec = gameObjectMap["rocketReloadTimerSec"].(float64)
-			if gameObjectMap["destroyed"].(bool) {
-				destroyedShips++
-			}
-			game.manager.AddSpaceship(spaceship)
-		case "explosion":
-			explosion := NewExplosion(
-				id,
-				position,
-				gameObjectMap["radius"].(float64),
-				gameObjectMap["durationSec"].(float64),
-			)
-			explosion.enabled = enabled
-			explosion.lifespanSec = gameObjectMap["lifespanSec"].(float64)
-			game.manager.AddGameObject(explosion)
-		default:
-			fmt.Println("Unknown game object type", gameObjectType)
+	game.manager.time = SimulationTime{Tick: state.Tick, ElapsedMs: state.ElapsedMs}
+	game.manager.ids.Set(lastID)
+	game.status = state.Status
+	game.manager.endReason = state.EndReason
+	return game, decoder.warnings, nil
+}
+
+// restoreGameObject decodes the game object and adds it to the game.
+func (game *Game) restoreGameObject(decoder *stateDecoder, value interface{}, path string) (GameObject, *StateError) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, typeError(path, "an object", value)
+	}
+	objectType, ok := object["type"].(string)
+	if !ok {
+		if _, exists := object["type"]; !exists {
+			return nil, &StateError{Path: joinPath(path, "type"), Message: "missing"}
 		}
+		return nil, typeError(joinPath(path, "type"), "a string", object["type"])
+	}
+	if id, ok := object["id"].(float64); ok && game.manager.GetGameObjectByID(int64(id)) != nil {
+		return nil, &StateError{Path: joinPath(path, "id"), Message: fmt.Sprintf("duplicate id %v", id)}
 	}
 
-	// Logs
-	logger := game.manager.Logger()
-	for _, log := range data["logs"].([]interface{}) {
-		logMap := log.(map[string]interface{})
-		time, err := time.Parse("2006-01-02 15:04:05", logMap["time"].(string))
-		if err != nil {
-			fmt.Println(err)
-			continue
+	var gameObject GameObject
+	switch objectType {
+	case "asteroid":
+		state := AsteroidState{}
+		if err := decoder.decode(object, &state, path); err != nil {
+			return nil, err.(*StateError)
+		}
+		var asteroid *Asteroid
+		if len(state.Vertices) > 0 {
+			vertices := make([]physics.Vector2, len(state.Vertices))
+			for i, vertex := range state.Vertices {
+				vertices[i] = physics.Vector2(vertex)
+			}
+			asteroid = NewPolygonAsteroid(state.ID, physics.Vector2(state.Position), vertices)
+		} else {
+			asteroid = NewAsteroid(state.ID, physics.Vector2(state.Position), state.Radius)
+		}
+		asteroid.enabled = state.Enabled
+		asteroid.startPosition = physics.Vector2(state.StartPosition)
+		asteroid.rotation = state.Rotation
+		asteroid.collider.SetRotation(state.Rotation)
+		asteroid.SetMotion(physics.Vector2(state.Velocity), state.AngularVelocity)
+		asteroid.startVelocity = physics.Vector2(state.StartVelocity)
+		asteroid.damage = state.Damage
+		asteroid.fragment = state.Fragment
+		gameObject = asteroid
+	case "laser", "rocket":
+		state := ProjectileState{}
+		if err := decoder.decode(object, &state, path); err != nil {
+			return nil, err.(*StateError)
+		}
+		owner, ok := game.manager.GetGameObjectByID(state.Owner).(*Spaceship)
+		if !ok {
+			return nil, &StateError{Path: joinPath(path, "owner"), Message: fmt.Sprintf("spaceship %d not found", state.Owner)}
 		}
 
-		id := int64(logMap["id"].(float64))
-		if id > uuid {
-			uuid = id
+		var projectile *Projectile
+		if objectType == "laser" {
+			projectile = NewLaserProjectile(state.ID, physics.Vector2(state.Position), state.Rotation, owner)
+		} else {
+			projectile = NewRocketProjectile(state.ID, physics.Vector2(state.Position), state.Rotation, owner)
+		}
+		projectile.enabled = state.Enabled
+		projectile.velocity = physics.Vector2(state.Velocity)
+		projectile.lifespanSec = state.LifespanSec
+		projectile.damage = state.Damage
+		if state.Target != 0 {
+			target, ok := game.manager.GetGameObjectByID(state.Target).(*Spaceship)
+			if !ok {
+				return nil, &StateError{Path: joinPath(path, "target"), Message: fmt.Sprintf("spaceship %d not found", state.Target)}
+			}
+			projectile.target = target
+		}
+		gameObject = projectile
+	case "mine":
+		state := MineState{}
+		if err := decoder.decode(object, &state, path); err != nil {
+			return nil, err.(*StateError)
+		}
+		owner, ok := game.manager.GetGameObjectByID(state.Owner).(*Spaceship)
+		if !ok {
+			return nil, &StateError{Path: joinPath(path, "owner"), Message: fmt.Sprintf("spaceship %d not found", state.Owner)}
 		}
 
-		logger.AddMessage(Message{
-			id:      id,
-			logType: LogType(logMap["logType"].(string)),
-			time:    time,
-			message: logMap["message"].(string),
-			meta:    logMap["meta"].(map[string]interface{}),
-		})
+		mine := NewMine(state.ID, physics.Vector2(state.Position), owner)
+		mine.enabled = state.Enabled
+		mine.armingTimerSec = state.ArmingTimerSec
+		mine.lifespanSec = state.LifespanSec
+		gameObject = mine
+	case "powerUp":
+		state := PowerUpState{}
+		if err := decoder.decode(object, &state, path); err != nil {
+			return nil, err.(*StateError)
+		}
+		switch state.Kind {
+		case PowerUpKindRockets, PowerUpKindHealth, PowerUpKindEnergy, PowerUpKindDamageBoost:
+		default:
+			return nil, &StateError{Path: joinPath(path, "kind"), Message: fmt.Sprintf("unknown power-up kind %q", state.Kind)}
+		}
+		powerUp := NewPowerUp(state.ID, state.Kind, physics.Vector2(state.Position), game.manager.rules)
+		powerUp.enabled = state.Enabled
+		powerUp.lifespanSec = state.LifespanSec
+		gameObject = powerUp
+	case "spaceship":
+		state := SpaceshipState{}
+		if err := decoder.decode(object, &state, path); err != nil {
+			return nil, err.(*StateError)
+		}
+		if _, err := game.manager.GetSpaceship(state.Name); err == nil {
+			return nil, &StateError{Path: joinPath(path, "name"), Message: fmt.Sprintf("duplicate spaceship name %q", state.Name)}
+		}
+
+		spaceship := NewSpaceship(state.ID, state.Name, physics.Vector2(state.Position), state.Rotation, game.manager.rules)
+		spaceship.team = state.Team
+		spaceship.enabled = state.Enabled
+		spaceship.startPosition = physics.Vector2(state.StartPosition)
+		spaceship.velocity = physics.Vector2(state.Velocity)
+		spaceship.angularVelocity = state.AngularVelocity
+		spaceship.health = state.Health
+		spaceship.energy = state.Energy
+		spaceship.engine = Engine{
+			mainThrust:     state.Engine.MainThrust,
+			leftThrust:     state.Engine.LeftThrust,
+			rightThrust:    state.Engine.RightThrust,
+			rotationThrust: state.Engine.RotationThrust,
+		}
+		spaceship.rockets = state.Rockets
+		spaceship.mines = state.Mines
+		spaceship.kills = state.Kills
+		spaceship.score = state.Score
+		spaceship.damageDealt = state.DamageDealt
+		spaceship.objective = state.Objective
+		spaceship.respawnTimerSec = state.RespawnTimerSec
+		spaceship.invulnerableTimerSec = state.InvulnerableTimerSec
+		spaceship.deaths = state.Deaths
+		spaceship.respawns = state.Respawns
+		spaceship.laserReloadTimerSec = state.LaserReloadTimerSec
+		spaceship.rocketReloadTimerSec = state.RocketReloadTimerSec
+		spaceship.shield = state.Shield
+		spaceship.shieldCooldownTimerSec = state.ShieldCooldownTimerSec
+		spaceship.damageBoostTimerSec = state.DamageBoostTimerSec
+		game.manager.AddSpaceship(spaceship)
+		return spaceship, nil
+	case "explosion":
+		state := ExplosionState{}
+		if err := decoder.decode(object, &state, path); err != nil {
+			return nil, err.(*StateError)
+		}
+		explosion := NewExplosion(state.ID, physics.Vector2(state.Position), state.Radius, state.DurationSec)
+		explosion.enabled = state.Enabled
+		explosion.lifespanSec = state.LifespanSec
+		gameObject = explosion
+	case "hill":
+		state := HillState{}
+		if err := decoder.decode(object, &state, path); err != nil {
+			return nil, err.(*StateError)
+		}
+		hill := NewHill(state.ID, physics.Vector2(state.Position), state.Radius)
+		hill.enabled = state.Enabled
+		hill.controller = state.Controller
+		gameObject = hill
+	case "flag":
+		state := FlagState{}
+		if err := decoder.decode(object, &state, path); err != nil {
+			return nil, err.(*StateError)
+		}
+		flag := NewFlag(state.ID, state.Side, physics.Vector2(state.Base))
+		flag.enabled = state.Enabled
+		flag.position = physics.Vector2(state.Position)
+		if state.Carrier != 0 {
+			carrier, ok := game.manager.GetGameObjectByID(state.Carrier).(*Spaceship)
+			if !ok {
+				return nil, &StateError{Path: joinPath(path, "carrier"), Message: fmt.Sprintf("spaceship %d not found", state.Carrier)}
+			}
+			flag.carrier = carrier
+		}
+		gameObject = flag
+	default:
+		return nil, &StateError{Path: joinPath(path, "type"), Message: fmt.Sprintf("unknown game object type %q", objectType)}
 	}
 
-	SetUUID(uuid)
-	game.manager.destroyedShips = destroyedShips
-	game.status = Status(data["status"].(string))
-	return game, nil
+	game.manager.AddGameObject(gameObject)
+	return gameObject, nil
 }
@@ -1,34 +1,191 @@
 package game
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
 
 type GameManager struct {
 	gameObjects        []GameObject
 	spaceShips         map[string]*Spaceship
-	destroyedShips     int
 	gracefulEndTimerMs float64
+	endReason          EndReason
 	logger             Logger
+	time               SimulationTime
+	ids                *IDGenerator
+	size               physics.Size
+	rules              *Rules
+	mode               GameMode
 }
 
 func NewGameManager() GameManager {
+	ids := NewIDGenerator()
 	return GameManager{
-		gameObjects:    []GameObject{},
-		spaceShips:     map[string]*Spaceship{},
-		logger:         NewLogger(),
-		destroyedShips: 0,
+		gameObjects: []GameObject{},
+		spaceShips:  map[string]*Spaceship{},
+		logger:      NewLogger(ids),
+		ids:         ids,
+		rules:       DefaultRules(),
+		mode:        &lastStanding{},
 	}
 }
 
+// NewID allocates a new unique ID within the game.
+func (manager *GameManager) NewID() int64 {
+	return manager.ids.Next()
+}
+
 func (manager *GameManager) GameObjects() []GameObject {
 	return manager.gameObjects
 }
 
+// Size returns the size of the battlefield, zero when the manager is not part of a game.
+func (manager *GameManager) Size() physics.Size {
+	return manager.size
+}
+
+// Rules returns the rules of the game, the defaults when the manager is not part of a game.
+func (manager *GameManager) Rules() *Rules {
+	return manager.rules
+}
+
+// Time returns the current simulation time, used to timestamp the logs.
+func (manager *GameManager) Time() SimulationTime {
+	return manager.time
+}
+
+// Mode returns the game mode of the match, see Rules.Mode.
+func (manager *GameManager) Mode() GameMode {
+	return manager.mode
+}
+
+// HasEnded checks if the match is over and records why, see EndReason.
+// The last side standing ends the match once the explosions are over.
 func (manager *GameManager) HasEnded(deltaTimeMs float64) bool {
 	if manager.gracefulEndTimerMs > 0 {
 		manager.gracefulEndTimerMs -= deltaTimeMs
 		return false
 	}
-	return manager.destroyedShips >= len(manager.spaceShips)-1
+	if reason := manager.mode.HasEnded(manager); reason != EndReasonNone {
+		manager.endReason = reason
+		return true
+	}
+	if duration := manager.rules.MatchDurationSec(); duration > 0 && manager.time.ElapsedMs >= duration*1000 {
+		manager.endReason = EndReasonTimeLimit
+		return true
+	}
+	return false
+}
+
+// EndReason returns why the match ended, EndReasonNone while it is not over.
+func (manager *GameManager) EndReason() EndReason {
+	return manager.endReason
+}
+
+// SuddenDeath returns the sudden death in effect, SuddenDeathOff until the time limit.
+func (manager *GameManager) SuddenDeath() SuddenDeath {
+	if manager.rules.TimeLimitSec <= 0 || manager.time.ElapsedMs < manager.rules.TimeLimitSec*1000 {
+		return SuddenDeathOff
+	}
+	return manager.rules.SuddenDeath
+}
+
+// SafeZoneRadius returns the radius of the safe zone around the center of the battlefield.
+// It covers the whole battlefield until the shrinking zone sudden death, which shrinks it
+// linearly to 0 over the sudden death duration.
+func (manager *GameManager) SafeZoneRadius() float64 {
+	radius := math.Hypot(manager.size.Width, manager.size.Height) / 2
+	if manager.SuddenDeath() != SuddenDeathShrinkingZone {
+		return radius
+	}
+	progress := (manager.time.ElapsedMs/1000 - manager.rules.TimeLimitSec) / manager.rules.SuddenDeathDurationSec
+	return radius * math.Max(1-progress, 0)
+}
+
+// SpawnPoint returns where the spaceship respawns: its start position when it is clear enough,
+// see Rules.SpawnClearance, otherwise the point of a grid over the battlefield the furthest
+// from the asteroids and the opponents.
+func (manager *GameManager) SpawnPoint(ship *Spaceship) physics.Vector2 {
+	if manager.clearance(ship, ship.startPosition) >= manager.rules.SpawnClearance {
+		return ship.startPosition
+	}
+
+	best, bestClearance := ship.startPosition, manager.clearance(ship, ship.startPosition)
+	cellWidth, cellHeight := manager.size.Width/spawnGridSize, manager.size.Height/spawnGridSize
+	for row := 0; row < spawnGridSize; row++ {
+		for column := 0; column < spawnGridSize; column++ {
+			point := physics.Vector2{X: (float64(column) + 0.5) * cellWidth, Y: (float64(row) + 0.5) * cellHeight}
+			if clearance := manager.clearance(ship, point); clearance > bestClearance {
+				best, bestClearance = point, clearance
+			}
+		}
+	}
+	return best
+}
+
+// spawnGridSize is the number of the rows and the columns of the spawn points.
+const spawnGridSize = 16
+
+// clearance returns the free space around the point for the spaceship, the distance to the edge
+// of the closest asteroid or opponent. The teammates do not count, they pass through each other
+// unless the friendly fire is on.
+func (manager *GameManager) clearance(ship *Spaceship, point physics.Vector2) float64 {
+	clearance := math.Inf(1)
+	for _, gameObject := range manager.gameObjects {
+		if !gameObject.Enabled() {
+			continue
+		}
+		var radius float64
+		switch object := gameObject.(type) {
+		case *Asteroid:
+			radius = object.radius
+		case *Spaceship:
+			if object == ship || ship.IsTeammate(object) {
+				continue
+			}
+			radius = object.rules.ShipSize / 2
+		default:
+			continue
+		}
+		distance := physics.WrappedDistance(point, gameObject.Position(), manager.size) - radius - ship.rules.ShipSize/2
+		clearance = math.Min(clearance, distance)
+	}
+	return clearance
+}
+
+// aliveSides returns the number of the teams, and the ships without a team,
+// with a spaceship not destroyed yet or waiting to respawn. The last side standing wins.
+func (manager *GameManager) aliveSides() int {
+	sides := 0
+	teams := map[string]bool{}
+	for _, spaceShip := range manager.spaceShips {
+		if (spaceShip.health <= 0 && spaceShip.respawnTimerSec <= 0) || teams[spaceShip.team] {
+			continue
+		}
+		if spaceShip.team != "" {
+			teams[spaceShip.team] = true
+		}
+		sides++
+	}
+	return sides
+}
+
+// DamageMultiplier returns the multiplier of the damage the dealer deals to the target,
+// 1 unless they are teammates, see FriendlyFire.
+func (manager *GameManager) DamageMultiplier(dealer *Spaceship, target *Spaceship) float64 {
+	if dealer == nil || !dealer.IsTeammate(target) {
+		return 1
+	}
+	switch manager.rules.FriendlyFire {
+	case FriendlyFireOff:
+		return 0
+	case FriendlyFireReduced:
+		return manager.rules.FriendlyFireCoefficient
+	default:
+		return 1
+	}
 }
 
 func (manager *GameManager) GetGameObjectByID(id int64) GameObject {
@@ -98,11 +255,13 @@ func (manager *GameManager) RemoveSpaceship(name string) error {
 	return nil
 }
 
-func (manager *GameManager) OnShipDestroyed() {
-	manager.destroyedShips++
-	if manager.destroyedShips >= len(manager.spaceShips)-1 {
-		manager.gracefulEndTimerMs = (ShipExplosionDurationSec * 1000) + 100
-	}
+// OnKill lets the game mode reward the killer of the spaceship.
+func (manager *GameManager) OnKill(killer *Spaceship, victim *Spaceship) {
+	manager.mode.OnKill(killer, victim, manager)
+}
+
+func (manager *GameManager) OnShipDestroyed(ship *Spaceship) {
+	manager.mode.OnShipDestroyed(ship, manager)
 }
 
 func (manager *GameManager) Reset() {
@@ -113,12 +272,16 @@ func (manager *GameManager) Reset() {
 			gameObject.(*Spaceship).Reset()
 			gameObjects = append(gameObjects, gameObject)
 		case *Asteroid:
-			gameObjects = append(gameObjects, gameObject)
+			if asteroid := gameObject.(*Asteroid); !asteroid.fragment {
+				asteroid.Reset()
+				gameObjects = append(gameObjects, gameObject)
+			}
 		}
 	}
 	manager.gameObjects = gameObjects
-	manager.destroyedShips = 0
 	manager.gracefulEndTimerMs = 0
+	manager.endReason = EndReasonNone
+	manager.time = SimulationTime{}
 }
 
 func (manager *GameManager) Logger() Logger {
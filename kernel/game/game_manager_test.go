@@ -1,6 +1,7 @@
 package game
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
@@ -12,15 +13,28 @@ func TestNewGameManager(t *testing.T) {
 
 	assert.Empty(t, manager.gameObjects)
 	assert.Empty(t, manager.spaceShips)
-	assert.Equal(t, 0, manager.destroyedShips)
 	assert.Equal(t, float64(0), manager.gracefulEndTimerMs)
 	assert.NotNil(t, manager.logger)
+	assert.NotNil(t, manager.ids)
+}
+
+func TestGameManager_NewID(t *testing.T) {
+	manager := NewGameManager()
+	other := NewGameManager()
+
+	assert.Equal(t, int64(1), manager.NewID())
+	assert.Equal(t, int64(2), manager.NewID())
+	assert.Equal(t, int64(1), other.NewID())
+
+	// The logger shares the generator with the manager
+	manager.Logger().GameState(manager.Time(), Running)
+	assert.Equal(t, int64(3), manager.Logger().Logs()[0].id)
 }
 
 func TestGameManager_GameObjects(t *testing.T) {
 	manager := NewGameManager()
 	asteroid := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
-	spaceship := NewSpaceship(1, "TestShip", physics.Vector2{X: 0, Y: 0}, 100)
+	spaceship := NewSpaceship(1, "TestShip", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
 
 	manager.AddGameObject(asteroid)
 	manager.AddSpaceship(spaceship)
@@ -30,20 +44,135 @@ func TestGameManager_GameObjects(t *testing.T) {
 
 func TestGameManager_HasEnded(t *testing.T) {
 	manager := NewGameManager()
-	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 100)
-	ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 0, Y: 0}, 100)
+	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
+	ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
 
 	_ = manager.AddSpaceship(ship1)
 	_ = manager.AddSpaceship(ship2)
 
 	assert.False(t, manager.HasEnded(0))
 
-	manager.OnShipDestroyed()
+	ship2.health = 0
+	manager.OnShipDestroyed(ship2)
 
 	// Graceful end timer
 	assert.False(t, manager.HasEnded(0))
 	assert.False(t, manager.HasEnded(ShipExplosionDurationSec*1000+100))
 	assert.True(t, manager.HasEnded(0))
+	assert.Equal(t, EndReasonEliminated, manager.EndReason())
+}
+
+func TestGameManager_HasEnded_TimeLimit(t *testing.T) {
+	var tests = []struct {
+		name        string
+		suddenDeath SuddenDeath
+		elapsedMs   float64
+		expected    bool
+	}{
+		{"before the time limit", SuddenDeathOff, 59950, false},
+		{"time limit", SuddenDeathOff, 60000, true},
+		{"sudden death", SuddenDeathNoRecharge, 60000, false},
+		{"sudden death over", SuddenDeathNoRecharge, 90000, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := NewGameManager()
+			manager.rules.TimeLimitSec = 60
+			manager.rules.SuddenDeath = test.suddenDeath
+			manager.rules.SuddenDeathDurationSec = 30
+			_ = manager.AddSpaceship(NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 0, DefaultRules()))
+			_ = manager.AddSpaceship(NewSpaceship(2, "Ship2", physics.Vector2{X: 0, Y: 0}, 0, DefaultRules()))
+			manager.time = SimulationTime{ElapsedMs: test.elapsedMs}
+
+			assert.Equal(t, test.expected, manager.HasEnded(0))
+			if test.expected {
+				assert.Equal(t, EndReasonTimeLimit, manager.EndReason())
+			} else {
+				assert.Equal(t, EndReasonNone, manager.EndReason())
+			}
+		})
+	}
+}
+
+func TestGameManager_SuddenDeath(t *testing.T) {
+	manager := NewGameManager()
+	manager.size = physics.Size{Width: 600, Height: 800}
+	manager.rules.SuddenDeath = SuddenDeathShrinkingZone
+	manager.rules.SuddenDeathDurationSec = 20
+
+	// Without the time limit
+	manager.time = SimulationTime{ElapsedMs: 60000}
+	assert.Equal(t, SuddenDeathOff, manager.SuddenDeath())
+	assert.Equal(t, 500.0, manager.SafeZoneRadius())
+
+	manager.rules.TimeLimitSec = 60
+	manager.time = SimulationTime{ElapsedMs: 59950}
+	assert.Equal(t, SuddenDeathOff, manager.SuddenDeath())
+	assert.Equal(t, 500.0, manager.SafeZoneRadius())
+
+	manager.time = SimulationTime{ElapsedMs: 65000}
+	assert.Equal(t, SuddenDeathShrinkingZone, manager.SuddenDeath())
+	assert.Equal(t, 375.0, manager.SafeZoneRadius())
+
+	manager.time = SimulationTime{ElapsedMs: 90000}
+	assert.Equal(t, 0.0, manager.SafeZoneRadius())
+}
+
+func TestGameManager_HasEnded_Teams(t *testing.T) {
+	var tests = []struct {
+		name      string
+		teams     []string
+		destroyed []int
+		expected  bool
+	}{
+		{"teams standing", []string{"red", "red", "blue", "blue"}, []int{0, 2}, false},
+		{"last team standing", []string{"red", "red", "blue", "blue"}, []int{0, 1}, true},
+		{"ship without a team standing", []string{"red", "red", ""}, []int{0}, false},
+		{"ships without a team", []string{"", "", "red"}, []int{2}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := NewGameManager()
+			ships := []*Spaceship{}
+			for i, team := range test.teams {
+				ship := NewSpaceship(int64(i), fmt.Sprintf("Ship%d", i), physics.Vector2{X: 0, Y: 0}, 0, DefaultRules())
+				ship.team = team
+				_ = manager.AddSpaceship(ship)
+				ships = append(ships, ship)
+			}
+			for _, i := range test.destroyed {
+				ships[i].health = 0
+			}
+
+			assert.Equal(t, test.expected, manager.HasEnded(0))
+		})
+	}
+}
+
+func TestGameManager_DamageMultiplier(t *testing.T) {
+	var tests = []struct {
+		friendlyFire FriendlyFire
+		expected     float64
+	}{
+		{FriendlyFireOff, 0},
+		{FriendlyFireReduced, FriendlyFireCoefficient},
+		{FriendlyFireFull, 1},
+	}
+
+	for _, test := range tests {
+		manager := NewGameManager()
+		manager.rules.FriendlyFire = test.friendlyFire
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, 0, DefaultRules())
+		teammate := NewSpaceship(2, "teammate", physics.Vector2{X: 0, Y: 0}, 0, DefaultRules())
+		enemy := NewSpaceship(3, "enemy", physics.Vector2{X: 0, Y: 0}, 0, DefaultRules())
+		ship.team, teammate.team, enemy.team = "red", "red", "blue"
+
+		assert.Equal(t, test.expected, manager.DamageMultiplier(ship, teammate))
+		assert.Equal(t, 1.0, manager.DamageMultiplier(ship, enemy))
+		assert.Equal(t, 1.0, manager.DamageMultiplier(nil, teammate))
+	}
 }
 
 func TestGameManager_GetGameObjectByID(t *testing.T) {
@@ -66,7 +195,7 @@ func TestGameManager_GetGameObjectByIndex(t *testing.T) {
 func TestGameManager_GameObjectSize(t *testing.T) {
 	manager := NewGameManager()
 	asteroid := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
-	spaceship := NewSpaceship(1, "TestShip", physics.Vector2{X: 0, Y: 0}, 100)
+	spaceship := NewSpaceship(1, "TestShip", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
 
 	assert.Equal(t, 0, manager.GameObjectSize())
 	manager.AddGameObject(asteroid)
@@ -117,7 +246,7 @@ func TestGameManager_RemoveGameObjectByIndex(t *testing.T) {
 
 func TestGameManager_AddSpaceship(t *testing.T) {
 	manager := NewGameManager()
-	ship := NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 100)
+	ship := NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
 
 	err := manager.AddSpaceship(ship)
 	assert.NoError(t, err)
@@ -131,7 +260,7 @@ func TestGameManager_AddSpaceship(t *testing.T) {
 
 func TestGameManager_GetSpaceship(t *testing.T) {
 	manager := NewGameManager()
-	ship := NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 100)
+	ship := NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
 
 	err := manager.AddSpaceship(ship)
 	assert.NoError(t, err)
@@ -148,7 +277,7 @@ func TestGameManager_GetSpaceship(t *testing.T) {
 
 func TestGameManager_RemoveSpaceship(t *testing.T) {
 	manager := NewGameManager()
-	ship := NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 100)
+	ship := NewSpaceship(1, "Ship", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
 
 	err := manager.AddSpaceship(ship)
 	assert.NoError(t, err)
@@ -165,25 +294,29 @@ func TestGameManager_RemoveSpaceship(t *testing.T) {
 
 func TestGameManager_OnShipDestroyed(t *testing.T) {
 	manager := NewGameManager()
-	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 100)
-	ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 0, Y: 0}, 100)
+	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
+	ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
+	ship3 := NewSpaceship(3, "Ship3", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
 
 	_ = manager.AddSpaceship(ship1)
 	_ = manager.AddSpaceship(ship2)
+	_ = manager.AddSpaceship(ship3)
 
-	manager.OnShipDestroyed()
-	assert.Equal(t, 1, manager.destroyedShips)
-	assert.Equal(t, float64(ShipExplosionDurationSec*1000+100), manager.gracefulEndTimerMs)
+	ship1.health = 0
+	manager.OnShipDestroyed(ship1)
+	assert.Equal(t, 2, manager.aliveSides())
+	assert.Equal(t, float64(0), manager.gracefulEndTimerMs)
 
-	manager.OnShipDestroyed()
-	assert.Equal(t, 2, manager.destroyedShips)
+	ship2.health = 0
+	manager.OnShipDestroyed(ship2)
+	assert.Equal(t, 1, manager.aliveSides())
 	assert.Equal(t, float64(ShipExplosionDurationSec*1000+100), manager.gracefulEndTimerMs)
 }
 
 func TestGameManager_Reset(t *testing.T) {
 	manager := NewGameManager()
-	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 100)
-	ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 0, Y: 0}, 100)
+	ship1 := NewSpaceship(1, "Ship1", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
+	ship2 := NewSpaceship(2, "Ship2", physics.Vector2{X: 0, Y: 0}, 100, DefaultRules())
 	asteroid := NewAsteroid(1, physics.Vector2{X: 0, Y: 0}, 5)
 	explosion := NewExplosion(1, physics.Vector2{X: 0, Y: 0}, 5, 2)
 
@@ -192,9 +325,12 @@ func TestGameManager_Reset(t *testing.T) {
 	manager.AddGameObject(asteroid)
 	manager.AddGameObject(explosion)
 
+	ship2.health = 0
+	manager.endReason = EndReasonEliminated
 	manager.Reset()
-	assert.Equal(t, 0, manager.destroyedShips)
+	assert.Equal(t, 2, manager.aliveSides())
 	assert.Equal(t, float64(0), manager.gracefulEndTimerMs)
+	assert.Equal(t, EndReasonNone, manager.EndReason())
 	assert.Len(t, manager.spaceShips, 2)
 	assert.Len(t, manager.gameObjects, 3)
 }
@@ -0,0 +1,191 @@
+package game
+
+import "fmt"
+
+// Mode selects the GameMode of the match, see Rules.Mode.
+type Mode string
+
+const (
+	// The last team or spaceship standing wins, the destroyed spaceships are out
+	ModeLastStanding Mode = "lastStanding"
+	// The destroyed spaceships respawn, the kills are the objective
+	ModeDeathmatch Mode = "deathmatch"
+	// The destroyed spaceships respawn, the time alone on the hill is the objective
+	ModeKingOfTheHill Mode = "kingOfTheHill"
+	// The destroyed spaceships respawn, the enemy flags brought to the own flag are the objective
+	ModeCaptureTheFlag Mode = "captureTheFlag"
+)
+
+// GameMode is the ruleset of the match: it places its objectives on the battlefield,
+// rewards the kills, decides what happens to the destroyed spaceships and when the match ends.
+// The modes keep their state in the game objects and the spaceships, so it is serialized with them.
+type GameMode interface {
+	Mode() Mode
+	// Setup places the objectives of the mode when the match starts or is reset,
+	// the objectives already on the battlefield are kept.
+	Setup(gameManager *GameManager)
+	// Update runs the objectives and the spawns of the mode, after the collisions of the tick.
+	Update(deltaTimeMs float64, gameManager *GameManager)
+	// OnKill rewards the killer, the teammates are not rewarded.
+	OnKill(killer *Spaceship, victim *Spaceship, gameManager *GameManager)
+	OnShipDestroyed(ship *Spaceship, gameManager *GameManager)
+	// HasEnded returns why the match ended by the conditions of the mode, EndReasonNone to go on.
+	// The time limit is common to all the modes, see GameManager.HasEnded.
+	HasEnded(gameManager *GameManager) EndReason
+	// Objective returns the progress of the spaceship towards the objective of the mode,
+	// it ranks the spaceships ahead of the score, see standing.compare.
+	Objective(ship *Spaceship) float64
+	// Eliminates tells whether the destroyed spaceships are out of the match and rank last.
+	Eliminates() bool
+}
+
+// NewGameMode creates the game mode of the rules, the rules must be valid, see Rules.Validate.
+func NewGameMode(rules *Rules) GameMode {
+	switch rules.Mode {
+	case ModeDeathmatch:
+		return &deathmatch{}
+	case ModeKingOfTheHill:
+		return &kingOfTheHill{}
+	case ModeCaptureTheFlag:
+		return &captureTheFlag{}
+	case ModeLastStanding:
+		return &lastStanding{respawn: rules.Respawn}
+	default:
+		panic(fmt.Sprintf("unknown game mode %q", rules.Mode))
+	}
+}
+
+// side returns the name of the side the spaceship fights for, its team or itself.
+func (ship *Spaceship) side() string {
+	if ship.team != "" {
+		return ship.team
+	}
+	return ship.name
+}
+
+// lastStanding is won by the last side standing. With the respawn rule the destroyed spaceships
+// respawn instead, the match is then decided by the time limit, see Rules.Respawn.
+type lastStanding struct {
+	respawn bool
+}
+
+func (mode *lastStanding) Mode() Mode {
+	return ModeLastStanding
+}
+
+func (mode *lastStanding) Setup(gameManager *GameManager) {}
+
+func (mode *lastStanding) Update(deltaTimeMs float64, gameManager *GameManager) {
+	if mode.respawn {
+		respawnShips(deltaTimeMs, gameManager)
+	}
+}
+
+func (mode *lastStanding) OnKill(killer *Spaceship, victim *Spaceship, gameManager *GameManager) {
+	killer.HasKilled(victim)
+}
+
+// OnShipDestroyed lets the explosions play out before the last side standing ends the match.
+func (mode *lastStanding) OnShipDestroyed(ship *Spaceship, gameManager *GameManager) {
+	if mode.respawn {
+		ship.respawnTimerSec = gameManager.rules.RespawnDelaySec
+		return
+	}
+	if gameManager.aliveSides() <= 1 {
+		gameManager.gracefulEndTimerMs = (gameManager.rules.ShipExplosionDurationSec * 1000) + 100
+	}
+}
+
+func (mode *lastStanding) HasEnded(gameManager *GameManager) EndReason {
+	if gameManager.aliveSides() <= 1 {
+		return EndReasonEliminated
+	}
+	return EndReasonNone
+}
+
+func (mode *lastStanding) Objective(ship *Spaceship) float64 {
+	return 0
+}
+
+func (mode *lastStanding) Eliminates() bool {
+	return !mode.respawn
+}
+
+// respawning is embedded by the modes in which the destroyed spaceships respawn
+// after the respawn delay, see Rules.RespawnDelaySec.
+type respawning struct{}
+
+func (mode *respawning) OnKill(killer *Spaceship, victim *Spaceship, gameManager *GameManager) {
+	killer.HasKilled(victim)
+}
+
+func (mode *respawning) OnShipDestroyed(ship *Spaceship, gameManager *GameManager) {
+	ship.respawnTimerSec = gameManager.rules.RespawnDelaySec
+}
+
+func (mode *respawning) Update(deltaTimeMs float64, gameManager *GameManager) {
+	respawnShips(deltaTimeMs, gameManager)
+}
+
+func (mode *respawning) Eliminates() bool {
+	return false
+}
+
+// hasEnded ends the match when a side reaches the objective limit, or when the opponents
+// are out for good, e.g. disqualified.
+func (mode *respawning) hasEnded(gameMode GameMode, gameManager *GameManager) EndReason {
+	if gameManager.aliveSides() <= 1 {
+		return EndReasonEliminated
+	}
+	limit := gameManager.rules.ObjectiveLimit
+	if limit <= 0 {
+		return EndReasonNone
+	}
+
+	objectives := map[string]float64{}
+	for _, gameObject := range gameManager.GameObjects() {
+		if ship, ok := gameObject.(*Spaceship); ok {
+			objectives[ship.side()] += gameMode.Objective(ship)
+			if objectives[ship.side()] >= limit {
+				return EndReasonObjective
+			}
+		}
+	}
+	return EndReasonNone
+}
+
+// respawnShips respawns the spaceships whose respawn delay is over at a safe spawn point,
+// they are invulnerable for a while, see GameManager.SpawnPoint.
+func respawnShips(deltaTimeMs float64, gameManager *GameManager) {
+	for _, gameObject := range gameManager.GameObjects() {
+		ship, ok := gameObject.(*Spaceship)
+		if !ok || ship.respawnTimerSec <= 0 {
+			continue
+		}
+		ship.respawnTimerSec -= deltaTimeMs / 1000
+		if ship.respawnTimerSec > 0 {
+			continue
+		}
+		ship.Respawn(gameManager.SpawnPoint(ship))
+		ship.respawns++
+		ship.invulnerableTimerSec = gameManager.rules.RespawnInvulnerabilitySec
+	}
+}
+
+type deathmatch struct {
+	respawning
+}
+
+func (mode *deathmatch) Mode() Mode {
+	return ModeDeathmatch
+}
+
+func (mode *deathmatch) Setup(gameManager *GameManager) {}
+
+func (mode *deathmatch) HasEnded(gameManager *GameManager) EndReason {
+	return mode.hasEnded(mode, gameManager)
+}
+
+func (mode *deathmatch) Objective(ship *Spaceship) float64 {
+	return float64(ship.kills)
+}
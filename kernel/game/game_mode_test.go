@@ -0,0 +1,198 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newModeTestGame(mode Mode, objectiveLimit float64) *Game {
+	rules := DefaultRules()
+	rules.Mode = mode
+	rules.ObjectiveLimit = objectiveLimit
+	return NewGame(physics.Size{Width: 1000, Height: 1000}, 1, rules)
+}
+
+func TestNewGameMode(t *testing.T) {
+	rules := DefaultRules()
+	for _, mode := range []Mode{ModeLastStanding, ModeDeathmatch, ModeKingOfTheHill, ModeCaptureTheFlag} {
+		rules.Mode = mode
+		assert.Equal(t, mode, NewGameMode(rules).Mode())
+	}
+	assert.Equal(t, ModeLastStanding, NewGame(physics.Size{Width: 100, Height: 100}, 1, DefaultRules()).manager.Mode().Mode())
+	assert.True(t, NewGameMode(DefaultRules()).Eliminates())
+
+	rules.Mode, rules.Respawn = ModeLastStanding, true
+	assert.False(t, NewGameMode(rules).Eliminates())
+
+	rules.Mode = "unknown"
+	assert.Panics(t, func() { NewGameMode(rules) })
+}
+
+func TestDeathmatch(t *testing.T) {
+	game := newModeTestGame(ModeDeathmatch, 2)
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.AddSpaceship("B", physics.Vector2{X: 500, Y: 500}, 0, "")
+	game.Start()
+	shipA, _ := game.manager.GetSpaceship("A")
+	shipB, _ := game.manager.GetSpaceship("B")
+
+	shipB.position = physics.Vector2{X: 700, Y: 700}
+	shipB.AddScore(10)
+	shipB.TakeDamage(MaxHealth, DamageTypeUnknown, &game.manager, shipA)
+	assert.Equal(t, int32(1), shipA.kills)
+	assert.Equal(t, float64(ScorePerKill), shipA.score)
+	assert.False(t, shipB.Enabled())
+	assert.Equal(t, float64(RespawnDelaySec), shipB.respawnTimerSec)
+
+	// Waiting to respawn, not eliminated
+	game.Update(16)
+	assert.Equal(t, Running, game.Status())
+	assert.Equal(t, "A", game.Winner())
+
+	for i := 0; i < RespawnDelaySec*1000/16; i++ {
+		game.Update(16)
+	}
+	assert.True(t, shipB.Enabled())
+	assert.Equal(t, float64(MaxHealth), shipB.health)
+	assert.Equal(t, physics.Vector2{X: 500, Y: 500}, shipB.position)
+	assert.Equal(t, float64(0), shipB.respawnTimerSec)
+	assert.Equal(t, float64(10), shipB.score)
+	assert.Equal(t, int32(1), shipB.deaths)
+	assert.Equal(t, int32(1), shipB.respawns)
+
+	// Invulnerable for a while after the respawn
+	assert.True(t, shipB.Invulnerable())
+	shipB.TakeDamage(MaxHealth, DamageTypeUnknown, &game.manager, shipA)
+	assert.Equal(t, float64(MaxHealth), shipB.health)
+	for i := 0; i < RespawnInvulnerabilitySec*1000/16+1; i++ {
+		game.Update(16)
+	}
+	assert.False(t, shipB.Invulnerable())
+
+	shipB.TakeDamage(MaxHealth, DamageTypeUnknown, &game.manager, shipA)
+	game.Update(16)
+	assert.Equal(t, Ended, game.Status())
+	assert.Equal(t, EndReasonObjective, game.EndReason())
+	assert.Equal(t, "A", game.Winner())
+}
+
+func TestDeathmatch_Disqualify(t *testing.T) {
+	game := newModeTestGame(ModeDeathmatch, 10)
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.AddSpaceship("B", physics.Vector2{X: 500, Y: 500}, 0, "")
+	game.AddSpaceship("C", physics.Vector2{X: 900, Y: 100}, 0, "")
+	game.Start()
+
+	// Disqualified while waiting to respawn
+	shipC, _ := game.manager.GetSpaceship("C")
+	shipC.TakeDamage(MaxHealth, DamageTypeUnknown, &game.manager, nil)
+	game.Disqualify("C")
+	for i := 0; i < RespawnDelaySec*1000/16+1; i++ {
+		game.Update(16)
+	}
+	assert.Equal(t, Running, game.Status())
+	assert.False(t, shipC.Enabled())
+	assert.Equal(t, int32(0), shipC.respawns)
+
+	// Out for good
+	game.Disqualify("B")
+	shipB, _ := game.manager.GetSpaceship("B")
+	assert.Equal(t, float64(0), shipB.respawnTimerSec)
+
+	game.Update(16)
+	assert.Equal(t, Ended, game.Status())
+	assert.Equal(t, EndReasonEliminated, game.EndReason())
+}
+
+func TestLastStanding_Respawn(t *testing.T) {
+	rules := DefaultRules()
+	rules.Respawn = true
+	rules.TimeLimitSec = 60
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1, rules)
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.AddSpaceship("B", physics.Vector2{X: 500, Y: 500}, 0, "")
+	game.Start()
+	shipB, _ := game.manager.GetSpaceship("B")
+
+	shipB.TakeDamage(MaxHealth, DamageTypeUnknown, &game.manager, nil)
+	for i := 0; i < RespawnDelaySec*1000/16+1; i++ {
+		game.Update(16)
+	}
+	assert.Equal(t, Running, game.Status())
+	assert.True(t, shipB.Enabled())
+	assert.Equal(t, int32(1), shipB.respawns)
+}
+
+func TestGameManager_SpawnPoint(t *testing.T) {
+	game := newModeTestGame(ModeDeathmatch, 10)
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 100}, 0, "red")
+	game.AddSpaceship("B", physics.Vector2{X: 500, Y: 500}, 0, "blue")
+	game.AddSpaceship("C", physics.Vector2{X: 150, Y: 100}, 0, "red")
+	shipA, _ := game.manager.GetSpaceship("A")
+	shipB, _ := game.manager.GetSpaceship("B")
+
+	// The teammates do not block the start position
+	assert.Equal(t, shipA.startPosition, game.manager.SpawnPoint(shipA))
+
+	// An opponent nearby
+	shipB.position = physics.Vector2{X: 120, Y: 100}
+	point := game.manager.SpawnPoint(shipA)
+	assert.NotEqual(t, shipA.startPosition, point)
+	assert.GreaterOrEqual(t, game.manager.clearance(shipA, point), float64(SpawnClearance))
+
+	// An asteroid on the start position
+	shipB.position = physics.Vector2{X: 500, Y: 500}
+	game.manager.AddGameObject(NewAsteroid(game.manager.NewID(), physics.Vector2{X: 100, Y: 100}, 20))
+	point = game.manager.SpawnPoint(shipA)
+	assert.Greater(t, physics.WrappedDistance(point, physics.Vector2{X: 100, Y: 100}, game.size), float64(SpawnClearance))
+}
+
+func TestGameMode_Serialize(t *testing.T) {
+	game := newModeTestGame(ModeCaptureTheFlag, 3)
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 100}, 0, "red")
+	game.AddSpaceship("B", physics.Vector2{X: 900, Y: 900}, 0, "blue")
+	game.Start()
+	shipA, _ := game.manager.GetSpaceship("A")
+	shipB, _ := game.manager.GetSpaceship("B")
+	shipB.position = physics.Vector2{X: 500, Y: 500}
+	shipA.position = physics.Vector2{X: 900, Y: 900}
+	game.Update(16)
+	shipB.TakeDamage(MaxHealth, DamageTypeUnknown, &game.manager, nil)
+
+	serialized, err := json.Marshal(game.Serialize())
+	assert.NoError(t, err)
+	deserialized, err := Deserialize(string(serialized))
+	assert.NoError(t, err)
+
+	assert.Equal(t, ModeCaptureTheFlag, deserialized.manager.Mode().Mode())
+	assert.Equal(t, game.Serialize(), deserialized.Serialize())
+	flags := (&captureTheFlag{}).flags(&deserialized.manager)
+	restoredA, _ := deserialized.manager.GetSpaceship("A")
+	restoredB, _ := deserialized.manager.GetSpaceship("B")
+	assert.Same(t, restoredA, flags[1].carrier)
+	assert.Equal(t, float64(RespawnDelaySec), restoredB.respawnTimerSec)
+}
+
+func TestGameMode_Serialize_EndedByObjective(t *testing.T) {
+	game := newModeTestGame(ModeDeathmatch, 1)
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.AddSpaceship("B", physics.Vector2{X: 500, Y: 500}, 0, "")
+	game.Start()
+	shipA, _ := game.manager.GetSpaceship("A")
+	shipB, _ := game.manager.GetSpaceship("B")
+	shipB.TakeDamage(MaxHealth, DamageTypeUnknown, &game.manager, shipA)
+	game.Update(16)
+	assert.Equal(t, EndReasonObjective, game.EndReason())
+
+	serialized, err := json.Marshal(game.Serialize())
+	assert.NoError(t, err)
+	deserialized, err := Deserialize(string(serialized))
+	assert.NoError(t, err)
+
+	assert.Equal(t, Ended, deserialized.Status())
+	assert.Equal(t, EndReasonObjective, deserialized.EndReason())
+	assert.Equal(t, game.Serialize(), deserialized.Serialize())
+}
@@ -2,6 +2,9 @@ package game
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
 	"testing"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
@@ -48,7 +51,7 @@ func (object *MockGameObject) Serialize() map[string]interface{} {
 }
 
 func TestNewGame(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
 
 	assert.Equal(t, int64(1234567890), game.seed)
 	assert.Equal(t, Initialized, game.status)
@@ -56,7 +59,7 @@ func TestNewGame(t *testing.T) {
 }
 
 func TestGame_Status(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
 	assert.Equal(t, Initialized, game.Status())
 
 	game.Start()
@@ -64,7 +67,7 @@ func TestGame_Status(t *testing.T) {
 }
 
 func TestGame_Start(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
 	assert.Equal(t, Initialized, game.Status())
 
 	game.Start()
@@ -78,7 +81,7 @@ func TestGame_Start(t *testing.T) {
 }
 
 func TestGame_Pause(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
 	assert.Equal(t, Initialized, game.Status())
 
 	game.Start()
@@ -94,20 +97,22 @@ func TestGame_Pause(t *testing.T) {
 }
 
 func TestGame_Reset(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
 	assert.Equal(t, Initialized, game.Status())
 
 	game.Start()
 	assert.Equal(t, Running, game.Status())
 
+	game.manager.time = SimulationTime{Tick: 1, ElapsedMs: 50}
 	game.Reset()
 	assert.Equal(t, Running, game.Status())
 	assert.Equal(t, 0, len(game.manager.Logger().Logs()))
+	assert.Equal(t, SimulationTime{}, game.Time())
 }
 
 func TestGame_Update(t *testing.T) {
 	t.Run("Updates game object positions", func(t *testing.T) {
-		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
 		gameObject := &MockGameObject{
 			position: physics.Vector2{X: 100, Y: 100},
 		}
@@ -120,7 +125,7 @@ func TestGame_Update(t *testing.T) {
 	})
 
 	t.Run("Wraps objects around screen edges", func(t *testing.T) {
-		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
 		gameObject := &MockGameObject{
 			position: physics.Vector2{X: 1000, Y: 1000},
 		}
@@ -140,9 +145,9 @@ func TestGame_Update(t *testing.T) {
 	})
 
 	t.Run("Handles collisions between objects", func(t *testing.T) {
-		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
-		spaceship := NewSpaceship(NewUUID(), "test", physics.Vector2{X: 100, Y: 100}, 0)
-		asteroid := NewAsteroid(NewUUID(), physics.Vector2{X: 150, Y: 100}, 50)
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+		spaceship := NewSpaceship(game.manager.NewID(), "test", physics.Vector2{X: 100, Y: 100}, 0, DefaultRules())
+		asteroid := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 150, Y: 100}, 50)
 		game.manager.AddGameObjects([]GameObject{spaceship, asteroid})
 
 		game.Update(100)
@@ -152,10 +157,90 @@ func TestGame_Update(t *testing.T) {
 		assert.True(t, asteroid.Enabled())
 	})
 
+	t.Run("Fast projectiles do not tunnel through objects", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+		owner := NewSpaceship(game.manager.NewID(), "owner", physics.Vector2{X: 100, Y: 500}, 0, DefaultRules())
+		target := NewSpaceship(game.manager.NewID(), "target", physics.Vector2{X: 232, Y: 100}, 0, DefaultRules())
+		laser := NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 200, Y: 100}, 0, owner)
+		game.manager.AddGameObjects([]GameObject{owner, target, laser})
+
+		// The laser moves 64 m, from in front of the target to behind it
+		game.Update(200)
+
+		assert.Equal(t, float64(MaxHealth-LaserDamage), target.health)
+		assert.False(t, laser.Enabled())
+	})
+
+	t.Run("Projectiles hit the first object along their path", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+		owner := NewSpaceship(game.manager.NewID(), "owner", physics.Vector2{X: 100, Y: 500}, 0, DefaultRules())
+		far := NewSpaceship(game.manager.NewID(), "far", physics.Vector2{X: 265, Y: 100}, 0, DefaultRules())
+		near := NewSpaceship(game.manager.NewID(), "near", physics.Vector2{X: 232, Y: 100}, 0, DefaultRules())
+		laser := NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 200, Y: 100}, 0, owner)
+		game.manager.AddGameObjects([]GameObject{owner, far, near, laser})
+
+		game.Update(200)
+
+		assert.Equal(t, float64(MaxHealth), far.health)
+		assert.Equal(t, float64(MaxHealth-LaserDamage), near.health)
+		// The explosion is at the point of impact
+		assert.InDelta(t, 216, laser.Position().X, 0.01)
+	})
+
+	t.Run("Handles collisions across the battlefield edges", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+		owner := NewSpaceship(game.manager.NewID(), "owner", physics.Vector2{X: 500, Y: 500}, 0, DefaultRules())
+		target := NewSpaceship(game.manager.NewID(), "target", physics.Vector2{X: 5, Y: 100}, 0, DefaultRules())
+		rocket := NewRocketProjectile(game.manager.NewID(), physics.Vector2{X: 980, Y: 100}, math.Pi, owner)
+		rocket.velocity = physics.Vector2{}
+		asteroid := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 500, Y: 990}, 20)
+		ship := NewSpaceship(game.manager.NewID(), "ship", physics.Vector2{X: 500, Y: 5}, 0, DefaultRules())
+		game.manager.AddGameObjects([]GameObject{owner, target, rocket, asteroid, ship})
+
+		game.Update(50)
+
+		assert.Equal(t, float64(MaxHealth-RocketDamage), target.health)
+		assert.False(t, rocket.Enabled())
+		assert.False(t, ship.Enabled())
+	})
+
+	t.Run("Projectiles are swept across the battlefield edges", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+		owner := NewSpaceship(game.manager.NewID(), "owner", physics.Vector2{X: 500, Y: 500}, 0, DefaultRules())
+		target := NewSpaceship(game.manager.NewID(), "target", physics.Vector2{X: 20, Y: 100}, 0, DefaultRules())
+		laser := NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 990, Y: 100}, 0, owner)
+		game.manager.AddGameObjects([]GameObject{owner, target, laser})
+
+		// The laser moves 64 m, wraps around and passes the target
+		game.Update(200)
+
+		assert.Equal(t, float64(MaxHealth-LaserDamage), target.health)
+	})
+
+	t.Run("Teammates pass through each other with the friendly fire off", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+		owner := NewSpaceship(game.manager.NewID(), "owner", physics.Vector2{X: 100, Y: 500}, 0, DefaultRules())
+		teammate := NewSpaceship(game.manager.NewID(), "teammate", physics.Vector2{X: 232, Y: 100}, 0, DefaultRules())
+		enemy := NewSpaceship(game.manager.NewID(), "enemy", physics.Vector2{X: 265, Y: 100}, 0, DefaultRules())
+		owner.team, teammate.team, enemy.team = "red", "red", "blue"
+		laser := NewLaserProjectile(game.manager.NewID(), physics.Vector2{X: 200, Y: 100}, 0, owner)
+		wingman := NewSpaceship(game.manager.NewID(), "wingman", physics.Vector2{X: 110, Y: 500}, 0, DefaultRules())
+		wingman.team = "red"
+		game.manager.AddGameObjects([]GameObject{owner, teammate, enemy, laser, wingman})
+
+		game.Update(200)
+
+		assert.Equal(t, float64(MaxHealth), teammate.health)
+		assert.Equal(t, float64(MaxHealth-LaserDamage), enemy.health)
+		// The overlapping teammates do not collide either
+		assert.Equal(t, float64(MaxHealth), owner.health)
+		assert.Equal(t, float64(MaxHealth), wingman.health)
+	})
+
 	t.Run("Handles collisions between objects, disabled colliding object", func(t *testing.T) {
-		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
-		spaceship := NewSpaceship(NewUUID(), "test", physics.Vector2{X: 100, Y: 100}, 0)
-		asteroid := NewAsteroid(NewUUID(), physics.Vector2{X: 150, Y: 100}, 50)
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+		spaceship := NewSpaceship(game.manager.NewID(), "test", physics.Vector2{X: 100, Y: 100}, 0, DefaultRules())
+		asteroid := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 150, Y: 100}, 50)
 		asteroid.SetEnabled(false)
 		game.manager.AddGameObjects([]GameObject{spaceship, asteroid})
 
@@ -167,9 +252,9 @@ func TestGame_Update(t *testing.T) {
 	})
 
 	t.Run("Ignores disabled objects", func(t *testing.T) {
-		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
-		spaceship := NewSpaceship(NewUUID(), "test", physics.Vector2{X: 100, Y: 100}, 0)
-		asteroid := NewAsteroid(NewUUID(), physics.Vector2{X: 150, Y: 100}, 50)
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+		spaceship := NewSpaceship(game.manager.NewID(), "test", physics.Vector2{X: 100, Y: 100}, 0, DefaultRules())
+		asteroid := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 150, Y: 100}, 50)
 		game.manager.AddGameObjects([]GameObject{spaceship, asteroid})
 
 		spaceship.SetEnabled(false)
@@ -180,29 +265,377 @@ func TestGame_Update(t *testing.T) {
 		assert.True(t, asteroid.Enabled())
 	})
 
+	t.Run("Advances the simulation time", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+
+		game.Update(50)
+		game.Update(25)
+
+		assert.Equal(t, SimulationTime{Tick: 2, ElapsedMs: 75}, game.Time())
+	})
+
+	t.Run("Logs carry the simulation time", func(t *testing.T) {
+		run := func() []map[string]interface{} {
+			game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+			game.AddSpaceship("ship1", physics.Vector2{X: 100, Y: 100}, 0, "")
+			game.AddSpaceship("ship2", physics.Vector2{X: 300, Y: 100}, 0, "")
+			game.Start()
+			game.ApplyAction("ship1", Action{Type: ActionFireRocket})
+			for i := 0; i < 20 && game.Status() != Ended; i++ {
+				game.Update(50)
+			}
+
+			logs := []map[string]interface{}{}
+			for _, log := range game.manager.Logger().Logs() {
+				logs = append(logs, log.Serialize())
+			}
+			return logs
+		}
+
+		logs := run()
+		assert.Equal(t, logs, run())
+		assert.Equal(t, "00:00:00.000", logs[0]["time"])
+		assert.Equal(t, int64(11), logs[1]["tick"])
+		assert.Equal(t, 550.0, logs[1]["elapsedMs"])
+		assert.Equal(t, "00:00:00.550", logs[1]["time"])
+	})
+
 	t.Run("Game ends when manager ends", func(t *testing.T) {
-		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
 		game.Start()
 
 		game.Update(100) // 100ms
 
 		assert.Equal(t, Ended, game.Status())
-		assert.Equal(t, "Game state changed to: ended", game.manager.Logger().Logs()[1].message)
+		assert.Equal(t, "Game state changed to: ended (eliminated), draw", game.manager.Logger().Logs()[1].message)
+	})
+
+	t.Run("Game ends at the time limit", func(t *testing.T) {
+		rules := DefaultRules()
+		rules.TimeLimitSec = 1
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, rules)
+		game.AddSpaceship("ship1", physics.Vector2{X: 100, Y: 100}, 0, "")
+		game.AddSpaceship("ship2", physics.Vector2{X: 500, Y: 500}, 0, "")
+		ship2, _ := game.manager.GetSpaceship("ship2")
+		ship2.score = 10
+		game.Start()
+
+		for i := 0; i < 19; i++ {
+			game.Update(50)
+		}
+		assert.Equal(t, Running, game.Status())
+
+		game.Update(50)
+		assert.Equal(t, Ended, game.Status())
+		assert.Equal(t, EndReasonTimeLimit, game.EndReason())
+		assert.Equal(t, "Game state changed to: ended (timeLimit), \"ship2\" won", game.manager.Logger().Logs()[1].message)
+		assert.Equal(t, "timeLimit", game.Serialize()["endReason"])
+	})
+
+	t.Run("Safe zone shrinks in the sudden death", func(t *testing.T) {
+		rules := DefaultRules()
+		rules.TimeLimitSec = 1
+		rules.SuddenDeath = SuddenDeathShrinkingZone
+		rules.SuddenDeathDurationSec = 10
+		rules.SafeZoneDamageSec = 100
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, rules)
+		game.AddSpaceship("center", physics.Vector2{X: 500, Y: 500}, 0, "")
+		game.AddSpaceship("corner", physics.Vector2{X: 50, Y: 50}, 0, "")
+		center, _ := game.manager.GetSpaceship("center")
+		corner, _ := game.manager.GetSpaceship("corner")
+		game.Start()
+
+		for i := 0; i < 20; i++ {
+			game.Update(50)
+		}
+		assert.Equal(t, "Time limit reached, sudden death: shrinkingZone", game.manager.Logger().Logs()[1].message)
+		assert.Equal(t, float64(MaxHealth), corner.health)
+
+		// The corner is outside of the zone after 10 % of the sudden death
+		for i := 0; i < 21; i++ {
+			game.Update(50)
+		}
+		assert.Equal(t, float64(MaxHealth-5), corner.health)
+
+		for game.Status() == Running {
+			game.Update(50)
+		}
+		assert.Equal(t, EndReasonEliminated, game.EndReason())
+		assert.Equal(t, float64(MaxHealth), center.health)
+		assert.True(t, corner.health <= 0)
+	})
+
+	t.Run("Energy does not recharge in the sudden death", func(t *testing.T) {
+		rules := DefaultRules()
+		rules.TimeLimitSec = 1
+		rules.SuddenDeath = SuddenDeathNoRecharge
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, rules)
+		game.AddSpaceship("ship1", physics.Vector2{X: 100, Y: 100}, 0, "")
+		game.AddSpaceship("ship2", physics.Vector2{X: 500, Y: 500}, 0, "")
+		ship1, _ := game.manager.GetSpaceship("ship1")
+		game.Start()
+
+		ship1.energy = 50
+		game.Update(50)
+		assert.Greater(t, ship1.energy, 50.0)
+
+		game.manager.time = SimulationTime{Tick: 20, ElapsedMs: 1000}
+		ship1.energy = 50
+		game.Update(50)
+		assert.Equal(t, 50.0, ship1.energy)
+		assert.Equal(t, Running, game.Status())
 	})
 }
 
+func TestGame_Concurrent(t *testing.T) {
+	run := func() string {
+		game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+		game.SeedAsteroids()
+		game.AddSpaceship("ship1", physics.Vector2{X: 300, Y: 200}, 0, "")
+		game.AddSpaceship("ship2", physics.Vector2{X: 700, Y: 500}, math.Pi, "")
+		game.Start()
+		for i := 0; i < 100; i++ {
+			game.ApplyAction("ship1", Action{Type: ActionFireLaser})
+			game.ApplyAction("ship2", Action{Type: ActionFireRocket})
+			game.Update(50)
+		}
+		serialized, _ := json.Marshal(game.Serialize())
+		return string(serialized)
+	}
+
+	expected := run()
+	results := make([]string, 8)
+	var wait sync.WaitGroup
+	for i := range results {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			results[i] = run()
+		}(i)
+	}
+	wait.Wait()
+
+	for _, result := range results {
+		assert.Equal(t, expected, result)
+	}
+}
+
+func TestGame_CollisionPairs(t *testing.T) {
+	game := newCrowdedGame(32)
+	// Asteroids overlapping the ships and each other
+	for i, gameObject := range game.manager.GameObjects() {
+		if _, ok := gameObject.(*Spaceship); ok && i%2 == 0 {
+			position := gameObject.Position()
+			game.manager.AddGameObject(NewAsteroid(game.manager.NewID(), physics.Vector2{X: position.X + 20, Y: position.Y}, 10))
+			game.manager.AddGameObject(NewAsteroid(game.manager.NewID(), physics.Vector2{X: position.X + 20, Y: position.Y + 15}, 10))
+		}
+	}
+	gameObjects := game.manager.GameObjects()
+
+	broadPhase := [][2]int{}
+	for _, pair := range game.collisionPairs(gameObjects) {
+		if gameObjects[pair.A].Collider().CollidesWith(gameObjects[pair.B].Collider()) {
+			broadPhase = append(broadPhase, [2]int{pair.A, pair.B})
+		}
+	}
+
+	assert.NotEmpty(t, broadPhase)
+	assert.Equal(t, bruteForceCollisions(gameObjects), broadPhase)
+}
+
+func TestGame_Bounce(t *testing.T) {
+	newGame := func(friendlyFire FriendlyFire) *Game {
+		rules := DefaultRules()
+		rules.CollisionResponse = CollisionResponsePhysical
+		rules.FriendlyFire = friendlyFire
+		return NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, rules)
+	}
+
+	t.Run("Exchanges the momentum of the spaceships", func(t *testing.T) {
+		game := newGame(FriendlyFireOff)
+		game.AddSpaceship("A", physics.Vector2{X: 100, Y: 500}, 0, "")
+		game.AddSpaceship("B", physics.Vector2{X: 125, Y: 500}, 0, "")
+		shipA, _ := game.manager.GetSpaceship("A")
+		shipB, _ := game.manager.GetSpaceship("B")
+		shipA.velocity = physics.Vector2{X: 50, Y: 0}
+		shipB.velocity = physics.Vector2{X: -50, Y: 0}
+
+		game.resolveCollisions()
+
+		assert.True(t, shipA.Enabled())
+		assert.True(t, shipB.Enabled())
+		assert.InDelta(t, -25, shipA.velocity.X, 1e-9)
+		assert.InDelta(t, 25, shipB.velocity.X, 1e-9)
+		assert.InDelta(t, 97.5, shipA.position.X, 1e-9)
+		assert.InDelta(t, 127.5, shipB.position.X, 1e-9)
+		assert.Equal(t, shipA.position, shipA.collider.Position())
+		// The impact speed times the collision damage coefficient
+		assert.InDelta(t, MaxHealth-50, shipA.health, 1e-9)
+		assert.InDelta(t, MaxHealth-50, shipB.health, 1e-9)
+		assert.Len(t, game.manager.Logger().Logs(), 1)
+		assert.Equal(t, LogTypeCollision, game.manager.Logger().Logs()[0].logType)
+	})
+
+	t.Run("Bounces across the edges of the battlefield", func(t *testing.T) {
+		game := newGame(FriendlyFireOff)
+		game.AddSpaceship("A", physics.Vector2{X: 5, Y: 500}, 0, "")
+		game.AddSpaceship("B", physics.Vector2{X: 985, Y: 500}, 0, "")
+		shipA, _ := game.manager.GetSpaceship("A")
+		shipB, _ := game.manager.GetSpaceship("B")
+		shipA.velocity = physics.Vector2{X: -50, Y: 0}
+		shipB.velocity = physics.Vector2{X: 50, Y: 0}
+
+		game.resolveCollisions()
+
+		assert.InDelta(t, 25, shipA.velocity.X, 1e-9)
+		assert.InDelta(t, -25, shipB.velocity.X, 1e-9)
+		assert.InDelta(t, 10, shipA.position.X, 1e-9)
+		assert.InDelta(t, 980, shipB.position.X, 1e-9)
+	})
+
+	t.Run("Deals the teammates the damage of the friendly fire", func(t *testing.T) {
+		game := newGame(FriendlyFireReduced)
+		game.AddSpaceship("A", physics.Vector2{X: 100, Y: 500}, 0, "red")
+		game.AddSpaceship("B", physics.Vector2{X: 125, Y: 500}, 0, "red")
+		shipA, _ := game.manager.GetSpaceship("A")
+		shipB, _ := game.manager.GetSpaceship("B")
+		shipA.velocity = physics.Vector2{X: 50, Y: 0}
+		shipB.velocity = physics.Vector2{X: -50, Y: 0}
+
+		game.resolveCollisions()
+
+		assert.InDelta(t, MaxHealth-50*FriendlyFireCoefficient, shipA.health, 1e-9)
+		assert.InDelta(t, MaxHealth-50*FriendlyFireCoefficient, shipB.health, 1e-9)
+	})
+
+	t.Run("Bounces off the heavier asteroid", func(t *testing.T) {
+		game := newGame(FriendlyFireOff)
+		game.AddSpaceship("ship", physics.Vector2{X: 100, Y: 500}, 0, "")
+		ship, _ := game.manager.GetSpaceship("ship")
+		ship.velocity = physics.Vector2{X: 100, Y: 0}
+		asteroid := NewAsteroid(game.manager.NewID(), physics.Vector2{X: 125, Y: 500}, 20)
+		game.manager.AddGameObject(asteroid)
+		shipMass, asteroidMass := game.Rules().ShipMass, game.Rules().AsteroidDensity*asteroid.Area()
+
+		game.resolveCollisions()
+
+		assert.True(t, ship.Enabled())
+		assert.True(t, asteroid.Enabled())
+		assert.Less(t, ship.velocity.X, 0.0)
+		assert.Greater(t, asteroid.velocity.X, 0.0)
+		assert.InDelta(t, shipMass*100, shipMass*ship.velocity.X+asteroidMass*asteroid.velocity.X, 1e-6)
+		assert.InDelta(t, MaxHealth-50, ship.health, 1e-9)
+		assert.False(t, ship.Collider().CollidesWith(asteroid.Collider()))
+	})
+
+	t.Run("Separates the bodies moving apart without damage", func(t *testing.T) {
+		game := newGame(FriendlyFireOff)
+		game.AddSpaceship("A", physics.Vector2{X: 100, Y: 500}, 0, "")
+		game.AddSpaceship("B", physics.Vector2{X: 125, Y: 500}, 0, "")
+		shipA, _ := game.manager.GetSpaceship("A")
+		shipB, _ := game.manager.GetSpaceship("B")
+		shipA.velocity = physics.Vector2{X: -10, Y: 0}
+
+		game.resolveCollisions()
+
+		assert.Equal(t, physics.Vector2{X: -10, Y: 0}, shipA.velocity)
+		assert.Equal(t, physics.Vector2{}, shipB.velocity)
+		assert.InDelta(t, 30, shipB.position.X-shipA.position.X, 1e-9)
+		assert.Equal(t, float64(MaxHealth), shipA.health)
+		assert.Empty(t, game.manager.Logger().Logs())
+	})
+
+	t.Run("Destroys the spaceship in the destroy mode", func(t *testing.T) {
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+		game.AddSpaceship("ship", physics.Vector2{X: 100, Y: 500}, 0, "")
+		ship, _ := game.manager.GetSpaceship("ship")
+		ship.velocity = physics.Vector2{X: 100, Y: 0}
+		game.manager.AddGameObject(NewAsteroid(game.manager.NewID(), physics.Vector2{X: 125, Y: 500}, 20))
+
+		game.resolveCollisions()
+
+		assert.False(t, ship.Enabled())
+	})
+}
+
+func BenchmarkGame_Collisions(b *testing.B) {
+	for _, ships := range []int{8, 32, 128} {
+		game := newCrowdedGame(ships)
+		gameObjects := game.manager.GameObjects()
+
+		b.Run(fmt.Sprintf("ships=%d/broad-phase", ships), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				for _, pair := range game.collisionPairs(gameObjects) {
+					gameObjects[pair.A].Collider().CollidesWith(gameObjects[pair.B].Collider())
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("ships=%d/brute-force", ships), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bruteForceCollisions(gameObjects)
+			}
+		})
+	}
+}
+
+// newCrowdedGame creates a running game with the ships on a grid, spinning and firing lasers.
+func newCrowdedGame(ships int) *Game {
+	game := NewGame(physics.Size{Width: 1920, Height: 1080}, 1234567890, DefaultRules())
+	game.SeedAsteroids()
+	columns := int(math.Ceil(math.Sqrt(float64(ships))))
+	rows := (ships + columns - 1) / columns
+	for i := 0; i < ships; i++ {
+		position := physics.Vector2{
+			X: 1920 / float64(columns) * (float64(i%columns) + 0.5),
+			Y: 1080 / float64(rows) * (float64(i/columns) + 0.5),
+		}
+		game.AddSpaceship(fmt.Sprintf("ship%d", i), position, float64(i), "")
+	}
+
+	game.Start()
+	for tick := 0; tick < 20; tick++ {
+		for i := 0; i < ships; i++ {
+			name := fmt.Sprintf("ship%d", i)
+			game.ApplyAction(name, Action{Type: ActionSetEngineThrust, Args: []float64{0, 50, -50}})
+			game.ApplyAction(name, Action{Type: ActionFireLaser})
+		}
+		game.Update(50)
+	}
+	return game
+}
+
+func bruteForceCollisions(gameObjects []GameObject) [][2]int {
+	collisions := [][2]int{}
+	for i := 0; i < len(gameObjects)-1; i++ {
+		a := gameObjects[i]
+		if !a.Enabled() || a.Collider() == nil {
+			continue
+		}
+		for j := i + 1; j < len(gameObjects); j++ {
+			b := gameObjects[j]
+			if !b.Enabled() || b.Collider() == nil {
+				continue
+			}
+			if a.Collider().CollidesWith(b.Collider()) {
+				collisions = append(collisions, [2]int{i, j})
+			}
+		}
+	}
+	return collisions
+}
+
 func TestGame_SeedAsteroids(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890)
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
 	game.SeedAsteroids()
 
 	assert.GreaterOrEqual(t, len(game.manager.GameObjects()), MinAsteroids)
 }
 
 func TestGame_SpaceshipAction(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
-	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0, "")
 
-	game.SpaceshipAction("test", func(spaceShip *Spaceship, gameManager *GameManager) {
+	game.spaceshipAction("test", func(spaceShip *Spaceship, gameManager *GameManager) {
 		spaceShip.position = physics.Vector2{X: 200, Y: 200}
 	})
 
@@ -211,15 +644,15 @@ func TestGame_SpaceshipAction(t *testing.T) {
 	assert.Equal(t, physics.Vector2{X: 200, Y: 200}, spaceship.position)
 
 	// Spaceship not found
-	err = game.SpaceshipAction("test1", func(spaceShip *Spaceship, gameManager *GameManager) {
+	err = game.spaceshipAction("test1", func(spaceShip *Spaceship, gameManager *GameManager) {
 		spaceShip.position = physics.Vector2{X: 200, Y: 200}
 	})
 	assert.Error(t, err)
 }
 
 func TestGame_AddSpaceship(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
-	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0, "")
 
 	gameObjects := game.manager.GameObjects()
 	spaceship := gameObjects[len(gameObjects)-1].(*Spaceship)
@@ -228,8 +661,8 @@ func TestGame_AddSpaceship(t *testing.T) {
 }
 
 func TestGame_RemoveSpaceship(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
-	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0, "")
 
 	game.RemoveSpaceship("test")
 
@@ -238,14 +671,16 @@ func TestGame_RemoveSpaceship(t *testing.T) {
 }
 
 func TestGame_Serialize(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
 	game.SeedAsteroids()
-	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0, "")
 	game.Start()
 
 	serialized := game.Serialize()
 	assert.Equal(t, "running", serialized["status"])
 	assert.Equal(t, int64(1234567890), serialized["seed"])
+	assert.Equal(t, int64(0), serialized["tick"])
+	assert.Equal(t, 0.0, serialized["elapsedMs"])
 	assert.Equal(t, 1024.0, serialized["size"].(map[string]interface{})["width"])
 	assert.Equal(t, 768.0, serialized["size"].(map[string]interface{})["height"])
 	assert.GreaterOrEqual(t, len(serialized["gameObjects"].([]interface{})), MinAsteroids)
@@ -258,21 +693,25 @@ func TestDeserialize_InvalidJSON(t *testing.T) {
 }
 
 func TestDeserialize(t *testing.T) {
-	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890)
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
 	game.SeedAsteroids()
-	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0)
-	game.manager.AddGameObject(NewExplosion(NewUUID(), physics.Vector2{X: 100, Y: 100}, 10, 1))
+	game.AddSpaceship("test", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.manager.AddGameObject(NewExplosion(game.manager.NewID(), physics.Vector2{X: 100, Y: 100}, 10, 1))
 	game.Start()
-	game.SpaceshipAction("test", func(spaceShip *Spaceship, gameManager *GameManager) {
+	game.spaceshipAction("test", func(spaceShip *Spaceship, gameManager *GameManager) {
 		spaceShip.FireLaser(gameManager)
 		spaceShip.FireRocket(gameManager)
+		spaceShip.SetRotationThrust(-30)
+		spaceShip.angularVelocity = 1.5
 	})
+	game.manager.time = SimulationTime{Tick: 1, ElapsedMs: 50}
 	game.manager.Logger().AddMessage(Message{
-		id:      NewUUID(),
+		id:      game.manager.NewID(),
+		time:    game.Time(),
 		message: "test",
 		meta:    map[string]interface{}{},
 	})
-	uuid := GetUUID()
+	lastID := game.manager.ids.Last()
 
 	serialized := game.Serialize()
 
@@ -314,5 +753,11 @@ func TestDeserialize(t *testing.T) {
 	assert.Equal(t, game.status, deserialized.status)
 	assert.Equal(t, len(game.manager.GameObjects()), len(deserialized.manager.GameObjects()))
 	assert.Equal(t, len(game.manager.Logger().Logs()), len(deserialized.manager.Logger().Logs()))
-	assert.Equal(t, GetUUID(), uuid)
+	assert.Equal(t, game.Time(), deserialized.Time())
+	assert.Equal(t, game.Time(), deserialized.manager.Logger().Logs()[1].time)
+	assert.Equal(t, lastID, deserialized.manager.ids.Last())
+
+	spaceship, _ := deserialized.manager.GetSpaceship("test")
+	assert.Equal(t, -30.0, spaceship.engine.rotationThrust)
+	assert.Equal(t, 1.5, spaceship.angularVelocity)
 }
@@ -0,0 +1,31 @@
+package game
+
+// IDGenerator allocates unique, sequential IDs for the game objects and the log messages.
+// Each game owns its generator, so independent games can run side by side.
+type IDGenerator struct {
+	last int64
+}
+
+func NewIDGenerator() *IDGenerator {
+	return &IDGenerator{}
+}
+
+// Next returns a new unique ID.
+func (generator *IDGenerator) Next() int64 {
+	generator.last++
+	return generator.last
+}
+
+// Last returns the last allocated ID.
+func (generator *IDGenerator) Last() int64 {
+	return generator.last
+}
+
+func (generator *IDGenerator) Reset() {
+	generator.last = 0
+}
+
+// Set sets the last allocated ID, the next ID will follow it.
+func (generator *IDGenerator) Set(id int64) {
+	generator.last = id
+}
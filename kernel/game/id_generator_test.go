@@ -0,0 +1,43 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDGenerator_Next(t *testing.T) {
+	generator := NewIDGenerator()
+	assert.Equal(t, int64(1), generator.Next())
+	assert.Equal(t, int64(2), generator.Next())
+}
+
+func TestIDGenerator_Last(t *testing.T) {
+	generator := NewIDGenerator()
+	generator.Next()
+	assert.Equal(t, int64(1), generator.Last())
+}
+
+func TestIDGenerator_Reset(t *testing.T) {
+	generator := NewIDGenerator()
+	generator.Next()
+	generator.Next()
+	assert.Equal(t, int64(3), generator.Next())
+	generator.Reset()
+	assert.Equal(t, int64(1), generator.Next())
+}
+
+func TestIDGenerator_Set(t *testing.T) {
+	generator := NewIDGenerator()
+	generator.Set(100)
+	assert.Equal(t, int64(101), generator.Next())
+}
+
+func TestIDGenerator_Independent(t *testing.T) {
+	a := NewIDGenerator()
+	b := NewIDGenerator()
+	a.Next()
+	a.Next()
+	assert.Equal(t, int64(1), b.Next())
+	assert.Equal(t, int64(3), a.Next())
+}
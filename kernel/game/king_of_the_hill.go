@@ -0,0 +1,136 @@
+package game
+
+import (
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+type kingOfTheHill struct {
+	respawning
+}
+
+func (mode *kingOfTheHill) Mode() Mode {
+	return ModeKingOfTheHill
+}
+
+// Setup places the hill in the center of the battlefield.
+func (mode *kingOfTheHill) Setup(gameManager *GameManager) {
+	if mode.hill(gameManager) != nil {
+		return
+	}
+	size := gameManager.Size()
+	center := physics.Vector2{X: size.Width / 2, Y: size.Height / 2}
+	gameManager.AddGameObject(NewHill(gameManager.NewID(), center, gameManager.rules.HillRadius))
+}
+
+// Update scores the time on the hill, only a side alone on the hill scores.
+func (mode *kingOfTheHill) Update(deltaTimeMs float64, gameManager *GameManager) {
+	mode.respawning.Update(deltaTimeMs, gameManager)
+	hill := mode.hill(gameManager)
+	if hill == nil {
+		return
+	}
+
+	kings := []*Spaceship{}
+	hill.controller = ""
+	for _, gameObject := range gameManager.GameObjects() {
+		ship, ok := gameObject.(*Spaceship)
+		if !ok || !ship.Enabled() || physics.WrappedDistance(ship.position, hill.position, gameManager.Size()) > hill.radius {
+			continue
+		}
+		if len(kings) > 0 && kings[0].side() != ship.side() {
+			// Contested
+			return
+		}
+		kings = append(kings, ship)
+	}
+	if len(kings) == 0 {
+		return
+	}
+
+	deltaTimeSec := deltaTimeMs / 1000
+	hill.controller = kings[0].side()
+	for _, ship := range kings {
+		ship.objective += deltaTimeSec
+		ship.AddScore(deltaTimeSec * gameManager.rules.HillScoreSec)
+	}
+}
+
+func (mode *kingOfTheHill) HasEnded(gameManager *GameManager) EndReason {
+	return mode.hasEnded(mode, gameManager)
+}
+
+// Objective returns the seconds the spaceship spent alone on the hill.
+func (mode *kingOfTheHill) Objective(ship *Spaceship) float64 {
+	return ship.objective
+}
+
+func (mode *kingOfTheHill) hill(gameManager *GameManager) *Hill {
+	for _, gameObject := range gameManager.GameObjects() {
+		if hill, ok := gameObject.(*Hill); ok {
+			return hill
+		}
+	}
+	return nil
+}
+
+// Hill is the zone of the king of the hill, it does not collide with anything.
+type Hill struct {
+	id       int64
+	enabled  bool
+	position physics.Vector2
+	radius   float64
+	// The side alone on the hill, empty when the hill is empty or contested
+	controller string
+}
+
+func NewHill(id int64, position physics.Vector2, radius float64) *Hill {
+	return &Hill{
+		id:       id,
+		enabled:  true,
+		position: position,
+		radius:   radius,
+	}
+}
+
+func (hill *Hill) ID() int64 {
+	return hill.id
+}
+
+func (hill *Hill) Enabled() bool {
+	return hill.enabled
+}
+
+func (hill *Hill) SetEnabled(enabled bool) {
+	hill.enabled = enabled
+}
+
+func (hill *Hill) Position() physics.Vector2 {
+	return hill.position
+}
+
+func (hill *Hill) SetPosition(position physics.Vector2) {
+	hill.position = position
+}
+
+func (hill *Hill) Update(deltaTimeMs float64, gameManager *GameManager) {}
+
+func (hill *Hill) Collider() collider.Collider {
+	return nil
+}
+
+func (hill *Hill) OnCollision(other GameObject, gameManager *GameManager, order int) {}
+
+func (hill *Hill) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "hill",
+		"id":      hill.id,
+		"enabled": hill.enabled,
+		"position": map[string]interface{}{
+			"x": hill.position.X,
+			"y": hill.position.Y,
+		},
+		"radius":     hill.radius,
+		"controller": hill.controller,
+	}
+}
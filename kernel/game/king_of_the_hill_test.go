@@ -0,0 +1,60 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKingOfTheHill(t *testing.T) {
+	game := newModeTestGame(ModeKingOfTheHill, 2)
+	game.AddSpaceship("A", physics.Vector2{X: 500, Y: 500}, 0, "")
+	game.AddSpaceship("B", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.Start()
+	shipA, _ := game.manager.GetSpaceship("A")
+	shipB, _ := game.manager.GetSpaceship("B")
+	hill := (&kingOfTheHill{}).hill(&game.manager)
+	assert.Equal(t, physics.Vector2{X: 500, Y: 500}, hill.position)
+	assert.Equal(t, float64(HillRadius), hill.radius)
+
+	game.Update(1000)
+	assert.Equal(t, "A", hill.controller)
+	assert.Equal(t, float64(1), shipA.objective)
+	assert.Equal(t, float64(HillScoreSec), shipA.score)
+
+	// Contested
+	shipB.position = physics.Vector2{X: 550, Y: 500}
+	game.Update(1000)
+	assert.Equal(t, "", hill.controller)
+	assert.Equal(t, float64(1), shipA.objective)
+	assert.Equal(t, float64(0), shipB.objective)
+
+	shipB.position = physics.Vector2{X: 100, Y: 100}
+	game.Update(1000)
+	assert.Equal(t, Ended, game.Status())
+	assert.Equal(t, EndReasonObjective, game.EndReason())
+	assert.Equal(t, "A", game.Winner())
+	assert.Equal(t, float64(2), game.Scoreboard()[0].Objective)
+}
+
+func TestKingOfTheHill_Teams(t *testing.T) {
+	game := newModeTestGame(ModeKingOfTheHill, 0)
+	game.AddSpaceship("A", physics.Vector2{X: 500, Y: 450}, 0, "red")
+	game.AddSpaceship("B", physics.Vector2{X: 500, Y: 550}, 0, "red")
+	game.AddSpaceship("C", physics.Vector2{X: 100, Y: 100}, 0, "blue")
+	game.Start()
+
+	game.Update(500)
+	hill := (&kingOfTheHill{}).hill(&game.manager)
+	assert.Equal(t, "red", hill.controller)
+	teams := game.Teams()
+	assert.Equal(t, "red", teams[0].Name)
+	assert.Equal(t, float64(1), teams[0].Objective)
+
+	// The hill is placed again on reset
+	game.Reset()
+	hill = (&kingOfTheHill{}).hill(&game.manager)
+	assert.Equal(t, "", hill.controller)
+	assert.Equal(t, 4, len(game.manager.GameObjects()))
+}
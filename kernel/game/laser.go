@@ -9,23 +9,25 @@ import (
 
 func NewLaserProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
 	direction := physics.Vector2{X: math.Cos(rotation), Y: math.Sin(rotation)}
+	rules := owner.rules
 
 	return &Projectile{
 		id:                   id,
 		damageType:           DamageTypeLaser,
 		enabled:              true,
 		position:             position,
+		previousPosition:     position,
 		rotation:             rotation,
-		velocity:             direction.Multiply(LaserVelocitySec),
-		lifespanSec:          LaserLifespanSec,
-		damage:               LaserDamage,
+		velocity:             direction.Multiply(rules.LaserVelocitySec),
+		lifespanSec:          rules.LaserLifespanSec,
+		damage:               rules.LaserDamage,
 		owner:                owner,
-		explosionRadius:      float64(LaserExplosionRadius),
-		explosionDurationSec: float64(LaserExplosionDurationSec),
+		explosionRadius:      rules.LaserExplosionRadius,
+		explosionDurationSec: rules.LaserExplosionDurationSec,
 		collider: collider.NewSquareCollider(
 			position,
 			rotation,
-			physics.Size{Width: LaserWidth, Height: LaserLength},
+			physics.Size{Width: rules.LaserWidth, Height: rules.LaserLength},
 		),
 	}
 }
@@ -10,7 +10,7 @@ import (
 )
 
 func TestNewLaserProjectile(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
 	projectile := NewLaserProjectile(1, physics.Vector2{X: 15, Y: 30}, math.Pi, owner)
 
 	assert.Equal(t, int64(1), projectile.ID())
@@ -31,7 +31,7 @@ func TestNewLaserProjectile(t *testing.T) {
 }
 
 func TestLaser_Serialize(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
 	projectile := NewLaserProjectile(1, physics.Vector2{X: 15, Y: 30}, math.Pi, owner)
 
 	assert.Equal(t, map[string]interface{}{
@@ -50,6 +50,7 @@ func TestLaser_Serialize(t *testing.T) {
 		"lifespanSec": 5.0,
 		"damage":      20.0,
 		"owner":       projectile.owner.ID(),
+		"target":      int64(0),
 		"collider":    projectile.collider.Serialize(),
 	}, projectile.Serialize())
 }
@@ -1,9 +1,6 @@
 package game
 
-import (
-	"fmt"
-	"time"
-)
+import "fmt"
 
 type LogType string
 
@@ -12,23 +9,26 @@ const (
 	LogTypeKill      LogType = "kill"
 	LogTypeCollision LogType = "collision"
 	LogTypeGameState LogType = "game_state"
+	LogTypePowerUp   LogType = "power_up"
 )
 
 type Message struct {
 	id      int64
 	logType LogType
-	time    time.Time
+	time    SimulationTime
 	message string
 	meta    map[string]interface{}
 }
 
 func (message *Message) Serialize() map[string]interface{} {
 	return map[string]interface{}{
-		"id":      message.id,
-		"logType": string(message.logType),
-		"time":    message.time.Format("2006-01-02 15:04:05"),
-		"message": message.message,
-		"meta":    message.meta,
+		"id":        message.id,
+		"logType":   string(message.logType),
+		"tick":      message.time.Tick,
+		"elapsedMs": message.time.ElapsedMs,
+		"time":      message.time.String(),
+		"message":   message.message,
+		"meta":      message.meta,
 	}
 }
 
@@ -36,20 +36,26 @@ type Logger interface {
 	Logs() []Message
 	Clear()
 	AddMessage(message Message)
-	Damage(time time.Time, damage float64, who string, by string, damageType DamageType)
-	Kill(time time.Time, who string, by string)
-	Collision(time time.Time, who string, with string)
-	GameState(time time.Time, state Status)
+	// Damage logs the damage taken, the absorbed part is the damage the shield took instead
+	Damage(time SimulationTime, damage float64, absorbed float64, who *Spaceship, whom *Spaceship, damageType DamageType)
+	Kill(time SimulationTime, who *Spaceship, by *Spaceship)
+	Collision(time SimulationTime, who *Spaceship, with GameObject)
+	GameState(time SimulationTime, state Status)
+	GameEnded(time SimulationTime, reason EndReason, winner string)
+	SuddenDeath(time SimulationTime, suddenDeath SuddenDeath)
+	PowerUp(time SimulationTime, who *Spaceship, kind PowerUpKind)
 }
 
-func NewLogger() Logger {
+func NewLogger(ids *IDGenerator) Logger {
 	return &logger{
 		messages: []Message{},
+		ids:      ids,
 	}
 }
 
 type logger struct {
 	messages []Message
+	ids      *IDGenerator
 }
 
 func (logger *logger) Logs() []Message {
@@ -64,50 +70,81 @@ func (logger *logger) AddMessage(message Message) {
 	logger.messages = append(logger.messages, message)
 }
 
-func (logger *logger) Damage(time time.Time, damage float64, who string, whom string, damageType DamageType) {
+// The logs of the spaceships in a team have the team in the meta, e.g. "whoTeam",
+// the damage and the kills between teammates are marked as friendly fire.
+
+func (logger *logger) Damage(time SimulationTime, damage float64, absorbed float64, who *Spaceship, whom *Spaceship, damageType DamageType) {
+	message := fmt.Sprintf("\"%s\" did %.2f damage to \"%s\" with %s", who.name, damage, whom.name, damageType)
+	meta := map[string]interface{}{
+		"who":        who.name,
+		"whom":       whom.name,
+		"damage":     fmt.Sprintf("%.2f", damage),
+		"damageType": string(damageType),
+	}
+	if absorbed > 0 {
+		message += fmt.Sprintf(", the shield absorbed %.2f", absorbed)
+		meta["absorbed"] = fmt.Sprintf("%.2f", absorbed)
+	}
+	if who.IsTeammate(whom) {
+		message += " (friendly fire)"
+		meta["friendlyFire"] = "true"
+	}
+	addTeam(meta, "whoTeam", who)
+	addTeam(meta, "whomTeam", whom)
+
 	logger.messages = append(logger.messages, Message{
-		id:      NewUUID(),
+		id:      logger.ids.Next(),
 		logType: LogTypeDamage,
 		time:    time,
-		message: fmt.Sprintf("\"%s\" did %.2f damage to \"%s\" with %s", who, damage, whom, damageType),
-		meta: map[string]interface{}{
-			"who":        who,
-			"whom":       whom,
-			"damage":     fmt.Sprintf("%.2f", damage),
-			"damageType": string(damageType),
-		},
+		message: message,
+		meta:    meta,
 	})
 }
 
-func (logger *logger) Kill(time time.Time, who string, whom string) {
+func (logger *logger) Kill(time SimulationTime, who *Spaceship, whom *Spaceship) {
+	message := fmt.Sprintf("\"%s\" was killed by \"%s\"", who.name, whom.name)
+	meta := map[string]interface{}{
+		"who":  who.name,
+		"whom": whom.name,
+	}
+	if who.IsTeammate(whom) {
+		message += " (friendly fire)"
+		meta["friendlyFire"] = "true"
+	}
+	addTeam(meta, "whoTeam", who)
+	addTeam(meta, "whomTeam", whom)
+
 	logger.messages = append(logger.messages, Message{
-		id:      NewUUID(),
+		id:      logger.ids.Next(),
 		logType: LogTypeKill,
 		time:    time,
-		message: fmt.Sprintf("\"%s\" was killed by \"%s\"", who, whom),
-		meta: map[string]interface{}{
-			"who":  who,
-			"whom": whom,
-		},
+		message: message,
+		meta:    meta,
 	})
 }
 
-func (logger *logger) Collision(time time.Time, who string, with string) {
+func (logger *logger) Collision(time SimulationTime, who *Spaceship, with GameObject) {
+	withName := "an asteroid"
+	meta := map[string]interface{}{"who": who.name}
+	addTeam(meta, "whoTeam", who)
+	if spaceship, ok := with.(*Spaceship); ok {
+		withName = spaceship.name
+		addTeam(meta, "withTeam", spaceship)
+	}
+	meta["with"] = withName
+
 	logger.messages = append(logger.messages, Message{
-		id:      NewUUID(),
+		id:      logger.ids.Next(),
 		logType: LogTypeCollision,
 		time:    time,
-		message: fmt.Sprintf("\"%s\" collided with \"%s\"", who, with),
-		meta: map[string]interface{}{
-			"who":  who,
-			"with": with,
-		},
+		message: fmt.Sprintf("\"%s\" collided with \"%s\"", who.name, withName),
+		meta:    meta,
 	})
 }
 
-func (logger *logger) GameState(time time.Time, state Status) {
+func (logger *logger) GameState(time SimulationTime, state Status) {
 	logger.messages = append(logger.messages, Message{
-		id:      NewUUID(),
+		id:      logger.ids.Next(),
 		logType: LogTypeGameState,
 		time:    time,
 		message: fmt.Sprintf("Game state changed to: %s", state),
@@ -116,3 +153,59 @@ func (logger *logger) GameState(time time.Time, state Status) {
 		},
 	})
 }
+
+// GameEnded logs the end of the match with its reason and the winner, empty on a draw.
+func (logger *logger) GameEnded(time SimulationTime, reason EndReason, winner string) {
+	message := fmt.Sprintf("Game state changed to: %s (%s), draw", Ended, reason)
+	meta := map[string]interface{}{
+		"state":  string(Ended),
+		"reason": string(reason),
+	}
+	if winner != "" {
+		message = fmt.Sprintf("Game state changed to: %s (%s), \"%s\" won", Ended, reason, winner)
+		meta["winner"] = winner
+	}
+
+	logger.messages = append(logger.messages, Message{
+		id:      logger.ids.Next(),
+		logType: LogTypeGameState,
+		time:    time,
+		message: message,
+		meta:    meta,
+	})
+}
+
+func (logger *logger) SuddenDeath(time SimulationTime, suddenDeath SuddenDeath) {
+	logger.messages = append(logger.messages, Message{
+		id:      logger.ids.Next(),
+		logType: LogTypeGameState,
+		time:    time,
+		message: fmt.Sprintf("Time limit reached, sudden death: %s", suddenDeath),
+		meta: map[string]interface{}{
+			"state":       string(Running),
+			"suddenDeath": string(suddenDeath),
+		},
+	})
+}
+
+func (logger *logger) PowerUp(time SimulationTime, who *Spaceship, kind PowerUpKind) {
+	meta := map[string]interface{}{
+		"who":     who.name,
+		"powerUp": string(kind),
+	}
+	addTeam(meta, "whoTeam", who)
+
+	logger.messages = append(logger.messages, Message{
+		id:      logger.ids.Next(),
+		logType: LogTypePowerUp,
+		time:    time,
+		message: fmt.Sprintf("\"%s\" collected %s", who.name, kind),
+		meta:    meta,
+	})
+}
+
+func addTeam(meta map[string]interface{}, key string, spaceship *Spaceship) {
+	if spaceship.team != "" {
+		meta[key] = spaceship.team
+	}
+}
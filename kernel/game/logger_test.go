@@ -2,13 +2,13 @@ package game
 
 import (
 	"testing"
-	"time"
 
+	"github.com/davidhorak/space-wars/kernel/physics"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestMessage_Serialize(t *testing.T) {
-	now := time.Now()
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
 	message := Message{
 		id:      1,
 		logType: LogTypeDamage,
@@ -20,30 +20,32 @@ func TestMessage_Serialize(t *testing.T) {
 	serialized := message.Serialize()
 	assert.Equal(t, int64(1), serialized["id"])
 	assert.Equal(t, "damage", serialized["logType"])
-	assert.Equal(t, now.Format("2006-01-02 15:04:05"), serialized["time"])
+	assert.Equal(t, int64(3), serialized["tick"])
+	assert.Equal(t, 150.0, serialized["elapsedMs"])
+	assert.Equal(t, "00:00:00.150", serialized["time"])
 	assert.Equal(t, "test", serialized["message"])
 	assert.Equal(t, map[string]interface{}{"test": "test"}, serialized["meta"])
 }
 
 func TestNewLogger(t *testing.T) {
-	logger := NewLogger()
+	logger := NewLogger(NewIDGenerator())
 	assert.Equal(t, []Message{}, logger.Logs())
 }
 
 func TestLogger_Logs(t *testing.T) {
-	logger := NewLogger()
+	logger := NewLogger(NewIDGenerator())
 	assert.Equal(t, []Message{}, logger.Logs())
 }
 
 func TestLogger_Clear(t *testing.T) {
-	logger := NewLogger()
+	logger := NewLogger(NewIDGenerator())
 	logger.Clear()
 	assert.Equal(t, []Message{}, logger.Logs())
 }
 
 func TestLogger_AddMessage(t *testing.T) {
-	now := time.Now()
-	logger := NewLogger()
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
 	logger.AddMessage(Message{
 		id:      1,
 		logType: LogTypeDamage,
@@ -62,10 +64,16 @@ func TestLogger_AddMessage(t *testing.T) {
 	}, logger.Logs()[0])
 }
 
+func newLoggerTestShip(id int64, name string, team string) *Spaceship {
+	ship := NewSpaceship(id, name, physics.Vector2{X: 0, Y: 0}, 0, DefaultRules())
+	ship.team = team
+	return ship
+}
+
 func TestLogger_Damage(t *testing.T) {
-	now := time.Now()
-	logger := NewLogger()
-	logger.Damage(now, 10, "test", "other", DamageTypeUnknown)
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
+	logger.Damage(now, 10, 0, newLoggerTestShip(1, "test", ""), newLoggerTestShip(2, "other", ""), DamageTypeUnknown)
 
 	log := logger.Logs()[0]
 	assert.Equal(t, 1, len(logger.Logs()))
@@ -76,37 +84,70 @@ func TestLogger_Damage(t *testing.T) {
 	assert.Equal(t, map[string]interface{}{"who": "test", "whom": "other", "damage": "10.00", "damageType": "unknown"}, log.meta)
 }
 
+func TestLogger_Damage_Teams(t *testing.T) {
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
+	logger.Damage(now, 10, 0, newLoggerTestShip(1, "test", "red"), newLoggerTestShip(2, "other", "blue"), DamageTypeLaser)
+	logger.Damage(now, 10, 0, newLoggerTestShip(1, "test", "red"), newLoggerTestShip(2, "other", "red"), DamageTypeLaser)
+
+	enemy, friendly := logger.Logs()[0], logger.Logs()[1]
+	assert.Equal(t, "\"test\" did 10.00 damage to \"other\" with laser", enemy.message)
+	assert.Equal(t, map[string]interface{}{"who": "test", "whoTeam": "red", "whom": "other", "whomTeam": "blue", "damage": "10.00", "damageType": "laser"}, enemy.meta)
+	assert.Equal(t, "\"test\" did 10.00 damage to \"other\" with laser (friendly fire)", friendly.message)
+	assert.Equal(t, map[string]interface{}{"who": "test", "whoTeam": "red", "whom": "other", "whomTeam": "red", "damage": "10.00", "damageType": "laser", "friendlyFire": "true"}, friendly.meta)
+}
+
+func TestLogger_Damage_Absorbed(t *testing.T) {
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
+	logger.Damage(now, 10, 10, newLoggerTestShip(1, "test", ""), newLoggerTestShip(2, "other", ""), DamageTypeRocket)
+
+	log := logger.Logs()[0]
+	assert.Equal(t, "\"test\" did 10.00 damage to \"other\" with rocket, the shield absorbed 10.00", log.message)
+	assert.Equal(t, map[string]interface{}{"who": "test", "whom": "other", "damage": "10.00", "absorbed": "10.00", "damageType": "rocket"}, log.meta)
+}
+
 func TestLogger_Kill(t *testing.T) {
-	now := time.Now()
-	logger := NewLogger()
-	logger.Kill(now, "test", "test")
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
+	logger.Kill(now, newLoggerTestShip(1, "test", ""), newLoggerTestShip(2, "other", ""))
+	logger.Kill(now, newLoggerTestShip(1, "test", "red"), newLoggerTestShip(2, "other", "red"))
 
 	log := logger.Logs()[0]
-	assert.Equal(t, 1, len(logger.Logs()))
+	assert.Equal(t, 2, len(logger.Logs()))
 	assert.Greater(t, log.id, int64(0))
 	assert.Equal(t, LogTypeKill, log.logType)
 	assert.Equal(t, now, log.time)
-	assert.Equal(t, "\"test\" was killed by \"test\"", log.message)
-	assert.Equal(t, map[string]interface{}{"who": "test", "whom": "test"}, log.meta)
+	assert.Equal(t, "\"test\" was killed by \"other\"", log.message)
+	assert.Equal(t, map[string]interface{}{"who": "test", "whom": "other"}, log.meta)
+
+	friendly := logger.Logs()[1]
+	assert.Equal(t, "\"test\" was killed by \"other\" (friendly fire)", friendly.message)
+	assert.Equal(t, map[string]interface{}{"who": "test", "whoTeam": "red", "whom": "other", "whomTeam": "red", "friendlyFire": "true"}, friendly.meta)
 }
 
 func TestLogger_Collision(t *testing.T) {
-	now := time.Now()
-	logger := NewLogger()
-	logger.Collision(now, "test", "test")
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
+	logger.Collision(now, newLoggerTestShip(1, "test", "red"), newLoggerTestShip(2, "other", ""))
+	logger.Collision(now, newLoggerTestShip(1, "test", ""), NewAsteroid(3, physics.Vector2{X: 0, Y: 0}, 10))
 
 	log := logger.Logs()[0]
-	assert.Equal(t, 1, len(logger.Logs()))
+	assert.Equal(t, 2, len(logger.Logs()))
 	assert.Greater(t, log.id, int64(0))
 	assert.Equal(t, LogTypeCollision, log.logType)
 	assert.Equal(t, now, log.time)
-	assert.Equal(t, "\"test\" collided with \"test\"", log.message)
-	assert.Equal(t, map[string]interface{}{"who": "test", "with": "test"}, log.meta)
+	assert.Equal(t, "\"test\" collided with \"other\"", log.message)
+	assert.Equal(t, map[string]interface{}{"who": "test", "whoTeam": "red", "with": "other"}, log.meta)
+
+	asteroid := logger.Logs()[1]
+	assert.Equal(t, "\"test\" collided with \"an asteroid\"", asteroid.message)
+	assert.Equal(t, map[string]interface{}{"who": "test", "with": "an asteroid"}, asteroid.meta)
 }
 
 func TestLogger_GameState(t *testing.T) {
-	now := time.Now()
-	logger := NewLogger()
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
 	logger.GameState(now, Running)
 
 	log := logger.Logs()[0]
@@ -117,3 +158,41 @@ func TestLogger_GameState(t *testing.T) {
 	assert.Equal(t, "Game state changed to: running", log.message)
 	assert.Equal(t, map[string]interface{}{"state": "running"}, log.meta)
 }
+
+func TestLogger_GameEnded(t *testing.T) {
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
+	logger.GameEnded(now, EndReasonTimeLimit, "red")
+	logger.GameEnded(now, EndReasonEliminated, "")
+
+	logs := logger.Logs()
+	assert.Equal(t, LogTypeGameState, logs[0].logType)
+	assert.Equal(t, "Game state changed to: ended (timeLimit), \"red\" won", logs[0].message)
+	assert.Equal(t, map[string]interface{}{"state": "ended", "reason": "timeLimit", "winner": "red"}, logs[0].meta)
+	assert.Equal(t, "Game state changed to: ended (eliminated), draw", logs[1].message)
+	assert.Equal(t, map[string]interface{}{"state": "ended", "reason": "eliminated"}, logs[1].meta)
+}
+
+func TestLogger_SuddenDeath(t *testing.T) {
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
+	logger.SuddenDeath(now, SuddenDeathShrinkingZone)
+
+	log := logger.Logs()[0]
+	assert.Equal(t, LogTypeGameState, log.logType)
+	assert.Equal(t, now, log.time)
+	assert.Equal(t, "Time limit reached, sudden death: shrinkingZone", log.message)
+	assert.Equal(t, map[string]interface{}{"state": "running", "suddenDeath": "shrinkingZone"}, log.meta)
+}
+
+func TestLogger_PowerUp(t *testing.T) {
+	now := SimulationTime{Tick: 3, ElapsedMs: 150}
+	logger := NewLogger(NewIDGenerator())
+	logger.PowerUp(now, newLoggerTestShip(1, "test", "red"), PowerUpKindDamageBoost)
+
+	log := logger.Logs()[0]
+	assert.Equal(t, LogTypePowerUp, log.logType)
+	assert.Equal(t, now, log.time)
+	assert.Equal(t, "\"test\" collected damageBoost", log.message)
+	assert.Equal(t, map[string]interface{}{"who": "test", "whoTeam": "red", "powerUp": "damageBoost"}, log.meta)
+}
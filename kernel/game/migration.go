@@ -0,0 +1,247 @@
+package game
+
+import "fmt"
+
+// StateVersion is the schema version of the serialized state, it must be bumped
+// with a new migration whenever the state changes in a way the older states don't fit.
+//
+// History:
+//   - 0: unversioned, the simulation time, the rules and the rotation control are optional
+//   - 1: the schemaVersion is introduced, all the fields are required
+//   - 2: the teams, the spaceships have a team
+//   - 3: the endReason, the spaceships and the teams have the damage dealt and the teams the health
+//   - 4: the game modes, the hills and the flags, the spaceships have the objective and the respawn timer,
+//     the teams the objective
+//   - 5: the respawns, the spaceships have the invulnerability timer, the spaceships and the teams
+//     have the deaths and the respawns
+//   - 6: the moving asteroids, the asteroids have the start position, the rotation, the velocity,
+//     the angular velocity, the damage and whether they are fragments
+//   - 7: the polygon asteroids, the asteroids have the vertices
+//   - 8: the shields, the spaceships have the shield and its cooldown timer
+//   - 9: the mines, the spaceships have the mines left
+//   - 10: the homing rockets, the projectiles have the target
+//   - 11: the power-ups, the spaceships have the damage boost timer
+//   - 12: the physical collisions, the asteroids have the start velocity
+const StateVersion = 12
+
+// migrations upgrade the state from the version of their index to the next one.
+var migrations = []func(state map[string]interface{}){
+	migrateUnversioned,
+	migrateTeams,
+	migrateEndReason,
+	migrateGameModes,
+	migrateRespawns,
+	migrateAsteroidMotion,
+	migrateAsteroidShapes,
+	migrateShields,
+	migrateMines,
+	migrateHomingRockets,
+	migratePowerUps,
+	migrateAsteroidStartVelocity,
+}
+
+// MigrateState upgrades the serialized state to the current StateVersion in place,
+// the states without a schemaVersion are of the version 0.
+func MigrateState(state map[string]interface{}) error {
+	version := 0
+	if value, ok := state["schemaVersion"]; ok {
+		number, ok := value.(float64)
+		if !ok || number != float64(int(number)) {
+			return typeError("schemaVersion", "an integer", value)
+		}
+		version = int(number)
+	}
+	if version < 0 || version > StateVersion {
+		return &StateError{Path: "schemaVersion", Message: fmt.Sprintf("unsupported version %d, the newest is %d", version, StateVersion)}
+	}
+
+	for ; version < StateVersion; version++ {
+		migrations[version](state)
+	}
+	state["schemaVersion"] = float64(StateVersion)
+	return nil
+}
+
+// migrateUnversioned fills the fields introduced before the state was versioned,
+// the values of the wrong type are left for the decoder to report.
+func migrateUnversioned(state map[string]interface{}) {
+	setDefault(state, "tick", 0.0)
+	setDefault(state, "elapsedMs", 0.0)
+	setDefault(state, "rules", map[string]interface{}{})
+
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		object, ok := gameObject.(map[string]interface{})
+		if !ok || object["type"] != "spaceship" {
+			continue
+		}
+		setDefault(object, "angularVelocity", 0.0)
+		if engine, ok := object["engine"].(map[string]interface{}); ok {
+			setDefault(engine, "rotationThrust", 0.0)
+		}
+	}
+
+	// The logs used to be timestamped with the wall-clock time only
+	logs, _ := state["logs"].([]interface{})
+	for _, log := range logs {
+		if object, ok := log.(map[string]interface{}); ok {
+			setDefault(object, "tick", 0.0)
+			setDefault(object, "elapsedMs", 0.0)
+		}
+	}
+}
+
+// migrateTeams puts the spaceships on their own, the teams are derived from the spaceships.
+func migrateTeams(state map[string]interface{}) {
+	setDefault(state, "teams", []interface{}{})
+
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		if object, ok := gameObject.(map[string]interface{}); ok && object["type"] == "spaceship" {
+			setDefault(object, "team", "")
+		}
+	}
+}
+
+// migrateEndReason ends the ended matches by elimination, the only way they could end,
+// the damage dealt before is unknown. The derived teams are left out.
+func migrateEndReason(state map[string]interface{}) {
+	if state["status"] == string(Ended) {
+		setDefault(state, "endReason", string(EndReasonEliminated))
+	} else {
+		setDefault(state, "endReason", string(EndReasonNone))
+	}
+	state["teams"] = []interface{}{}
+
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		if object, ok := gameObject.(map[string]interface{}); ok && object["type"] == "spaceship" {
+			setDefault(object, "damageDealt", 0.0)
+		}
+	}
+}
+
+// migrateGameModes puts the spaceships at no objective, the matches were all last standing
+// so no spaceship is waiting to respawn. The derived teams are left out.
+func migrateGameModes(state map[string]interface{}) {
+	state["teams"] = []interface{}{}
+
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		if object, ok := gameObject.(map[string]interface{}); ok && object["type"] == "spaceship" {
+			setDefault(object, "objective", 0.0)
+			setDefault(object, "respawnTimerSec", 0.0)
+		}
+	}
+}
+
+// migrateRespawns counts the destroyed spaceships as dead once, the earlier deaths and respawns
+// are unknown. The derived teams are left out.
+func migrateRespawns(state map[string]interface{}) {
+	state["teams"] = []interface{}{}
+
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		object, ok := gameObject.(map[string]interface{})
+		if !ok || object["type"] != "spaceship" {
+			continue
+		}
+		deaths := 0.0
+		if object["destroyed"] == true {
+			deaths = 1
+		}
+		setDefault(object, "invulnerableTimerSec", 0.0)
+		setDefault(object, "deaths", deaths)
+		setDefault(object, "respawns", 0.0)
+	}
+}
+
+// migrateAsteroidMotion keeps the asteroids static where they are, undamaged.
+func migrateAsteroidMotion(state map[string]interface{}) {
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		object, ok := gameObject.(map[string]interface{})
+		if !ok || object["type"] != "asteroid" {
+			continue
+		}
+		if position, ok := object["position"]; ok {
+			setDefault(object, "startPosition", position)
+		}
+		setDefault(object, "rotation", 0.0)
+		setDefault(object, "velocity", map[string]interface{}{"x": 0.0, "y": 0.0})
+		setDefault(object, "angularVelocity", 0.0)
+		setDefault(object, "damage", 0.0)
+		setDefault(object, "fragment", false)
+	}
+}
+
+// migrateAsteroidShapes keeps the asteroids circles.
+func migrateAsteroidShapes(state map[string]interface{}) {
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		if object, ok := gameObject.(map[string]interface{}); ok && object["type"] == "asteroid" {
+			setDefault(object, "vertices", []interface{}{})
+		}
+	}
+}
+
+// migrateShields lowers the shields, there were none.
+func migrateShields(state map[string]interface{}) {
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		if object, ok := gameObject.(map[string]interface{}); ok && object["type"] == "spaceship" {
+			setDefault(object, "shield", false)
+			setDefault(object, "shieldCooldownTimerSec", 0.0)
+		}
+	}
+}
+
+// migrateMines leaves the spaceships without mines, they carried none.
+func migrateMines(state map[string]interface{}) {
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		if object, ok := gameObject.(map[string]interface{}); ok && object["type"] == "spaceship" {
+			setDefault(object, "mines", 0.0)
+		}
+	}
+}
+
+// migrateHomingRockets leaves the projectiles without a target, they flew straight.
+func migrateHomingRockets(state map[string]interface{}) {
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		if object, ok := gameObject.(map[string]interface{}); ok && (object["type"] == "laser" || object["type"] == "rocket") {
+			setDefault(object, "target", 0.0)
+		}
+	}
+}
+
+// migratePowerUps leaves the spaceships without the damage boost, there were no power-ups.
+func migratePowerUps(state map[string]interface{}) {
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		if object, ok := gameObject.(map[string]interface{}); ok && object["type"] == "spaceship" {
+			setDefault(object, "damageBoostTimerSec", 0.0)
+		}
+	}
+}
+
+// migrateAsteroidStartVelocity starts the asteroids with their velocity, nothing pushed them.
+func migrateAsteroidStartVelocity(state map[string]interface{}) {
+	gameObjects, _ := state["gameObjects"].([]interface{})
+	for _, gameObject := range gameObjects {
+		object, ok := gameObject.(map[string]interface{})
+		if !ok || object["type"] != "asteroid" {
+			continue
+		}
+		if velocity, ok := object["velocity"]; ok {
+			setDefault(object, "startVelocity", velocity)
+		}
+	}
+}
+
+func setDefault(object map[string]interface{}, key string, value interface{}) {
+	if _, ok := object[key]; !ok {
+		object[key] = value
+	}
+}
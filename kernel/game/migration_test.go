@@ -0,0 +1,259 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateState(t *testing.T) {
+	state := map[string]interface{}{
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "spaceship", "engine": map[string]interface{}{"mainThrust": 10.0}},
+			map[string]interface{}{"type": "asteroid"},
+			"invalid",
+		},
+		"logs": []interface{}{
+			map[string]interface{}{"time": "2024-09-01 10:00:00"},
+		},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	assert.Equal(t, map[string]interface{}{
+		"schemaVersion": float64(StateVersion),
+		"tick":          0.0,
+		"elapsedMs":     0.0,
+		"endReason":     "",
+		"rules":         map[string]interface{}{},
+		"gameObjects": []interface{}{
+			map[string]interface{}{
+				"type":                   "spaceship",
+				"angularVelocity":        0.0,
+				"engine":                 map[string]interface{}{"mainThrust": 10.0, "rotationThrust": 0.0},
+				"team":                   "",
+				"damageDealt":            0.0,
+				"objective":              0.0,
+				"respawnTimerSec":        0.0,
+				"invulnerableTimerSec":   0.0,
+				"deaths":                 0.0,
+				"respawns":               0.0,
+				"shield":                 false,
+				"shieldCooldownTimerSec": 0.0,
+				"mines":                  0.0,
+				"damageBoostTimerSec":    0.0,
+			},
+			map[string]interface{}{
+				"type":            "asteroid",
+				"rotation":        0.0,
+				"velocity":        map[string]interface{}{"x": 0.0, "y": 0.0},
+				"startVelocity":   map[string]interface{}{"x": 0.0, "y": 0.0},
+				"angularVelocity": 0.0,
+				"damage":          0.0,
+				"fragment":        false,
+				"vertices":        []interface{}{},
+			},
+			"invalid",
+		},
+		"teams": []interface{}{},
+		"logs": []interface{}{
+			map[string]interface{}{"time": "2024-09-01 10:00:00", "tick": 0.0, "elapsedMs": 0.0},
+		},
+	}, state)
+}
+
+func TestMigrateState_EndReason(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 2.0,
+		"status":        "ended",
+		"teams":         []interface{}{map[string]interface{}{"name": "red"}},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	assert.Equal(t, "eliminated", state["endReason"])
+	assert.Equal(t, []interface{}{}, state["teams"])
+}
+
+func TestMigrateState_GameModes(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 3.0,
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "spaceship", "kills": 2.0},
+		},
+		"teams": []interface{}{map[string]interface{}{"name": "red"}},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"type": "spaceship", "kills": 2.0, "objective": 0.0, "respawnTimerSec": 0.0,
+			"invulnerableTimerSec": 0.0, "deaths": 0.0, "respawns": 0.0, "shield": false, "shieldCooldownTimerSec": 0.0,
+			"mines": 0.0, "damageBoostTimerSec": 0.0,
+		},
+	}, state["gameObjects"])
+	assert.Equal(t, []interface{}{}, state["teams"])
+}
+
+func TestMigrateState_Respawns(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 4.0,
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "spaceship", "destroyed": true},
+			map[string]interface{}{"type": "spaceship", "destroyed": false},
+		},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	gameObjects := state["gameObjects"].([]interface{})
+	assert.Equal(t, 1.0, gameObjects[0].(map[string]interface{})["deaths"])
+	assert.Equal(t, 0.0, gameObjects[1].(map[string]interface{})["deaths"])
+	assert.Equal(t, 0.0, gameObjects[1].(map[string]interface{})["respawns"])
+	assert.Equal(t, []interface{}{}, state["teams"])
+}
+
+func TestMigrateState_Asteroids(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 5.0,
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "asteroid", "position": map[string]interface{}{"x": 10.0, "y": 20.0}},
+		},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	asteroid := state["gameObjects"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"x": 10.0, "y": 20.0}, asteroid["startPosition"])
+	assert.Equal(t, map[string]interface{}{"x": 0.0, "y": 0.0}, asteroid["velocity"])
+	assert.Equal(t, map[string]interface{}{"x": 0.0, "y": 0.0}, asteroid["startVelocity"])
+	assert.Equal(t, false, asteroid["fragment"])
+}
+
+func TestMigrateState_AsteroidShapes(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 6.0,
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "asteroid", "radius": 10.0},
+			map[string]interface{}{"type": "spaceship"},
+		},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "asteroid", "radius": 10.0, "vertices": []interface{}{}},
+		map[string]interface{}{"type": "spaceship", "shield": false, "shieldCooldownTimerSec": 0.0, "mines": 0.0, "damageBoostTimerSec": 0.0},
+	}, state["gameObjects"])
+}
+
+func TestMigrateState_Shields(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 7.0,
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "spaceship", "energy": 50.0},
+			map[string]interface{}{"type": "asteroid"},
+		},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "spaceship", "energy": 50.0, "shield": false, "shieldCooldownTimerSec": 0.0, "mines": 0.0, "damageBoostTimerSec": 0.0},
+		map[string]interface{}{"type": "asteroid"},
+	}, state["gameObjects"])
+}
+
+func TestMigrateState_Mines(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 8.0,
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "spaceship", "rockets": 5.0},
+			map[string]interface{}{"type": "rocket"},
+		},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "spaceship", "rockets": 5.0, "mines": 0.0, "damageBoostTimerSec": 0.0},
+		map[string]interface{}{"type": "rocket", "target": 0.0},
+	}, state["gameObjects"])
+}
+
+func TestMigrateState_HomingRockets(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 9.0,
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "laser", "owner": 1.0},
+			map[string]interface{}{"type": "rocket", "owner": 1.0},
+			map[string]interface{}{"type": "mine", "owner": 1.0},
+		},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "laser", "owner": 1.0, "target": 0.0},
+		map[string]interface{}{"type": "rocket", "owner": 1.0, "target": 0.0},
+		map[string]interface{}{"type": "mine", "owner": 1.0},
+	}, state["gameObjects"])
+}
+
+func TestMigrateState_PowerUps(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 10.0,
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "spaceship", "health": 50.0},
+			map[string]interface{}{"type": "mine"},
+		},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "spaceship", "health": 50.0, "damageBoostTimerSec": 0.0},
+		map[string]interface{}{"type": "mine"},
+	}, state["gameObjects"])
+}
+
+func TestMigrateState_AsteroidStartVelocity(t *testing.T) {
+	state := map[string]interface{}{
+		"schemaVersion": 11.0,
+		"gameObjects": []interface{}{
+			map[string]interface{}{"type": "asteroid", "velocity": map[string]interface{}{"x": 3.0, "y": -4.0}},
+			map[string]interface{}{"type": "spaceship", "velocity": map[string]interface{}{"x": 1.0, "y": 0.0}},
+		},
+	}
+
+	assert.NoError(t, MigrateState(state))
+
+	asteroid := state["gameObjects"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"x": 3.0, "y": -4.0}, asteroid["startVelocity"])
+	assert.NotContains(t, state["gameObjects"].([]interface{})[1], "startVelocity")
+}
+
+func TestMigrateState_Current(t *testing.T) {
+	state := map[string]interface{}{"schemaVersion": float64(StateVersion)}
+
+	assert.NoError(t, MigrateState(state))
+	assert.Equal(t, map[string]interface{}{"schemaVersion": float64(StateVersion)}, state)
+}
+
+func TestMigrateState_Errors(t *testing.T) {
+	var tests = []struct {
+		version     interface{}
+		expectedErr string
+	}{
+		{"1", `schemaVersion: expected an integer, got "1"`},
+		{1.5, "schemaVersion: expected an integer, got 1.5"},
+		{-1.0, "schemaVersion: unsupported version -1, the newest is 12"},
+		{float64(StateVersion + 1), "schemaVersion: unsupported version 13, the newest is 12"},
+	}
+
+	for _, test := range tests {
+		err := MigrateState(map[string]interface{}{"schemaVersion": test.version})
+		assert.EqualError(t, err, test.expectedErr)
+	}
+}
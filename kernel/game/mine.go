@@ -0,0 +1,128 @@
+package game
+
+import (
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// Mine is dropped by a spaceship and stays where it was dropped. Once armed, it detonates when
+// an enemy spaceship comes within its trigger radius, the explosion damages all the spaceships
+// around except its owner. The mine does not collide, the projectiles pass over it.
+type Mine struct {
+	id             int64
+	enabled        bool
+	position       physics.Vector2
+	armingTimerSec float64 // Until the mine could be triggered
+	lifespanSec    float64
+	owner          *Spaceship
+}
+
+func NewMine(id int64, position physics.Vector2, owner *Spaceship) *Mine {
+	return &Mine{
+		id:             id,
+		enabled:        true,
+		position:       position,
+		armingTimerSec: owner.rules.MineArmingSec,
+		lifespanSec:    owner.rules.MineLifespanSec,
+		owner:          owner,
+	}
+}
+
+func (mine *Mine) ID() int64 {
+	return mine.id
+}
+
+func (mine *Mine) Enabled() bool {
+	return mine.enabled
+}
+
+func (mine *Mine) SetEnabled(enabled bool) {
+	mine.enabled = enabled
+}
+
+func (mine *Mine) Position() physics.Vector2 {
+	return mine.position
+}
+
+func (mine *Mine) SetPosition(position physics.Vector2) {
+	mine.position = position
+}
+
+// Armed checks if the mine could be triggered.
+func (mine *Mine) Armed() bool {
+	return mine.armingTimerSec <= 0
+}
+
+func (mine *Mine) Update(deltaTimeMs float64, gameManager *GameManager) {
+	deltaTimeSec := deltaTimeMs / 1000
+	mine.lifespanSec -= deltaTimeSec
+	if mine.lifespanSec <= 0 {
+		mine.remove(gameManager)
+		return
+	}
+	if !mine.Armed() {
+		mine.armingTimerSec = max(mine.armingTimerSec-deltaTimeSec, 0)
+		return
+	}
+
+	for _, gameObject := range gameManager.GameObjects() {
+		ship, ok := gameObject.(*Spaceship)
+		if ok && mine.triggers(ship, gameManager) {
+			mine.detonate(gameManager)
+			return
+		}
+	}
+}
+
+// triggers checks if the spaceship is an enabled enemy within the trigger radius,
+// measured to its edge. The owner does not trigger its mines, the teammates do with the friendly fire.
+func (mine *Mine) triggers(ship *Spaceship, gameManager *GameManager) bool {
+	if !ship.Enabled() || ship == mine.owner || gameManager.DamageMultiplier(mine.owner, ship) == 0 {
+		return false
+	}
+	distance := physics.WrappedDistance(mine.position, ship.position, gameManager.Size()) - ship.collider.Radius()
+	return distance <= gameManager.rules.MineTriggerRadius
+}
+
+// detonate removes the mine and deals its area damage, see explode.
+func (mine *Mine) detonate(gameManager *GameManager) {
+	mine.remove(gameManager)
+	rules := gameManager.rules
+	gameManager.AddGameObject(NewExplosion(
+		gameManager.NewID(),
+		physics.Vector2{
+			X: mine.position.X - rules.MineExplosionRadius,
+			Y: mine.position.Y - rules.MineExplosionRadius,
+		},
+		rules.MineExplosionRadius,
+		rules.MineExplosionDurationSec,
+	))
+	explode(gameManager, mine.owner, mine.position, rules.MineExplosionRadius, rules.MineDamage, DamageTypeMine, nil)
+}
+
+func (mine *Mine) remove(gameManager *GameManager) {
+	mine.lifespanSec = 0
+	mine.enabled = false
+	gameManager.RemoveGameObject(mine)
+}
+
+func (mine *Mine) Collider() collider.Collider {
+	return nil
+}
+
+func (mine *Mine) OnCollision(other GameObject, gameManager *GameManager, order int) {}
+
+func (mine *Mine) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "mine",
+		"id":      mine.id,
+		"enabled": mine.enabled,
+		"position": map[string]interface{}{
+			"x": mine.position.X,
+			"y": mine.position.Y,
+		},
+		"armingTimerSec": mine.armingTimerSec,
+		"lifespanSec":    mine.lifespanSec,
+		"owner":          mine.owner.ID(),
+	}
+}
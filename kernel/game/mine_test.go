@@ -0,0 +1,108 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMine(t *testing.T) {
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 0, DefaultRules())
+	mine := NewMine(2, physics.Vector2{X: 15, Y: 30}, owner)
+
+	assert.Equal(t, int64(2), mine.ID())
+	assert.True(t, mine.Enabled())
+	assert.Equal(t, physics.Vector2{X: 15, Y: 30}, mine.Position())
+	assert.Equal(t, float64(MineArmingSec), mine.armingTimerSec)
+	assert.Equal(t, float64(MineLifespanSec), mine.lifespanSec)
+	assert.Equal(t, owner, mine.owner)
+	assert.False(t, mine.Armed())
+	assert.Nil(t, mine.Collider())
+}
+
+func TestMine_Update(t *testing.T) {
+	gameManager := NewGameManager()
+	gameManager.size = physics.Size{Width: 1000, Height: 1000}
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 500, Y: 500}, 0, DefaultRules())
+	// 40 meters from the edge of the ship, half of the explosion radius
+	enemy := NewSpaceship(2, "enemy", physics.Vector2{X: 555, Y: 500}, 0, DefaultRules())
+	far := NewSpaceship(3, "far", physics.Vector2{X: 500, Y: 700}, 0, DefaultRules())
+	mine := NewMine(4, physics.Vector2{X: 500, Y: 500}, owner)
+	gameManager.AddGameObjects([]GameObject{owner, enemy, far, mine})
+
+	// Not armed yet
+	mine.Update(500, &gameManager)
+	assert.True(t, mine.Enabled())
+	assert.Equal(t, 0.5, mine.armingTimerSec)
+	mine.Update(500, &gameManager)
+	assert.True(t, mine.Armed())
+	assert.Equal(t, 100.0, enemy.health)
+
+	mine.Update(16, &gameManager)
+	assert.False(t, mine.Enabled())
+	assert.Nil(t, gameManager.GetGameObjectByID(mine.ID()))
+	assert.Equal(t, 75.0, enemy.health)
+	assert.Equal(t, 100.0, owner.health)
+	assert.Equal(t, 100.0, far.health)
+	assert.Equal(t, 25.0*ScorePerDamageCoefficient, owner.score)
+	assert.Equal(t, 25.0, owner.damageDealt)
+
+	explosion := gameManager.gameObjects[len(gameManager.gameObjects)-1].(*Explosion)
+	assert.Equal(t, float64(MineExplosionRadius), explosion.radius)
+	assert.Equal(t, "\"owner\" did 25.00 damage to \"enemy\" with mine", gameManager.Logger().Logs()[0].message)
+}
+
+func TestMine_Update_Owner(t *testing.T) {
+	gameManager := NewGameManager()
+	gameManager.size = physics.Size{Width: 1000, Height: 1000}
+	gameManager.rules.FriendlyFire = FriendlyFireOff
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 500, Y: 500}, 0, DefaultRules())
+	teammate := NewSpaceship(2, "teammate", physics.Vector2{X: 520, Y: 500}, 0, DefaultRules())
+	owner.team, teammate.team = "red", "red"
+	mine := NewMine(3, physics.Vector2{X: 500, Y: 500}, owner)
+	mine.armingTimerSec = 0
+	gameManager.AddGameObjects([]GameObject{owner, teammate, mine})
+
+	// Neither the owner nor the teammates trigger the mine without the friendly fire
+	mine.Update(16, &gameManager)
+	assert.True(t, mine.Enabled())
+
+	gameManager.rules.FriendlyFire = FriendlyFireFull
+	mine.Update(16, &gameManager)
+	assert.False(t, mine.Enabled())
+	assert.Less(t, teammate.health, 100.0)
+	assert.Equal(t, 100.0, owner.health)
+}
+
+func TestMine_Update_Lifespan(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 500, Y: 500}, 0, DefaultRules())
+	mine := NewMine(2, physics.Vector2{X: 500, Y: 500}, owner)
+	gameManager.AddGameObjects([]GameObject{owner, mine})
+
+	mine.Update(MineLifespanSec*1000, &gameManager)
+
+	assert.False(t, mine.Enabled())
+	assert.Equal(t, 0.0, mine.lifespanSec)
+	// Expires without an explosion
+	assert.Equal(t, []GameObject{owner}, gameManager.GameObjects())
+}
+
+func TestMine_Serialize(t *testing.T) {
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 0, DefaultRules())
+	mine := NewMine(2, physics.Vector2{X: 15, Y: 30}, owner)
+
+	assert.Equal(t, map[string]interface{}{
+		"type":    "mine",
+		"id":      int64(2),
+		"enabled": true,
+		"position": map[string]interface{}{
+			"x": 15.0,
+			"y": 30.0,
+		},
+		"armingTimerSec": float64(MineArmingSec),
+		"lifespanSec":    float64(MineLifespanSec),
+		"owner":          int64(1),
+	}, mine.Serialize())
+}
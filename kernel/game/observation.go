@@ -0,0 +1,130 @@
+package game
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"math/rand"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// publicSpaceshipFields are the serialized fields of the other spaceships a spaceship observes,
+// the health, energy, weapons and score stay hidden. The shield and the respawn invulnerability are seen.
+var publicSpaceshipFields = []string{
+	"type", "id", "enabled", "destroyed", "name", "team", "position", "rotation", "velocity", "angularVelocity", "shield",
+	"invulnerableTimerSec", "collider",
+}
+
+// Observation is what a single spaceship perceives of the battlefield, see Game.Observe.
+type Observation struct {
+	Time SimulationTime
+	// The full serialized state of the observing spaceship
+	Spaceship map[string]interface{}
+	// The serialized states of the observed game objects
+	GameObjects []interface{}
+}
+
+func (observation *Observation) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"tick":        observation.Time.Tick,
+		"elapsedMs":   observation.Time.ElapsedMs,
+		"spaceship":   observation.Spaceship,
+		"gameObjects": observation.GameObjects,
+	}
+}
+
+// Observe returns what the spaceship perceives of the battlefield, limited by the radar rules:
+//   - the objects beyond the radar range are not observed, 0 is unlimited
+//   - with the line of sight, the objects behind the asteroids are not observed
+//   - the positions beyond the radar precision range are off by up to the radar noise
+//     coefficient per unit of distance beyond it
+//
+// The disabled objects are not observed. The spaceship's own state is complete,
+// the other spaceships show only their public fields, see publicSpaceshipFields.
+// The noise is seeded by the game seed, the tick and the spaceship, observing
+// the same tick twice gives the same observation.
+func (game *Game) Observe(name string) (*Observation, error) {
+	spaceShip, err := game.manager.GetSpaceship(name)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := game.manager.rules
+	random := rand.New(rand.NewSource(observationSeed(game.seed, game.manager.time.Tick, spaceShip.id)))
+	gameObjects := make([]interface{}, 0)
+	for _, gameObject := range game.manager.GameObjects() {
+		if gameObject.ID() == spaceShip.id || !gameObject.Enabled() {
+			continue
+		}
+
+		displacement := physics.WrappedDisplacement(spaceShip.position, gameObject.Position(), game.size)
+		distance := displacement.Magnitude()
+		if rules.RadarRange > 0 && distance > rules.RadarRange {
+			continue
+		}
+		if rules.RadarLineOfSight && game.occluded(spaceShip.position, displacement, gameObject) {
+			continue
+		}
+
+		// Off by up to the error at the distance, in a random direction
+		angle := random.Float64() * 2 * math.Pi
+		offset := physics.Vector2{X: math.Cos(angle), Y: math.Sin(angle)}
+		offset = offset.Multiply(random.Float64() * math.Max(0, rules.RadarNoiseCoefficient*(distance-rules.RadarPrecisionRange)))
+		position := gameObject.Position()
+		position = physics.Wrap(position.Add(offset), game.size)
+
+		gameObjects = append(gameObjects, observedState(gameObject, position))
+	}
+
+	return &Observation{
+		Time:        game.manager.time,
+		Spaceship:   spaceShip.Serialize(),
+		GameObjects: gameObjects,
+	}, nil
+}
+
+// occluded tells whether an asteroid blocks the line of sight between the centers,
+// the displacement is the wrapped one from the position to the target.
+func (game *Game) occluded(position physics.Vector2, displacement physics.Vector2, target GameObject) bool {
+	for _, gameObject := range game.manager.GameObjects() {
+		asteroid, ok := gameObject.(*Asteroid)
+		if !ok || !asteroid.Enabled() || asteroid.ID() == target.ID() {
+			continue
+		}
+
+		// The ray is cast next to the asteroid, so it is blocked across the edges too
+		toAsteroid := physics.WrappedDisplacement(position, asteroid.position, game.size)
+		start := asteroid.position.Subtract(toAsteroid)
+		if _, hit := collider.Raycast(start, start.Add(displacement), asteroid.Collider()); hit {
+			return true
+		}
+	}
+	return false
+}
+
+// observedState serializes the game object at the observed position.
+func observedState(gameObject GameObject, position physics.Vector2) map[string]interface{} {
+	state := gameObject.Serialize()
+	if _, ok := gameObject.(*Spaceship); ok {
+		public := map[string]interface{}{}
+		for _, field := range publicSpaceshipFields {
+			public[field] = state[field]
+		}
+		state = public
+	}
+
+	state["position"] = map[string]interface{}{"x": position.X, "y": position.Y}
+	// The collider must not give the exact position away
+	if colliderState, ok := state["collider"].(map[string]interface{}); ok {
+		colliderState["position"] = map[string]interface{}{"x": position.X, "y": position.Y}
+	}
+	return state
+}
+
+func observationSeed(seed int64, tick int64, id int64) int64 {
+	hash := fnv.New64a()
+	binary.Write(hash, binary.LittleEndian, [3]int64{seed, tick, id})
+	return int64(hash.Sum64())
+}
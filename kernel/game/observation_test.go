@@ -0,0 +1,153 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newObservationTestGame(rules *Rules, positions map[string]physics.Vector2, asteroids ...*Asteroid) *Game {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, rules)
+	for _, name := range []string{"observer", "near", "far"} {
+		if position, ok := positions[name]; ok {
+			game.AddSpaceship(name, position, 0, "")
+		}
+	}
+	for _, asteroid := range asteroids {
+		asteroid.id = game.manager.NewID()
+		game.manager.AddGameObject(asteroid)
+	}
+	return game
+}
+
+func observedNames(observation *Observation) []string {
+	names := []string{}
+	for _, gameObject := range observation.GameObjects {
+		state := gameObject.(map[string]interface{})
+		if name, ok := state["name"].(string); ok {
+			names = append(names, name)
+		} else {
+			names = append(names, state["type"].(string))
+		}
+	}
+	return names
+}
+
+func TestGame_Observe(t *testing.T) {
+	var tests = []struct {
+		name      string
+		modify    func(rules *Rules)
+		positions map[string]physics.Vector2
+		asteroids []*Asteroid
+		expected  []string
+	}{
+		{
+			name:      "unlimited",
+			modify:    func(rules *Rules) {},
+			positions: map[string]physics.Vector2{"observer": {X: 100, Y: 100}, "near": {X: 300, Y: 100}, "far": {X: 100, Y: 600}},
+			expected:  []string{"near", "far"},
+		},
+		{
+			name:      "radar range",
+			modify:    func(rules *Rules) { rules.RadarRange = 300 },
+			positions: map[string]physics.Vector2{"observer": {X: 100, Y: 100}, "near": {X: 300, Y: 100}, "far": {X: 100, Y: 600}},
+			expected:  []string{"near"},
+		},
+		{
+			name:      "radar range across the edges",
+			modify:    func(rules *Rules) { rules.RadarRange = 300 },
+			positions: map[string]physics.Vector2{"observer": {X: 100, Y: 100}, "near": {X: 900, Y: 900}, "far": {X: 600, Y: 100}},
+			expected:  []string{"near"},
+		},
+		{
+			name:      "line of sight",
+			modify:    func(rules *Rules) { rules.RadarLineOfSight = true },
+			positions: map[string]physics.Vector2{"observer": {X: 100, Y: 100}, "near": {X: 300, Y: 100}, "far": {X: 100, Y: 600}},
+			asteroids: []*Asteroid{NewAsteroid(0, physics.Vector2{X: 200, Y: 110}, 20)},
+			expected:  []string{"far", "asteroid"},
+		},
+		{
+			name:      "line of sight across the edges",
+			modify:    func(rules *Rules) { rules.RadarLineOfSight = true },
+			positions: map[string]physics.Vector2{"observer": {X: 100, Y: 100}, "near": {X: 900, Y: 100}, "far": {X: 100, Y: 600}},
+			asteroids: []*Asteroid{NewAsteroid(0, physics.Vector2{X: 10, Y: 100}, 20)},
+			expected:  []string{"far", "asteroid"},
+		},
+		{
+			name:      "line of sight ignored",
+			modify:    func(rules *Rules) {},
+			positions: map[string]physics.Vector2{"observer": {X: 100, Y: 100}, "near": {X: 300, Y: 100}},
+			asteroids: []*Asteroid{NewAsteroid(0, physics.Vector2{X: 200, Y: 110}, 20)},
+			expected:  []string{"near", "asteroid"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules := DefaultRules()
+			test.modify(rules)
+			game := newObservationTestGame(rules, test.positions, test.asteroids...)
+
+			observation, err := game.Observe("observer")
+
+			assert.NoError(t, err)
+			assert.Equal(t, "observer", observation.Spaceship["name"])
+			assert.Equal(t, test.expected, observedNames(observation))
+		})
+	}
+}
+
+func TestGame_Observe_State(t *testing.T) {
+	game := newObservationTestGame(DefaultRules(), map[string]physics.Vector2{"observer": {X: 100, Y: 100}, "near": {X: 300, Y: 100}})
+	near, _ := game.manager.GetSpaceship("near")
+	near.SetEnabled(false)
+
+	observation, err := game.Observe("observer")
+	assert.NoError(t, err)
+	assert.Empty(t, observation.GameObjects)
+
+	near.SetEnabled(true)
+	near.invulnerableTimerSec = 1.5
+	observation, _ = game.Observe("observer")
+	assert.Contains(t, observation.Spaceship, "health")
+	state := observation.GameObjects[0].(map[string]interface{})
+	assert.Len(t, state, len(publicSpaceshipFields))
+	assert.NotContains(t, state, "health")
+	// Immune to the damage, not worth the rockets
+	assert.Equal(t, 1.5, state["invulnerableTimerSec"])
+	assert.Equal(t, map[string]interface{}{"x": 300.0, "y": 100.0}, state["position"])
+
+	_, err = game.Observe("missing")
+	assert.EqualError(t, err, "space ship not found: missing")
+}
+
+func TestGame_Observe_Noise(t *testing.T) {
+	rules := DefaultRules()
+	rules.RadarPrecisionRange = 100
+	rules.RadarNoiseCoefficient = 0.1
+	game := newObservationTestGame(rules, map[string]physics.Vector2{"observer": {X: 100, Y: 100}, "near": {X: 150, Y: 100}, "far": {X: 400, Y: 100}})
+
+	observation, _ := game.Observe("observer")
+
+	positions := map[string]physics.Vector2{}
+	for _, gameObject := range observation.GameObjects {
+		state := gameObject.(map[string]interface{})
+		position := state["position"].(map[string]interface{})
+		positions[state["name"].(string)] = physics.Vector2{X: position["x"].(float64), Y: position["y"].(float64)}
+		assert.Equal(t, position, state["collider"].(map[string]interface{})["position"])
+	}
+	// Within the precision range
+	assert.Equal(t, physics.Vector2{X: 150, Y: 100}, positions["near"])
+	// Off by up to 0.1 * (300 - 100)
+	far := positions["far"]
+	assert.NotEqual(t, physics.Vector2{X: 400, Y: 100}, far)
+	assert.LessOrEqual(t, far.Distance(physics.Vector2{X: 400, Y: 100}), 20.0)
+
+	// The same tick gives the same observation, the next one a different one
+	again, _ := game.Observe("observer")
+	assert.Equal(t, observation, again)
+	game.Update(50)
+	next, _ := game.Observe("observer")
+	assert.NotEqual(t, observation.GameObjects, next.GameObjects)
+}
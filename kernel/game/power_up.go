@@ -0,0 +1,192 @@
+package game
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"math/rand"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/davidhorak/space-wars/kernel/physics/collider"
+)
+
+// PowerUpKind is what the power-up gives the spaceship collecting it.
+type PowerUpKind string
+
+const (
+	PowerUpKindRockets PowerUpKind = "rockets"
+	PowerUpKindHealth  PowerUpKind = "health"
+	PowerUpKindEnergy  PowerUpKind = "energy"
+	// Multiplies the damage the spaceship deals for a while, see Rules.DamageBoostMultiplier
+	PowerUpKindDamageBoost PowerUpKind = "damageBoost"
+)
+
+var powerUpKinds = []PowerUpKind{PowerUpKindRockets, PowerUpKindHealth, PowerUpKindEnergy, PowerUpKindDamageBoost}
+
+// powerUpCandidates is the number of the random points a power-up could spawn at,
+// the one the furthest from the asteroids and the spaceships is taken.
+const powerUpCandidates = 8
+
+// PowerUp is collected by the first spaceship touching it, the projectiles and the asteroids
+// pass over it. It disappears after its lifespan.
+type PowerUp struct {
+	id          int64
+	enabled     bool
+	kind        PowerUpKind
+	position    physics.Vector2
+	lifespanSec float64
+	collider    collider.CircleCollider
+}
+
+func NewPowerUp(id int64, kind PowerUpKind, position physics.Vector2, rules *Rules) *PowerUp {
+	return &PowerUp{
+		id:          id,
+		enabled:     true,
+		kind:        kind,
+		position:    position,
+		lifespanSec: rules.PowerUpLifespanSec,
+		collider:    *collider.NewCircleCollider(position, rules.PowerUpRadius),
+	}
+}
+
+func (powerUp *PowerUp) ID() int64 {
+	return powerUp.id
+}
+
+func (powerUp *PowerUp) Kind() PowerUpKind {
+	return powerUp.kind
+}
+
+func (powerUp *PowerUp) Enabled() bool {
+	return powerUp.enabled
+}
+
+func (powerUp *PowerUp) SetEnabled(enabled bool) {
+	powerUp.enabled = enabled
+}
+
+func (powerUp *PowerUp) Position() physics.Vector2 {
+	return powerUp.position
+}
+
+func (powerUp *PowerUp) SetPosition(position physics.Vector2) {
+	powerUp.position = position
+	powerUp.collider.SetPosition(position)
+}
+
+func (powerUp *PowerUp) Update(deltaTimeMs float64, gameManager *GameManager) {
+	powerUp.lifespanSec -= deltaTimeMs / 1000
+	if powerUp.lifespanSec <= 0 {
+		powerUp.remove(gameManager)
+	}
+}
+
+func (powerUp *PowerUp) Collider() collider.Collider {
+	return &powerUp.collider
+}
+
+// OnCollision does nothing, the spaceships collect the power-ups, see Spaceship.OnCollision.
+func (powerUp *PowerUp) OnCollision(other GameObject, gameManager *GameManager, order int) {}
+
+// collect gives the power-up to the spaceship, the rockets, the health and the energy
+// are capped at their maximums. The collected power-up is removed.
+func (powerUp *PowerUp) collect(ship *Spaceship, gameManager *GameManager) {
+	rules := ship.rules
+	switch powerUp.kind {
+	case PowerUpKindRockets:
+		ship.rockets = min(ship.rockets+int32(rules.PowerUpRockets), int32(rules.MaxRockets))
+	case PowerUpKindHealth:
+		ship.health = math.Min(ship.health+rules.PowerUpHealth, rules.MaxHealth)
+	case PowerUpKindEnergy:
+		ship.energy = math.Min(ship.energy+rules.PowerUpEnergy, rules.MaxEnergy)
+	case PowerUpKindDamageBoost:
+		ship.damageBoostTimerSec = rules.DamageBoostDurationSec
+	}
+	powerUp.remove(gameManager)
+	gameManager.Logger().PowerUp(gameManager.Time(), ship, powerUp.kind)
+}
+
+func (powerUp *PowerUp) remove(gameManager *GameManager) {
+	powerUp.lifespanSec = 0
+	powerUp.enabled = false
+	gameManager.RemoveGameObject(powerUp)
+}
+
+func (powerUp *PowerUp) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "powerUp",
+		"id":      powerUp.id,
+		"enabled": powerUp.enabled,
+		"kind":    string(powerUp.kind),
+		"position": map[string]interface{}{
+			"x": powerUp.position.X,
+			"y": powerUp.position.Y,
+		},
+		"lifespanSec": powerUp.lifespanSec,
+		"collider":    powerUp.collider.Serialize(),
+	}
+}
+
+// spawnPowerUps spawns a power-up whenever the match time passes a multiple of the power-up interval,
+// unless the battlefield has the maximum of them already. The kind and the place are drawn from
+// the seed and the tick, the same match spawns the same power-ups when replayed or restored.
+func (game *Game) spawnPowerUps(deltaTimeMs float64) {
+	rules := game.manager.rules
+	if rules.PowerUpIntervalSec <= 0 {
+		return
+	}
+	intervalMs := rules.PowerUpIntervalSec * 1000
+	elapsedMs := game.manager.time.ElapsedMs
+	if math.Floor(elapsedMs/intervalMs) == math.Floor((elapsedMs-deltaTimeMs)/intervalMs) {
+		return
+	}
+
+	powerUps := 0
+	for _, gameObject := range game.manager.GameObjects() {
+		if _, ok := gameObject.(*PowerUp); ok {
+			powerUps++
+		}
+	}
+	if powerUps >= rules.MaxPowerUps {
+		return
+	}
+
+	random := rand.New(rand.NewSource(powerUpSeed(game.seed, game.manager.time.Tick)))
+	kind := powerUpKinds[random.Intn(len(powerUpKinds))]
+	game.manager.AddGameObject(NewPowerUp(game.manager.NewID(), kind, game.powerUpPoint(random), rules))
+}
+
+// powerUpPoint returns the random point the furthest from the edges of the asteroids and the spaceships.
+func (game *Game) powerUpPoint(random *rand.Rand) physics.Vector2 {
+	var best physics.Vector2
+	bestClearance := math.Inf(-1)
+	for i := 0; i < powerUpCandidates; i++ {
+		point := physics.Vector2{X: random.Float64() * game.size.Width, Y: random.Float64() * game.size.Height}
+		clearance := math.Inf(1)
+		for _, gameObject := range game.manager.GameObjects() {
+			if !gameObject.Enabled() {
+				continue
+			}
+			var radius float64
+			switch object := gameObject.(type) {
+			case *Asteroid:
+				radius = object.radius
+			case *Spaceship:
+				radius = object.collider.Radius()
+			default:
+				continue
+			}
+			clearance = math.Min(clearance, physics.WrappedDistance(point, gameObject.Position(), game.size)-radius)
+		}
+		if clearance > bestClearance {
+			best, bestClearance = point, clearance
+		}
+	}
+	return best
+}
+
+func powerUpSeed(seed int64, tick int64) int64 {
+	hash := fnv.New64a()
+	binary.Write(hash, binary.LittleEndian, [2]int64{seed, tick})
+	return int64(hash.Sum64())
+}
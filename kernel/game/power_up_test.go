@@ -0,0 +1,209 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPowerUp(t *testing.T) {
+	powerUp := NewPowerUp(1, PowerUpKindHealth, physics.Vector2{X: 15, Y: 30}, DefaultRules())
+
+	assert.Equal(t, int64(1), powerUp.ID())
+	assert.True(t, powerUp.Enabled())
+	assert.Equal(t, PowerUpKindHealth, powerUp.Kind())
+	assert.Equal(t, physics.Vector2{X: 15, Y: 30}, powerUp.Position())
+	assert.Equal(t, float64(PowerUpLifespanSec), powerUp.lifespanSec)
+	assert.Equal(t, physics.Vector2{X: 15, Y: 30}, powerUp.Collider().Position())
+}
+
+func TestPowerUp_Update(t *testing.T) {
+	gameManager := NewGameManager()
+	powerUp := NewPowerUp(1, PowerUpKindHealth, physics.Vector2{X: 15, Y: 30}, DefaultRules())
+	gameManager.AddGameObject(powerUp)
+
+	powerUp.Update(1000, &gameManager)
+	assert.True(t, powerUp.Enabled())
+	assert.Equal(t, float64(PowerUpLifespanSec-1), powerUp.lifespanSec)
+
+	powerUp.Update(PowerUpLifespanSec*1000, &gameManager)
+	assert.False(t, powerUp.Enabled())
+	assert.Equal(t, 0.0, powerUp.lifespanSec)
+	assert.Empty(t, gameManager.GameObjects())
+}
+
+func TestPowerUp_Collect(t *testing.T) {
+	var tests = []struct {
+		kind   PowerUpKind
+		modify func(ship *Spaceship)
+		check  func(ship *Spaceship)
+	}{
+		{PowerUpKindRockets, func(ship *Spaceship) { ship.rockets = 1 }, func(ship *Spaceship) {
+			assert.Equal(t, int32(1+PowerUpRockets), ship.rockets)
+		}},
+		{PowerUpKindRockets, func(ship *Spaceship) { ship.rockets = MaxRockets - 1 }, func(ship *Spaceship) {
+			assert.Equal(t, int32(MaxRockets), ship.rockets)
+		}},
+		{PowerUpKindHealth, func(ship *Spaceship) { ship.health = 50 }, func(ship *Spaceship) {
+			assert.Equal(t, 50+float64(PowerUpHealth), ship.health)
+		}},
+		{PowerUpKindHealth, func(ship *Spaceship) { ship.health = 90 }, func(ship *Spaceship) {
+			assert.Equal(t, float64(MaxHealth), ship.health)
+		}},
+		{PowerUpKindEnergy, func(ship *Spaceship) { ship.energy = 10 }, func(ship *Spaceship) {
+			assert.Equal(t, 10+float64(PowerUpEnergy), ship.energy)
+		}},
+		{PowerUpKindEnergy, func(ship *Spaceship) {}, func(ship *Spaceship) {
+			assert.Equal(t, float64(MaxEnergy), ship.energy)
+		}},
+		{PowerUpKindDamageBoost, func(ship *Spaceship) {}, func(ship *Spaceship) {
+			assert.Equal(t, float64(DamageBoostDurationSec), ship.damageBoostTimerSec)
+			assert.Equal(t, float64(DamageBoostMultiplier), ship.DamageBoost())
+		}},
+	}
+
+	for _, test := range tests {
+		gameManager := NewGameManager()
+		ship := NewSpaceship(1, "ship", physics.Vector2{X: 100, Y: 100}, 0, DefaultRules())
+		powerUp := NewPowerUp(2, test.kind, physics.Vector2{X: 100, Y: 100}, DefaultRules())
+		gameManager.AddGameObjects([]GameObject{ship, powerUp})
+		test.modify(ship)
+
+		ship.OnCollision(powerUp, &gameManager, 0)
+
+		test.check(ship)
+		assert.False(t, powerUp.Enabled())
+		assert.Equal(t, []GameObject{ship}, gameManager.GameObjects())
+		log := gameManager.Logger().Logs()[0]
+		assert.Equal(t, LogTypePowerUp, log.logType)
+		assert.Equal(t, "\"ship\" collected "+string(test.kind), log.message)
+	}
+}
+
+func TestPowerUp_Collision(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1, DefaultRules())
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 500}, 0, "")
+	game.AddSpaceship("B", physics.Vector2{X: 300, Y: 500}, 0, "")
+	shipA, _ := game.manager.GetSpaceship("A")
+	shipB, _ := game.manager.GetSpaceship("B")
+	shipA.energy = 50
+	// Between the ships, in the path of the laser
+	passed := NewPowerUp(game.manager.NewID(), PowerUpKindRockets, physics.Vector2{X: 200, Y: 500}, game.Rules())
+	collected := NewPowerUp(game.manager.NewID(), PowerUpKindEnergy, physics.Vector2{X: 100, Y: 510}, game.Rules())
+	game.manager.AddGameObjects([]GameObject{passed, collected})
+	game.Start()
+
+	game.ApplyAction("A", Action{Type: ActionFireLaser})
+	for i := 0; i < 60; i++ {
+		game.Update(16)
+	}
+
+	// The laser passes over the power-up and hits the other spaceship
+	assert.True(t, passed.Enabled())
+	assert.Equal(t, MaxHealth-float64(LaserDamage), shipB.health)
+	assert.False(t, collected.Enabled())
+	assert.Greater(t, shipA.energy, 50.0)
+}
+
+func TestGame_SpawnPowerUps(t *testing.T) {
+	newGame := func() *Game {
+		rules := DefaultRules()
+		rules.PowerUpIntervalSec = 1
+		rules.MaxPowerUps = 2
+		game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, rules)
+		game.AddSpaceship("A", physics.Vector2{X: 100, Y: 100}, 0, "")
+		game.AddSpaceship("B", physics.Vector2{X: 900, Y: 900}, 0, "")
+		game.Start()
+		return game
+	}
+	powerUps := func(game *Game) []*PowerUp {
+		powerUps := []*PowerUp{}
+		for _, gameObject := range game.manager.GameObjects() {
+			if powerUp, ok := gameObject.(*PowerUp); ok {
+				powerUps = append(powerUps, powerUp)
+			}
+		}
+		return powerUps
+	}
+
+	game := newGame()
+	for i := 0; i < 62; i++ {
+		game.Update(16)
+	}
+	assert.Empty(t, powerUps(game))
+
+	// The first interval passes
+	game.Update(16)
+	assert.Len(t, powerUps(game), 1)
+	powerUp := powerUps(game)[0]
+	assert.Contains(t, powerUpKinds, powerUp.kind)
+	assert.GreaterOrEqual(t, powerUp.position.X, 0.0)
+	assert.Less(t, powerUp.position.X, 1000.0)
+	spawned := powerUp.Serialize()
+
+	// Not more than the maximum
+	for i := 0; i < 250; i++ {
+		game.Update(16)
+	}
+	assert.Len(t, powerUps(game), 2)
+
+	// The same seed spawns the same power-ups
+	replayed := newGame()
+	for i := 0; i < 63; i++ {
+		replayed.Update(16)
+	}
+	assert.Equal(t, spawned, powerUps(replayed)[0].Serialize())
+}
+
+func TestGame_SpawnPowerUps_Disabled(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.AddSpaceship("B", physics.Vector2{X: 900, Y: 900}, 0, "")
+	game.Start()
+
+	for i := 0; i < 1000; i++ {
+		game.Update(16)
+	}
+	for _, gameObject := range game.manager.GameObjects() {
+		assert.IsType(t, &Spaceship{}, gameObject)
+	}
+}
+
+func TestDeserialize_PowerUp(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1234567890, DefaultRules())
+	game.AddSpaceship("ship", physics.Vector2{X: 100, Y: 100}, 0, "")
+	ship, _ := game.manager.GetSpaceship("ship")
+	ship.damageBoostTimerSec = 4
+	game.manager.AddGameObject(NewPowerUp(game.manager.NewID(), PowerUpKindDamageBoost, physics.Vector2{X: 500, Y: 500}, game.Rules()))
+
+	serialized, _ := json.Marshal(game.Serialize())
+	deserialized, err := Deserialize(string(serialized))
+
+	assert.NoError(t, err)
+	assert.Equal(t, game.Serialize(), deserialized.Serialize())
+
+	state := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(serialized, &state))
+	gameObjectState(state, "powerUp")["kind"] = "shield"
+	_, _, err = deserializeTestState(t, state, Strict)
+	assert.ErrorContains(t, err, `unknown power-up kind "shield"`)
+}
+
+func TestPowerUp_Serialize(t *testing.T) {
+	powerUp := NewPowerUp(1, PowerUpKindEnergy, physics.Vector2{X: 15, Y: 30}, DefaultRules())
+
+	assert.Equal(t, map[string]interface{}{
+		"type":    "powerUp",
+		"id":      int64(1),
+		"enabled": true,
+		"kind":    "energy",
+		"position": map[string]interface{}{
+			"x": 15.0,
+			"y": 30.0,
+		},
+		"lifespanSec": float64(PowerUpLifespanSec),
+		"collider":    powerUp.collider.Serialize(),
+	}, powerUp.Serialize())
+}
@@ -1,7 +1,7 @@
 package game
 
 import (
-	"time"
+	"math"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
 	"github.com/davidhorak/space-wars/kernel/physics/collider"
@@ -12,28 +12,32 @@ type Projectile struct {
 	damageType           DamageType
 	enabled              bool
 	position             physics.Vector2
+	previousPosition     physics.Vector2
 	velocity             physics.Vector2
 	rotation             float64
 	lifespanSec          float64
 	damage               float64
 	owner                *Spaceship
+	target               *Spaceship // Homed in on, see lockOn
 	collider             collider.Collider
 	explosionRadius      float64
 	explosionDurationSec float64
+	explosionDamage      float64
 }
 
-func NewProjectile(position physics.Vector2, velocity physics.Vector2, rotation float64, lifespanSec float64, damage float64, owner *Spaceship) *Projectile {
+func NewProjectile(id int64, position physics.Vector2, velocity physics.Vector2, rotation float64, lifespanSec float64, damage float64, owner *Spaceship) *Projectile {
 	return &Projectile{
-		id:          NewUUID(),
-		enabled:     true,
-		damageType:  DamageTypeUnknown,
-		position:    position,
-		velocity:    velocity,
-		rotation:    rotation,
-		lifespanSec: lifespanSec,
-		damage:      damage,
-		owner:       owner,
-		collider:    collider.NewSquareCollider(position, 1, physics.Size{Width: 1, Height: 1}),
+		id:               id,
+		enabled:          true,
+		damageType:       DamageTypeUnknown,
+		position:         position,
+		previousPosition: position,
+		velocity:         velocity,
+		rotation:         rotation,
+		lifespanSec:      lifespanSec,
+		damage:           damage,
+		owner:            owner,
+		collider:         collider.NewSquareCollider(position, 1, physics.Size{Width: 1, Height: 1}),
 	}
 }
 
@@ -62,9 +66,24 @@ func (projectile *Projectile) Position() physics.Vector2 {
 }
 
 func (projectile *Projectile) SetPosition(position physics.Vector2) {
+	// Keep the displacement, e.g. when wrapped around the screen
+	projectile.previousPosition = projectile.previousPosition.Add(position.Subtract(projectile.position))
 	projectile.position = position
 }
 
+// Displacement returns how far the projectile moved during the last update.
+func (projectile *Projectile) Displacement() physics.Vector2 {
+	return projectile.position.Subtract(projectile.previousPosition)
+}
+
+// rewind moves the projectile back along its last displacement to the given time (0 to 1),
+// e.g. to the point of impact.
+func (projectile *Projectile) rewind(time float64) {
+	displacement := projectile.Displacement()
+	projectile.position = projectile.position.Subtract(displacement.Multiply(1 - time))
+	projectile.collider.SetPosition(projectile.position)
+}
+
 func (projectile *Projectile) Update(deltaTimeMs float64, gameManager *GameManager) {
 	deltaTimeSec := deltaTimeMs / 1000
 	projectile.lifespanSec -= deltaTimeSec
@@ -73,6 +92,10 @@ func (projectile *Projectile) Update(deltaTimeMs float64, gameManager *GameManag
 		return
 	}
 
+	if projectile.target != nil {
+		projectile.steer(deltaTimeSec, gameManager)
+	}
+	projectile.previousPosition = projectile.position
 	projectile.position = projectile.position.Add(projectile.velocity.Multiply(deltaTimeSec))
 	projectile.collider.SetPosition(projectile.position)
 }
@@ -88,7 +111,7 @@ func (projectile *Projectile) Destroy(gameManager *GameManager, createExplosion
 
 	if createExplosion {
 		gameManager.AddGameObject(NewExplosion(
-			NewUUID(),
+			gameManager.NewID(),
 			physics.Vector2{
 				X: projectile.position.X - float64(projectile.explosionRadius),
 				Y: projectile.position.Y - float64(projectile.explosionRadius),
@@ -106,12 +129,55 @@ func (projectile *Projectile) OnCollision(other GameObject, gameManager *GameMan
 			return
 		}
 
-		gameManager.Logger().Damage(time.Now(), projectile.Damage(), projectile.owner.name, spaceship.name, projectile.damageType)
-		spaceship.TakeDamage(projectile.damage, gameManager, projectile.owner)
-		projectile.owner.AddScore(projectile.damage * ScorePerDamageCoefficient)
+		dealDamage(gameManager, projectile.owner, spaceship, projectile.damage, projectile.damageType)
 	}
 
 	projectile.Destroy(gameManager, true)
+	projectile.explode(gameManager, other)
+}
+
+// explode deals the area damage of the projectile explosion, see explode.
+func (projectile *Projectile) explode(gameManager *GameManager, hit GameObject) {
+	explode(gameManager, projectile.owner, projectile.position, projectile.explosionRadius, projectile.explosionDamage, DamageTypeExplosion, hit)
+}
+
+// explode deals the area damage of an explosion to the spaceships within its radius,
+// except the owner and the directly hit object. The damage falls off linearly from
+// the center of the explosion to its radius, measured to the edge of the spaceship.
+func explode(gameManager *GameManager, owner *Spaceship, position physics.Vector2, radius float64, damage float64, damageType DamageType, hit GameObject) {
+	if damage <= 0 {
+		return
+	}
+
+	for _, gameObject := range gameManager.GameObjects() {
+		spaceship, ok := gameObject.(*Spaceship)
+		if !ok || !spaceship.Enabled() || spaceship == owner || gameObject == hit {
+			continue
+		}
+
+		distance := physics.WrappedDistance(position, spaceship.position, gameManager.Size()) - spaceship.collider.Radius()
+		if distance >= radius {
+			continue
+		}
+		dealDamage(gameManager, owner, spaceship, damage*(1-math.Max(distance, 0)/radius), damageType)
+	}
+}
+
+// dealDamage deals the damage of the owner to the spaceship, boosted by the damage boost of the owner
+// and reduced by the friendly fire rules.
+// Only the damage taken by the enemies is scored and counted as dealt, the invulnerable take none
+// and the shields absorb a part of it.
+func dealDamage(gameManager *GameManager, owner *Spaceship, spaceship *Spaceship, damage float64, damageType DamageType) {
+	multiplier := gameManager.DamageMultiplier(owner, spaceship)
+	if multiplier == 0 {
+		return
+	}
+
+	damage = spaceship.TakeDamage(damage*multiplier*owner.DamageBoost(), damageType, gameManager, owner)
+	if !owner.IsTeammate(spaceship) {
+		owner.AddScore(damage * owner.rules.ScorePerDamageCoefficient)
+		owner.damageDealt += damage
+	}
 }
 
 func (projectile *Projectile) Serialize() map[string]interface{} {
@@ -119,6 +185,10 @@ func (projectile *Projectile) Serialize() map[string]interface{} {
 	if projectile.damageType == DamageTypeRocket {
 		projectileType = "rocket"
 	}
+	target := int64(0)
+	if projectile.target != nil {
+		target = projectile.target.ID()
+	}
 
 	return map[string]interface{}{
 		"type":    projectileType,
@@ -136,6 +206,8 @@ func (projectile *Projectile) Serialize() map[string]interface{} {
 		"lifespanSec": projectile.lifespanSec,
 		"damage":      projectile.damage,
 		"owner":       projectile.owner.ID(),
-		"collider":    projectile.collider.Serialize(),
+		// ID of the spaceship the projectile homes in on, 0 when none
+		"target":   target,
+		"collider": projectile.collider.Serialize(),
 	}
 }
@@ -10,10 +10,10 @@ import (
 )
 
 func TestNewProjectile(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 
-	assert.GreaterOrEqual(t, projectile.ID(), int64(1))
+	assert.Equal(t, int64(2), projectile.ID())
 	assert.Equal(t, DamageTypeUnknown, projectile.DamageType())
 	assert.True(t, projectile.enabled)
 	assert.Equal(t, physics.Vector2{X: 15, Y: 30}, projectile.Position())
@@ -28,31 +28,31 @@ func TestNewProjectile(t *testing.T) {
 }
 
 func TestProjectile_ID(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 
-	assert.GreaterOrEqual(t, projectile.ID(), int64(1))
+	assert.Equal(t, int64(2), projectile.ID())
 }
 
 func TestProjectile_DamageType(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 	projectile.damageType = DamageTypeRocket
 
 	assert.Equal(t, DamageTypeRocket, projectile.DamageType())
 }
 
 func TestProjectile_Enabled(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 	projectile.enabled = false
 
 	assert.False(t, projectile.Enabled())
 }
 
 func TestProjectile_SetEnabled(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 
 	projectile.SetEnabled(false)
 	assert.False(t, projectile.Enabled())
@@ -62,33 +62,51 @@ func TestProjectile_SetEnabled(t *testing.T) {
 }
 
 func TestProjectile_Damage(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 	projectile.damage = 30.0
 
 	assert.Equal(t, 30.0, projectile.Damage())
 }
 
 func TestProjectile_Position(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 
 	assert.Equal(t, physics.Vector2{X: 15, Y: 30}, projectile.Position())
 }
 
 func TestProjectile_SetPosition(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 
 	projectile.SetPosition(physics.Vector2{X: 20, Y: 40})
 	assert.Equal(t, physics.Vector2{X: 20, Y: 40}, projectile.Position())
 }
 
+func TestProjectile_Displacement(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	assert.Equal(t, physics.Vector2{X: 0, Y: 0}, projectile.Displacement())
+
+	projectile.Update(1000, &gameManager)
+	assert.Equal(t, physics.Vector2{X: 10, Y: 20}, projectile.Displacement())
+
+	// Wrapping around the screen keeps the displacement
+	projectile.SetPosition(physics.Vector2{X: 1000, Y: 50})
+	assert.Equal(t, physics.Vector2{X: 10, Y: 20}, projectile.Displacement())
+
+	projectile.rewind(0.5)
+	assert.Equal(t, physics.Vector2{X: 995, Y: 40}, projectile.Position())
+	assert.Equal(t, physics.Vector2{X: 995, Y: 40}, projectile.Collider().Position())
+}
+
 func TestProjectile_Update(t *testing.T) {
 	gameManager := NewGameManager()
 	lifespanSec := 5.0
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, lifespanSec, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, lifespanSec, 20.0, owner)
 
 	projectile.Update(1000, &gameManager)
 	assert.Equal(t, lifespanSec-1.0, projectile.lifespanSec)
@@ -100,16 +118,16 @@ func TestProjectile_Update(t *testing.T) {
 }
 
 func TestProjectile_Collider(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 
 	assert.Equal(t, projectile.collider, projectile.Collider())
 }
 
 func TestProjectile_Destroy(t *testing.T) {
 	gameManager := NewGameManager()
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 	projectile.explosionRadius = 1.0
 	projectile.explosionDurationSec = 1.0
 
@@ -137,9 +155,9 @@ func TestProjectile_Destroy(t *testing.T) {
 
 func TestProjectile_OnCollision(t *testing.T) {
 	gameManager := NewGameManager()
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	other := NewSpaceship(2, "other", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	other := NewSpaceship(2, "other", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 
 	gameManager.AddGameObject(projectile)
 	gameManager.AddGameObject(owner)
@@ -159,9 +177,86 @@ func TestProjectile_OnCollision(t *testing.T) {
 	assert.Equal(t, 10.0, owner.score)
 }
 
+func TestProjectile_OnCollision_FriendlyFire(t *testing.T) {
+	var tests = []struct {
+		mode           FriendlyFire
+		expectedHealth float64
+	}{
+		{FriendlyFireOff, 100},
+		{FriendlyFireReduced, 90},
+		{FriendlyFireFull, 80},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.mode), func(t *testing.T) {
+			gameManager := NewGameManager()
+			gameManager.rules.FriendlyFire = test.mode
+			owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+			teammate := NewSpaceship(2, "teammate", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+			owner.team, teammate.team = "red", "red"
+			projectile := NewProjectile(3, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+			gameManager.AddGameObjects([]GameObject{projectile, owner, teammate})
+
+			projectile.OnCollision(teammate, &gameManager, 0)
+
+			assert.Equal(t, test.expectedHealth, teammate.health)
+			// The damage dealt to the teammates is not scored
+			assert.Equal(t, 0.0, owner.score)
+		})
+	}
+}
+
+func TestProjectile_OnCollision_DamageBoost(t *testing.T) {
+	gameManager := NewGameManager()
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	other := NewSpaceship(2, "other", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	owner.damageBoostTimerSec = 1
+	projectile := NewProjectile(3, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	gameManager.AddGameObjects([]GameObject{projectile, owner, other})
+
+	projectile.OnCollision(other, &gameManager, 0)
+
+	assert.Equal(t, 100.0-20.0*DamageBoostMultiplier, other.health)
+	assert.Equal(t, 20.0*DamageBoostMultiplier, owner.damageDealt)
+
+	// The boost wears off
+	owner.Update(1000, &gameManager)
+	assert.Equal(t, 1.0, owner.DamageBoost())
+}
+
+func TestProjectile_OnCollision_Explosion(t *testing.T) {
+	gameManager := NewGameManager()
+	gameManager.size = physics.Size{Width: 1000, Height: 1000}
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 20, Y: 500}, 100, DefaultRules())
+	target := NewSpaceship(2, "target", physics.Vector2{X: 10, Y: 500}, 100, DefaultRules())
+	near := NewSpaceship(3, "near", physics.Vector2{X: 10, Y: 530}, 100, DefaultRules())
+	wrapped := NewSpaceship(4, "wrapped", physics.Vector2{X: 995, Y: 500}, 100, DefaultRules())
+	far := NewSpaceship(5, "far", physics.Vector2{X: 110, Y: 500}, 100, DefaultRules())
+	rocket := NewRocketProjectile(6, physics.Vector2{X: 10, Y: 500}, 0, owner)
+
+	for _, gameObject := range []GameObject{rocket, owner, target, near, wrapped, far} {
+		gameManager.AddGameObject(gameObject)
+	}
+	rocket.OnCollision(target, &gameManager, 0)
+
+	// The directly hit ship takes only the rocket damage, the owner is not hurt
+	assert.Equal(t, 100.0-RocketDamage, target.health)
+	assert.Equal(t, 100.0, owner.health)
+	// 15 meters from the edge of the ship, half of the explosion radius
+	assert.Equal(t, 80.0, near.health)
+	// Across the edge of the battlefield, touching the ship
+	assert.Equal(t, 100.0-RocketExplosionDamage, wrapped.health)
+	assert.Equal(t, 100.0, far.health)
+	assert.Equal(t, (RocketDamage+20.0+RocketExplosionDamage)*ScorePerDamageCoefficient, owner.score)
+
+	logs := gameManager.Logger().Logs()
+	assert.Equal(t, "\"owner\" did 20.00 damage to \"near\" with explosion", logs[1].message)
+	assert.Equal(t, "\"owner\" did 40.00 damage to \"wrapped\" with explosion", logs[2].message)
+}
+
 func TestProjectile_Serialize(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
-	projectile := NewProjectile(physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
+	projectile := NewProjectile(2, physics.Vector2{X: 15, Y: 30}, physics.Vector2{X: 10, Y: 20}, math.Pi, 5.0, 20.0, owner)
 
 	assert.Equal(t, map[string]interface{}{
 		"type":    "laser",
@@ -179,6 +274,7 @@ func TestProjectile_Serialize(t *testing.T) {
 		"lifespanSec": 5.0,
 		"damage":      20.0,
 		"owner":       projectile.owner.ID(),
+		"target":      int64(0),
 		"collider":    projectile.collider.Serialize(),
 	}, projectile.Serialize())
 }
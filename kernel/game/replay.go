@@ -0,0 +1,283 @@
+package game
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+const ReplayVersion = 1
+
+type ReplayEventType string
+
+const (
+	ReplayEventTick       ReplayEventType = "t"
+	ReplayEventAction     ReplayEventType = "a"
+	ReplayEventStart      ReplayEventType = "s"
+	ReplayEventPause      ReplayEventType = "p"
+	ReplayEventReset      ReplayEventType = "r"
+	ReplayEventDisqualify ReplayEventType = "d"
+)
+
+// ReplayEvent is a single input to the game, serialized as a tuple:
+//   - ["t", deltaTimeMs, count]: count consecutive ticks with the same delta time
+//   - ["a", shipName, action]: an action applied to the ship
+//   - ["d", shipName]: the ship was disqualified
+//   - ["s"], ["p"], ["r"]: the game was started, paused or reset
+type ReplayEvent struct {
+	Type        ReplayEventType
+	Ship        string
+	Action      Action
+	DeltaTimeMs float64
+	Count       int64
+}
+
+type ReplayShip struct {
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	Team          string  `json:"team,omitempty"`
+	X             float64 `json:"x"`
+	Y             float64 `json:"y"`
+	Rotation      float64 `json:"rotation"`
+	StartX        float64 `json:"startX"`
+	StartY        float64 `json:"startY"`
+	StartRotation float64 `json:"startRotation"`
+}
+
+// Replay holds the initial state of a match and every input applied to it,
+// which is enough to re-run the match deterministically.
+type Replay struct {
+	Version        int           `json:"version"`
+	Seed           int64         `json:"seed"`
+	Width          float64       `json:"width"`
+	Height         float64       `json:"height"`
+	Rules          *Rules        `json:"rules,omitempty"`
+	Asteroids      bool          `json:"asteroids"`
+	LastID         int64         `json:"lastId"`
+	Ships          []ReplayShip  `json:"ships"`
+	Events         []ReplayEvent `json:"events"`
+	FinalStateHash string        `json:"finalStateHash"`
+}
+
+// StartRecording starts recording the inputs of the game into a replay.
+// The recording must start before the first tick, after the asteroids
+// have been seeded and the spaceships added.
+func (game *Game) StartRecording() error {
+	if game.manager.Time().Tick > 0 {
+		return errors.New("recording must start before the first tick")
+	}
+
+	rules := *game.Rules()
+	replay := &Replay{
+		Version: ReplayVersion,
+		Seed:    game.seed,
+		Width:   game.size.Width,
+		Height:  game.size.Height,
+		Rules:   &rules,
+		LastID:  game.manager.ids.Last(),
+		Ships:   []ReplayShip{},
+		Events:  []ReplayEvent{},
+	}
+	for _, gameObject := range game.manager.GameObjects() {
+		switch gameObject := gameObject.(type) {
+		case *Asteroid:
+			replay.Asteroids = true
+		case *Spaceship:
+			replay.Ships = append(replay.Ships, ReplayShip{
+				ID:            gameObject.id,
+				Name:          gameObject.name,
+				Team:          gameObject.team,
+				X:             gameObject.position.X,
+				Y:             gameObject.position.Y,
+				Rotation:      gameObject.rotation,
+				StartX:        gameObject.startPosition.X,
+				StartY:        gameObject.startPosition.Y,
+				StartRotation: gameObject.startRotation,
+			})
+		}
+	}
+
+	game.replay = replay
+	return nil
+}
+
+// StopRecording stops the recording and returns the replay with the hash of the current state.
+func (game *Game) StopRecording() *Replay {
+	replay := game.replay
+	game.replay = nil
+	if replay != nil {
+		replay.FinalStateHash = game.StateHash()
+	}
+	return replay
+}
+
+func (game *Game) record(event ReplayEvent) {
+	if game.replay == nil {
+		return
+	}
+
+	if event.Type == ReplayEventTick {
+		events := game.replay.Events
+		if last := len(events) - 1; last >= 0 && events[last].Type == ReplayEventTick && events[last].DeltaTimeMs == event.DeltaTimeMs {
+			events[last].Count++
+			return
+		}
+		event.Count = 1
+	}
+
+	game.replay.Events = append(game.replay.Events, event)
+}
+
+// PlayReplay re-runs the recorded match and returns the game in its final state.
+func PlayReplay(replay *Replay) (*Game, error) {
+	if replay.Version != ReplayVersion {
+		return nil, fmt.Errorf("unsupported replay version: %d", replay.Version)
+	}
+
+	// Missing in the replays recorded before the rules were configurable
+	rules := DefaultRules()
+	if replay.Rules != nil {
+		if err := replay.Rules.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid rules: %w", err)
+		}
+		rules = replay.Rules
+	}
+
+	game := NewGame(physics.Size{Width: replay.Width, Height: replay.Height}, replay.Seed, rules)
+	if replay.Asteroids {
+		game.SeedAsteroids()
+	}
+	for _, ship := range replay.Ships {
+		spaceship := NewSpaceship(ship.ID, ship.Name, physics.Vector2{X: ship.X, Y: ship.Y}, ship.Rotation, game.manager.rules)
+		spaceship.team = ship.Team
+		spaceship.SetStartPosition(physics.Vector2{X: ship.StartX, Y: ship.StartY})
+		spaceship.SetStartRotation(ship.StartRotation)
+		if err := game.manager.AddSpaceship(spaceship); err != nil {
+			return nil, err
+		}
+	}
+	game.manager.ids.Set(replay.LastID)
+
+	for i, event := range replay.Events {
+		switch event.Type {
+		case ReplayEventTick:
+			for j := int64(0); j < event.Count; j++ {
+				game.Update(event.DeltaTimeMs)
+			}
+		case ReplayEventAction:
+			if err := game.ApplyAction(event.Ship, event.Action); err != nil {
+				return nil, fmt.Errorf("event %d: %w", i, err)
+			}
+		case ReplayEventDisqualify:
+			if err := game.Disqualify(event.Ship); err != nil {
+				return nil, fmt.Errorf("event %d: %w", i, err)
+			}
+		case ReplayEventStart:
+			game.Start()
+		case ReplayEventPause:
+			game.Pause()
+		case ReplayEventReset:
+			game.Reset()
+		default:
+			return nil, fmt.Errorf("event %d: unknown event type: %s", i, event.Type)
+		}
+	}
+
+	return game, nil
+}
+
+// Verify re-runs the recorded match and checks the final state matches the recorded one.
+func (replay *Replay) Verify() (*Game, error) {
+	game, err := PlayReplay(replay)
+	if err != nil {
+		return nil, err
+	}
+	if hash := game.StateHash(); hash != replay.FinalStateHash {
+		return game, fmt.Errorf("final state hash mismatch: expected %s, got %s", replay.FinalStateHash, hash)
+	}
+	return game, nil
+}
+
+// WriteReplay writes the replay as gzip compressed JSON.
+func WriteReplay(writer io.Writer, replay *Replay) error {
+	compressed := gzip.NewWriter(writer)
+	if err := json.NewEncoder(compressed).Encode(replay); err != nil {
+		return err
+	}
+	return compressed.Close()
+}
+
+// ReadReplay reads a replay written by WriteReplay.
+func ReadReplay(reader io.Reader) (*Replay, error) {
+	compressed, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer compressed.Close()
+
+	replay := &Replay{}
+	if err := json.NewDecoder(compressed).Decode(replay); err != nil {
+		return nil, err
+	}
+	if replay.Version != ReplayVersion {
+		return nil, fmt.Errorf("unsupported replay version: %d", replay.Version)
+	}
+	return replay, nil
+}
+
+func (event ReplayEvent) MarshalJSON() ([]byte, error) {
+	switch event.Type {
+	case ReplayEventTick:
+		return json.Marshal([]interface{}{event.Type, event.DeltaTimeMs, event.Count})
+	case ReplayEventAction:
+		return json.Marshal([]interface{}{event.Type, event.Ship, event.Action})
+	case ReplayEventDisqualify:
+		return json.Marshal([]interface{}{event.Type, event.Ship})
+	default:
+		return json.Marshal([]interface{}{event.Type})
+	}
+}
+
+func (event *ReplayEvent) UnmarshalJSON(data []byte) error {
+	tuple := []json.RawMessage{}
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	if len(tuple) == 0 {
+		return errors.New("replay event must not be empty")
+	}
+	if err := json.Unmarshal(tuple[0], &event.Type); err != nil {
+		return err
+	}
+
+	expected := 1
+	switch event.Type {
+	case ReplayEventTick, ReplayEventAction:
+		expected = 3
+	case ReplayEventDisqualify:
+		expected = 2
+	}
+	if len(tuple) != expected {
+		return fmt.Errorf("replay event %s expects %d values, got %d", event.Type, expected, len(tuple))
+	}
+
+	switch event.Type {
+	case ReplayEventTick:
+		if err := json.Unmarshal(tuple[1], &event.DeltaTimeMs); err != nil {
+			return err
+		}
+		return json.Unmarshal(tuple[2], &event.Count)
+	case ReplayEventAction:
+		if err := json.Unmarshal(tuple[1], &event.Ship); err != nil {
+			return err
+		}
+		return json.Unmarshal(tuple[2], &event.Action)
+	case ReplayEventDisqualify:
+		return json.Unmarshal(tuple[1], &event.Ship)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func recordTestMatch(t *testing.T) (*Game, *Replay) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.SeedAsteroids()
+	game.AddSpaceship("ship1", physics.Vector2{X: 300, Y: 200}, 0, "")
+	game.AddSpaceship("ship2", physics.Vector2{X: 700, Y: 500}, math.Pi, "")
+	game.AddSpaceship("ship3", physics.Vector2{X: 500, Y: 100}, 0, "")
+	game.ApplyAction("ship1", Action{Type: ActionSetStartPosition, Args: []float64{310, 210, 1}})
+	assert.NoError(t, game.StartRecording())
+
+	game.Start()
+	for i := 0; i < 60; i++ {
+		game.ApplyAction("ship1", Action{Type: ActionSetEngineThrust, Args: []float64{float64(i % 100), 10, 0}})
+		game.ApplyAction("ship1", Action{Type: ActionFireLaser})
+		game.ApplyAction("ship2", Action{Type: ActionFireRocket})
+		if i == 30 {
+			game.Pause()
+			game.Disqualify("ship3")
+			game.Start()
+		}
+		game.Update(50)
+	}
+	game.Update(20)
+
+	return game, game.StopRecording()
+}
+
+func TestGame_StartRecording(t *testing.T) {
+	game, replay := recordTestMatch(t)
+
+	assert.Nil(t, game.replay)
+	assert.Equal(t, ReplayVersion, replay.Version)
+	assert.Equal(t, int64(1234567890), replay.Seed)
+	assert.Equal(t, 1024.0, replay.Width)
+	assert.Equal(t, 768.0, replay.Height)
+	assert.Equal(t, DefaultRules(), replay.Rules)
+	assert.True(t, replay.Asteroids)
+	assert.Len(t, replay.Ships, 3)
+	assert.Equal(t, ReplayShip{ID: 7, Name: "ship1", X: 300, Y: 200, Rotation: 0, StartX: 310, StartY: 210, StartRotation: 1}, replay.Ships[0])
+	assert.Equal(t, game.StateHash(), replay.FinalStateHash)
+
+	// Ticks with the same delta time are merged
+	last := replay.Events[len(replay.Events)-1]
+	assert.Equal(t, ReplayEvent{Type: ReplayEventTick, DeltaTimeMs: 20, Count: 1}, last)
+
+	game.Update(50)
+	assert.EqualError(t, game.StartRecording(), "recording must start before the first tick")
+}
+
+func TestGame_StopRecording_NotRecording(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	assert.Nil(t, game.StopRecording())
+}
+
+func TestReplay_Verify(t *testing.T) {
+	game, replay := recordTestMatch(t)
+
+	played, err := replay.Verify()
+
+	assert.NoError(t, err)
+	assert.Equal(t, game.Serialize(), played.Serialize())
+
+	replay.Events = replay.Events[:len(replay.Events)-1]
+	_, err = replay.Verify()
+	assert.ErrorContains(t, err, "final state hash mismatch")
+}
+
+func TestPlayReplay_Errors(t *testing.T) {
+	_, err := PlayReplay(&Replay{Version: 0})
+	assert.EqualError(t, err, "unsupported replay version: 0")
+
+	_, err = PlayReplay(&Replay{Version: ReplayVersion, Events: []ReplayEvent{{Type: ReplayEventAction, Ship: "unknown"}}})
+	assert.EqualError(t, err, "event 0: space ship not found: unknown")
+
+	_, err = PlayReplay(&Replay{Version: ReplayVersion, Events: []ReplayEvent{{Type: "x"}}})
+	assert.EqualError(t, err, "event 0: unknown event type: x")
+
+	_, err = PlayReplay(&Replay{Version: ReplayVersion, Rules: &Rules{}})
+	assert.EqualError(t, err, "invalid rules: shipSize must be greater than 0")
+}
+
+func TestWriteReadReplay(t *testing.T) {
+	_, replay := recordTestMatch(t)
+
+	buffer := bytes.Buffer{}
+	assert.NoError(t, WriteReplay(&buffer, replay))
+	read, err := ReadReplay(&buffer)
+
+	assert.NoError(t, err)
+	assert.Equal(t, replay, read)
+	_, err = read.Verify()
+	assert.NoError(t, err)
+
+	_, err = ReadReplay(bytes.NewBufferString("invalid"))
+	assert.Error(t, err)
+
+	buffer.Reset()
+	WriteReplay(&buffer, &Replay{Version: 99})
+	_, err = ReadReplay(&buffer)
+	assert.EqualError(t, err, "unsupported replay version: 99")
+}
+
+func TestReplayEvent_JSON(t *testing.T) {
+	events := []ReplayEvent{
+		{Type: ReplayEventTick, DeltaTimeMs: 50, Count: 3},
+		{Type: ReplayEventAction, Ship: "ship", Action: Action{Type: ActionFireLaser}},
+		{Type: ReplayEventDisqualify, Ship: "ship"},
+		{Type: ReplayEventStart},
+	}
+
+	data, err := json.Marshal(events)
+	assert.NoError(t, err)
+	assert.Equal(t, `[["t",50,3],["a","ship",["fireLaser"]],["d","ship"],["s"]]`, string(data))
+
+	decoded := []ReplayEvent{}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, events, decoded)
+
+	event := ReplayEvent{}
+	assert.EqualError(t, json.Unmarshal([]byte(`[]`), &event), "replay event must not be empty")
+	assert.EqualError(t, json.Unmarshal([]byte(`["t", 50]`), &event), "replay event t expects 3 values, got 2")
+}
+
+func TestGame_Disqualify(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.AddSpaceship("ship", physics.Vector2{X: 100, Y: 100}, 0, "")
+
+	assert.NoError(t, game.Disqualify("ship"))
+	spaceship, _ := game.manager.GetSpaceship("ship")
+	assert.False(t, spaceship.Enabled())
+	assert.Equal(t, 0.0, spaceship.health)
+	assert.Equal(t, 0, game.manager.aliveSides())
+
+	// Already destroyed
+	assert.NoError(t, game.Disqualify("ship"))
+	assert.Equal(t, 0, game.manager.aliveSides())
+
+	assert.EqualError(t, game.Disqualify("unknown"), "space ship not found: unknown")
+}
@@ -9,22 +9,64 @@ import (
 
 func NewRocketProjectile(id int64, position physics.Vector2, rotation float64, owner *Spaceship) *Projectile {
 	direction := physics.Vector2{X: math.Cos(rotation), Y: math.Sin(rotation)}
+	rules := owner.rules
 
 	return &Projectile{
 		id:                   id,
 		damageType:           DamageTypeRocket,
 		enabled:              true,
 		position:             position,
+		previousPosition:     position,
 		rotation:             rotation,
-		velocity:             direction.Multiply(RocketSpeedSec),
-		lifespanSec:          RocketLifespanSec,
-		damage:               RocketDamage,
+		velocity:             direction.Multiply(rules.RocketSpeedSec),
+		lifespanSec:          rules.RocketLifespanSec,
+		damage:               rules.RocketDamage,
 		owner:                owner,
-		explosionRadius:      float64(RocketExplosionRadius),
-		explosionDurationSec: float64(RocketExplosionDurationSec),
+		explosionRadius:      rules.RocketExplosionRadius,
+		explosionDurationSec: rules.RocketExplosionDurationSec,
+		explosionDamage:      rules.RocketExplosionDamage,
 		collider: collider.NewCircleCollider(
 			position,
-			RocketDetonateRadius,
+			rules.RocketDetonateRadius,
 		),
 	}
 }
+
+// lockOn makes the rocket home in on the nearest enemy spaceship within the homing cone
+// around its direction, it flies straight when there is none.
+func (projectile *Projectile) lockOn(gameManager *GameManager) {
+	rules := gameManager.rules
+	nearest := math.Inf(1)
+	for _, gameObject := range gameManager.GameObjects() {
+		ship, ok := gameObject.(*Spaceship)
+		if !ok || !ship.Enabled() || ship == projectile.owner || projectile.owner.IsTeammate(ship) {
+			continue
+		}
+
+		displacement := physics.WrappedDisplacement(projectile.position, ship.position, gameManager.Size())
+		angle := math.Atan2(displacement.Y, displacement.X)
+		distance := displacement.Magnitude()
+		if math.Abs(math.Remainder(angle-projectile.rotation, 2*math.Pi)) <= rules.RocketHomingConeAngle && distance < nearest {
+			nearest = distance
+			projectile.target = ship
+		}
+	}
+}
+
+// steer turns the rocket towards its target by up to the homing turn rate, keeping its speed.
+// The lock is lost once the target is destroyed, the rocket flies on straight.
+func (projectile *Projectile) steer(deltaTimeSec float64, gameManager *GameManager) {
+	if !projectile.target.Enabled() {
+		projectile.target = nil
+		return
+	}
+
+	displacement := physics.WrappedDisplacement(projectile.position, projectile.target.position, gameManager.Size())
+	turn := math.Remainder(math.Atan2(displacement.Y, displacement.X)-projectile.rotation, 2*math.Pi)
+	maxTurn := gameManager.rules.RocketHomingTurnRateSec * deltaTimeSec
+	turn = math.Max(-maxTurn, math.Min(turn, maxTurn))
+
+	projectile.rotation = math.Remainder(projectile.rotation+turn, 2*math.Pi)
+	projectile.velocity = projectile.velocity.Rotate(turn)
+	projectile.collider.SetRotation(projectile.rotation)
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"encoding/json"
 	"math"
 	"testing"
 
@@ -11,7 +12,7 @@ import (
 )
 
 func TestNewRocketProjectile(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
 	projectile := NewRocketProjectile(1, physics.Vector2{X: 15, Y: 30}, math.Pi, owner)
 
 	assert.Equal(t, int64(1), projectile.ID())
@@ -31,7 +32,7 @@ func TestNewRocketProjectile(t *testing.T) {
 }
 
 func TestRocket_Serialize(t *testing.T) {
-	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100)
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 15, Y: 30}, 100, DefaultRules())
 	projectile := NewRocketProjectile(1, physics.Vector2{X: 15, Y: 30}, math.Pi, owner)
 
 	assert.Equal(t, map[string]interface{}{
@@ -50,6 +51,92 @@ func TestRocket_Serialize(t *testing.T) {
 		"lifespanSec": 10.0,
 		"damage":      60.0,
 		"owner":       projectile.owner.ID(),
+		"target":      int64(0),
 		"collider":    projectile.collider.Serialize(),
 	}, projectile.Serialize())
 }
+
+func TestRocket_LockOn(t *testing.T) {
+	gameManager := NewGameManager()
+	gameManager.size = physics.Size{Width: 1000, Height: 1000}
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 100, Y: 500}, 0, DefaultRules())
+	teammate := NewSpaceship(2, "teammate", physics.Vector2{X: 200, Y: 500}, 0, DefaultRules())
+	behind := NewSpaceship(3, "behind", physics.Vector2{X: 50, Y: 500}, 0, DefaultRules())
+	outside := NewSpaceship(4, "outside", physics.Vector2{X: 300, Y: 700}, 0, DefaultRules())
+	far := NewSpaceship(5, "far", physics.Vector2{X: 600, Y: 550}, 0, DefaultRules())
+	near := NewSpaceship(6, "near", physics.Vector2{X: 400, Y: 450}, 0, DefaultRules())
+	owner.team, teammate.team = "red", "red"
+	gameManager.AddGameObjects([]GameObject{owner, teammate, behind, outside, far, near})
+
+	rocket := NewRocketProjectile(7, physics.Vector2{X: 115, Y: 500}, 0, owner)
+	rocket.lockOn(&gameManager)
+	// The nearest enemy within the cone, the teammates are not targeted
+	assert.Same(t, near, rocket.target)
+
+	near.enabled = false
+	rocket.lockOn(&gameManager)
+	assert.Same(t, far, rocket.target)
+
+	// Nothing within the cone
+	rocket = NewRocketProjectile(8, physics.Vector2{X: 115, Y: 500}, math.Pi/2, owner)
+	rocket.lockOn(&gameManager)
+	assert.Nil(t, rocket.target)
+}
+
+func TestRocket_Update_Homing(t *testing.T) {
+	gameManager := NewGameManager()
+	gameManager.size = physics.Size{Width: 1000, Height: 1000}
+	owner := NewSpaceship(1, "owner", physics.Vector2{X: 100, Y: 500}, 0, DefaultRules())
+	target := NewSpaceship(2, "target", physics.Vector2{X: 400, Y: 400}, 0, DefaultRules())
+	gameManager.AddGameObjects([]GameObject{owner, target})
+	rocket := NewRocketProjectile(3, physics.Vector2{X: 100, Y: 500}, 0, owner)
+	rocket.target = target
+
+	// Turns by up to the turn rate, keeping the speed
+	rocket.Update(100, &gameManager)
+	assert.InDelta(t, -RocketHomingTurnRateSec*0.1, rocket.rotation, 1e-9)
+	assert.InDelta(t, float64(RocketSpeedSec), rocket.velocity.Magnitude(), 1e-9)
+
+	// Towards the target, no further once aligned
+	for i := 0; i < 10; i++ {
+		rocket.Update(100, &gameManager)
+	}
+	displacement := target.position.Subtract(rocket.position)
+	assert.InDelta(t, math.Atan2(displacement.Y, displacement.X), rocket.rotation, 0.01)
+
+	// The lock is lost with the target
+	target.enabled = false
+	rotation := rocket.rotation
+	rocket.Update(100, &gameManager)
+	assert.Nil(t, rocket.target)
+	assert.Equal(t, rotation, rocket.rotation)
+	assert.Equal(t, int64(0), rocket.Serialize()["target"])
+}
+
+func TestRocket_Homing(t *testing.T) {
+	rules := DefaultRules()
+	rules.RocketHoming = true
+	game := NewGame(physics.Size{Width: 1000, Height: 1000}, 1, rules)
+	game.AddSpaceship("A", physics.Vector2{X: 100, Y: 500}, 0, "")
+	game.AddSpaceship("B", physics.Vector2{X: 500, Y: 400}, 0, "")
+	game.Start()
+	shipB, _ := game.manager.GetSpaceship("B")
+
+	assert.NoError(t, game.ApplyAction("A", Action{Type: ActionFireRocket}))
+	rocket := game.manager.gameObjects[len(game.manager.gameObjects)-1].(*Projectile)
+	assert.Same(t, shipB, rocket.target)
+	assert.Equal(t, shipB.ID(), rocket.Serialize()["target"])
+
+	// The target survives the state round trip
+	serialized, err := json.Marshal(game.Serialize())
+	assert.NoError(t, err)
+	deserialized, err := Deserialize(string(serialized))
+	assert.NoError(t, err)
+	assert.Equal(t, game.Serialize(), deserialized.Serialize())
+
+	// A straight rocket would miss the ship 100 meters off its line
+	for i := 0; i < 100 && shipB.health == MaxHealth; i++ {
+		game.Update(16)
+	}
+	assert.Less(t, shipB.health, float64(MaxHealth))
+}
@@ -0,0 +1,530 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+// FriendlyFire is the damage the teammates deal to each other.
+type FriendlyFire string
+
+const (
+	// The teammates and their projectiles pass through each other
+	FriendlyFireOff FriendlyFire = "off"
+	// The damage is multiplied by the friendly fire coefficient
+	FriendlyFireReduced FriendlyFire = "reduced"
+	FriendlyFireFull    FriendlyFire = "full"
+)
+
+// SuddenDeath is what follows the time limit, until the match is decided or the sudden death runs out.
+type SuddenDeath string
+
+const (
+	// The match ends at the time limit
+	SuddenDeathOff SuddenDeath = "off"
+	// The safe zone shrinks to the center of the battlefield, the spaceships outside take damage
+	SuddenDeathShrinkingZone SuddenDeath = "shrinkingZone"
+	// The energy of the spaceships does not recharge
+	SuddenDeathNoRecharge SuddenDeath = "noRecharge"
+)
+
+// AsteroidShape is the shape of the seeded asteroids.
+type AsteroidShape string
+
+const (
+	AsteroidShapeCircle AsteroidShape = "circle"
+	// Irregular rocks, the vertices are drawn around the circle of the asteroid size
+	AsteroidShapePolygon AsteroidShape = "polygon"
+)
+
+// CollisionResponse is what happens when a spaceship collides with another spaceship or an asteroid.
+type CollisionResponse string
+
+const (
+	// The spaceships are destroyed
+	CollisionResponseDestroy CollisionResponse = "destroy"
+	// The bodies bounce off each other by their mass, the spaceships take damage by the impact speed
+	CollisionResponsePhysical CollisionResponse = "physical"
+)
+
+// Rules holds the balance of the game, it is fixed for the whole match.
+// The defaults are the constants of the configuration.
+type Rules struct {
+	// Asteroids
+	MinAsteroids          int     `json:"minAsteroids"`
+	MaxAsteroids          int     `json:"maxAsteroids"`
+	MinAsteroidSize       float64 `json:"minAsteroidSize"`
+	MaxAsteroidSize       float64 `json:"maxAsteroidSize"`
+	MinAsteroidSeparation float64 `json:"minAsteroidSeparation"`
+	// The motion of the seeded asteroids, drawn up to the maximums
+	MaxAsteroidVelocitySec        float64 `json:"maxAsteroidVelocitySec"`
+	MaxAsteroidAngularVelocitySec float64 `json:"maxAsteroidAngularVelocitySec"`
+	// The rockets split the asteroids, the fragments smaller than minAsteroidSize are not created
+	AsteroidSplit            bool    `json:"asteroidSplit"`
+	AsteroidSplitVelocitySec float64 `json:"asteroidSplitVelocitySec"`
+	// The damage destroying an asteroid, 0 is indestructible
+	AsteroidHealth float64 `json:"asteroidHealth"`
+	// The polygon asteroids have between the min and the max vertices, pulled in by up to the roughness of their size
+	AsteroidShape       AsteroidShape `json:"asteroidShape"`
+	MinAsteroidVertices int           `json:"minAsteroidVertices"`
+	MaxAsteroidVertices int           `json:"maxAsteroidVertices"`
+	AsteroidRoughness   float64       `json:"asteroidRoughness"`
+
+	// Ships
+	ShipSize                       float64 `json:"shipSize"`
+	MaxHealth                      float64 `json:"maxHealth"`
+	MaxEnergy                      float64 `json:"maxEnergy"`
+	MaxVelocitySec                 float64 `json:"maxVelocitySec"`
+	AccelerationCoefficient        float64 `json:"accelerationCoefficient"`
+	DragCoefficient                float64 `json:"dragCoefficient"`
+	SideThrustPowerCoefficient     float64 `json:"sideThrustPowerCoefficient"`
+	MaxAngularVelocitySec          float64 `json:"maxAngularVelocitySec"`
+	AngularAccelerationCoefficient float64 `json:"angularAccelerationCoefficient"`
+	AngularDragCoefficient         float64 `json:"angularDragCoefficient"`
+	HeadingFollowsVelocity         bool    `json:"headingFollowsVelocity"`
+	EnergyConsumptionMainThrustSec float64 `json:"energyConsumptionMainThrustSec"`
+	EnergyConsumptionSideThrustSec float64 `json:"energyConsumptionSideThrustSec"`
+	EnergyConsumptionRotationSec   float64 `json:"energyConsumptionRotationSec"`
+	EnergyRechargeRateSec          float64 `json:"energyRechargeRateSec"`
+	ShipExplosionRadius            float64 `json:"shipExplosionRadius"`
+	ShipExplosionDurationSec       float64 `json:"shipExplosionDurationSec"`
+	ScorePerKill                   float64 `json:"scorePerKill"`
+	ScorePerDamageCoefficient      float64 `json:"scorePerDamageCoefficient"`
+
+	// Collisions, the mass of the spaceships and of a square meter of the asteroids.
+	// The restitution is from 0, the bodies stick together, to 1, they bounce off without a loss
+	CollisionResponse          CollisionResponse `json:"collisionResponse"`
+	ShipMass                   float64           `json:"shipMass"`
+	AsteroidDensity            float64           `json:"asteroidDensity"`
+	Restitution                float64           `json:"restitution"`
+	CollisionDamageCoefficient float64           `json:"collisionDamageCoefficient"`
+
+	// Lasers
+	LaserReloadSec            float64 `json:"laserReloadSec"`
+	EnergyConsumptionLaser    float64 `json:"energyConsumptionLaser"`
+	LaserLifespanSec          float64 `json:"laserLifespanSec"`
+	LaserDamage               float64 `json:"laserDamage"`
+	LaserVelocitySec          float64 `json:"laserVelocitySec"`
+	LaserWidth                float64 `json:"laserWidth"`
+	LaserLength               float64 `json:"laserLength"`
+	LaserExplosionRadius      float64 `json:"laserExplosionRadius"`
+	LaserExplosionDurationSec float64 `json:"laserExplosionDurationSec"`
+
+	// Rockets
+	MaxRockets                 int     `json:"maxRockets"`
+	RocketReloadSec            float64 `json:"rocketReloadSec"`
+	EnergyConsumptionRocket    float64 `json:"energyConsumptionRocket"`
+	RocketLifespanSec          float64 `json:"rocketLifespanSec"`
+	RocketDamage               float64 `json:"rocketDamage"`
+	RocketSpeedSec             float64 `json:"rocketSpeedSec"`
+	RocketDetonateRadius       float64 `json:"rocketDetonateRadius"`
+	RocketExplosionRadius      float64 `json:"rocketExplosionRadius"`
+	RocketExplosionDurationSec float64 `json:"rocketExplosionDurationSec"`
+	RocketExplosionDamage      float64 `json:"rocketExplosionDamage"`
+	// The rockets home in on the nearest enemy within the cone at launch, turning by up to the turn rate
+	RocketHoming            bool    `json:"rocketHoming"`
+	RocketHomingConeAngle   float64 `json:"rocketHomingConeAngle"`
+	RocketHomingTurnRateSec float64 `json:"rocketHomingTurnRateSec"`
+
+	// Mines
+	MaxMines                 int     `json:"maxMines"`
+	MineArmingSec            float64 `json:"mineArmingSec"`
+	MineLifespanSec          float64 `json:"mineLifespanSec"`
+	MineTriggerRadius        float64 `json:"mineTriggerRadius"`
+	MineExplosionRadius      float64 `json:"mineExplosionRadius"`
+	MineExplosionDurationSec float64 `json:"mineExplosionDurationSec"`
+	MineDamage               float64 `json:"mineDamage"`
+
+	// Power-ups, spawned every interval when it is not 0
+	PowerUpIntervalSec     float64 `json:"powerUpIntervalSec"`
+	MaxPowerUps            int     `json:"maxPowerUps"`
+	PowerUpLifespanSec     float64 `json:"powerUpLifespanSec"`
+	PowerUpRadius          float64 `json:"powerUpRadius"`
+	PowerUpRockets         int     `json:"powerUpRockets"`
+	PowerUpHealth          float64 `json:"powerUpHealth"`
+	PowerUpEnergy          float64 `json:"powerUpEnergy"`
+	DamageBoostMultiplier  float64 `json:"damageBoostMultiplier"`
+	DamageBoostDurationSec float64 `json:"damageBoostDurationSec"`
+
+	// Shields
+	EnergyConsumptionShieldSec float64 `json:"energyConsumptionShieldSec"`
+	ShieldAbsorption           float64 `json:"shieldAbsorption"`
+	ShieldCooldownSec          float64 `json:"shieldCooldownSec"`
+
+	// Radar
+	RadarRange            float64 `json:"radarRange"`
+	RadarPrecisionRange   float64 `json:"radarPrecisionRange"`
+	RadarNoiseCoefficient float64 `json:"radarNoiseCoefficient"`
+	RadarLineOfSight      bool    `json:"radarLineOfSight"`
+
+	// Teams
+	FriendlyFire            FriendlyFire `json:"friendlyFire"`
+	FriendlyFireCoefficient float64      `json:"friendlyFireCoefficient"`
+
+	// Match
+	TimeLimitSec           float64     `json:"timeLimitSec"`
+	SuddenDeath            SuddenDeath `json:"suddenDeath"`
+	SuddenDeathDurationSec float64     `json:"suddenDeathDurationSec"`
+	SafeZoneDamageSec      float64     `json:"safeZoneDamageSec"`
+
+	// Game modes
+	Mode             Mode    `json:"mode"`
+	ObjectiveLimit   float64 `json:"objectiveLimit"`
+	HillRadius       float64 `json:"hillRadius"`
+	HillScoreSec     float64 `json:"hillScoreSec"`
+	FlagRadius       float64 `json:"flagRadius"`
+	FlagCaptureScore float64 `json:"flagCaptureScore"`
+
+	// Respawns, always on in the modes other than the last standing
+	Respawn                   bool    `json:"respawn"`
+	RespawnDelaySec           float64 `json:"respawnDelaySec"`
+	RespawnInvulnerabilitySec float64 `json:"respawnInvulnerabilitySec"`
+	SpawnClearance            float64 `json:"spawnClearance"`
+}
+
+func DefaultRules() *Rules {
+	return &Rules{
+		MinAsteroids:          MinAsteroids,
+		MaxAsteroids:          MaxAsteroids,
+		MinAsteroidSize:       MinAsteroidSize,
+		MaxAsteroidSize:       MaxAsteroidSize,
+		MinAsteroidSeparation: MinAsteroidSeparation,
+
+		MaxAsteroidVelocitySec:        MaxAsteroidVelocitySec,
+		MaxAsteroidAngularVelocitySec: MaxAsteroidAngularVelocitySec,
+		AsteroidSplit:                 AsteroidSplit,
+		AsteroidSplitVelocitySec:      AsteroidSplitVelocitySec,
+		AsteroidHealth:                AsteroidHealth,
+		AsteroidShape:                 AsteroidShapeKind,
+		MinAsteroidVertices:           MinAsteroidVertices,
+		MaxAsteroidVertices:           MaxAsteroidVertices,
+		AsteroidRoughness:             AsteroidRoughness,
+
+		ShipSize:                       ShipSize,
+		MaxHealth:                      MaxHealth,
+		MaxEnergy:                      MaxEnergy,
+		MaxVelocitySec:                 MaxVelocitySec,
+		AccelerationCoefficient:        AccelerationCoefficient,
+		DragCoefficient:                DragCoefficient,
+		SideThrustPowerCoefficient:     SideThrustPowerCoefficient,
+		MaxAngularVelocitySec:          MaxAngularVelocitySec,
+		AngularAccelerationCoefficient: AngularAccelerationCoefficient,
+		AngularDragCoefficient:         AngularDragCoefficient,
+		HeadingFollowsVelocity:         HeadingFollowsVelocity,
+		EnergyConsumptionMainThrustSec: EnergyConsumptionMainThrustSec,
+		EnergyConsumptionSideThrustSec: EnergyConsumptionSideThrustSec,
+		EnergyConsumptionRotationSec:   EnergyConsumptionRotationSec,
+		EnergyRechargeRateSec:          EnergyRechargeRateSec,
+		ShipExplosionRadius:            ShipExplosionRadius,
+		ShipExplosionDurationSec:       ShipExplosionDurationSec,
+		ScorePerKill:                   ScorePerKill,
+		ScorePerDamageCoefficient:      ScorePerDamageCoefficient,
+
+		CollisionResponse:          CollisionResponseMode,
+		ShipMass:                   ShipMass,
+		AsteroidDensity:            AsteroidDensity,
+		Restitution:                Restitution,
+		CollisionDamageCoefficient: CollisionDamageCoefficient,
+
+		LaserReloadSec:            LaserReloadSec,
+		EnergyConsumptionLaser:    EnergyConsumptionLaser,
+		LaserLifespanSec:          LaserLifespanSec,
+		LaserDamage:               LaserDamage,
+		LaserVelocitySec:          LaserVelocitySec,
+		LaserWidth:                LaserWidth,
+		LaserLength:               LaserLength,
+		LaserExplosionRadius:      LaserExplosionRadius,
+		LaserExplosionDurationSec: LaserExplosionDurationSec,
+
+		MaxRockets:                 MaxRockets,
+		RocketReloadSec:            RocketReloadSec,
+		EnergyConsumptionRocket:    EnergyConsumptionRocket,
+		RocketLifespanSec:          RocketLifespanSec,
+		RocketDamage:               RocketDamage,
+		RocketSpeedSec:             RocketSpeedSec,
+		RocketDetonateRadius:       RocketDetonateRadius,
+		RocketExplosionRadius:      RocketExplosionRadius,
+		RocketExplosionDurationSec: RocketExplosionDurationSec,
+		RocketExplosionDamage:      RocketExplosionDamage,
+		RocketHoming:               RocketHoming,
+		RocketHomingConeAngle:      RocketHomingConeAngle,
+		RocketHomingTurnRateSec:    RocketHomingTurnRateSec,
+
+		MaxMines:                 MaxMines,
+		MineArmingSec:            MineArmingSec,
+		MineLifespanSec:          MineLifespanSec,
+		MineTriggerRadius:        MineTriggerRadius,
+		MineExplosionRadius:      MineExplosionRadius,
+		MineExplosionDurationSec: MineExplosionDurationSec,
+		MineDamage:               MineDamage,
+
+		PowerUpIntervalSec:     PowerUpIntervalSec,
+		MaxPowerUps:            MaxPowerUps,
+		PowerUpLifespanSec:     PowerUpLifespanSec,
+		PowerUpRadius:          PowerUpRadius,
+		PowerUpRockets:         PowerUpRockets,
+		PowerUpHealth:          PowerUpHealth,
+		PowerUpEnergy:          PowerUpEnergy,
+		DamageBoostMultiplier:  DamageBoostMultiplier,
+		DamageBoostDurationSec: DamageBoostDurationSec,
+
+		EnergyConsumptionShieldSec: EnergyConsumptionShieldSec,
+		ShieldAbsorption:           ShieldAbsorption,
+		ShieldCooldownSec:          ShieldCooldownSec,
+
+		RadarRange:            RadarRange,
+		RadarPrecisionRange:   RadarPrecisionRange,
+		RadarNoiseCoefficient: RadarNoiseCoefficient,
+		RadarLineOfSight:      RadarLineOfSight,
+
+		FriendlyFire:            FriendlyFireMode,
+		FriendlyFireCoefficient: FriendlyFireCoefficient,
+
+		TimeLimitSec:           TimeLimitSec,
+		SuddenDeath:            SuddenDeathMode,
+		SuddenDeathDurationSec: SuddenDeathDurationSec,
+		SafeZoneDamageSec:      SafeZoneDamageSec,
+
+		Mode:             MatchMode,
+		ObjectiveLimit:   ObjectiveLimit,
+		HillRadius:       HillRadius,
+		HillScoreSec:     HillScoreSec,
+		FlagRadius:       FlagRadius,
+		FlagCaptureScore: FlagCaptureScore,
+
+		Respawn:                   Respawn,
+		RespawnDelaySec:           RespawnDelaySec,
+		RespawnInvulnerabilitySec: RespawnInvulnerabilitySec,
+		SpawnClearance:            SpawnClearance,
+	}
+}
+
+// LoadRules reads and parses a JSON rules file.
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses JSON rules, the omitted values keep their defaults.
+// Unknown values are rejected so a typo does not silently fall back to the default.
+func ParseRules(data []byte) (*Rules, error) {
+	rules := DefaultRules()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(rules); err != nil {
+		return nil, err
+	}
+	return rules, rules.Validate()
+}
+
+// ParseRulesOrDefault parses the rules as ParseRules does, the invalid rules fall back
+// to the defaults, returned with the error.
+func ParseRulesOrDefault(data []byte) (*Rules, error) {
+	rules, err := ParseRules(data)
+	if err != nil {
+		return DefaultRules(), err
+	}
+	return rules, nil
+}
+
+func (rules *Rules) Validate() error {
+	positive := []struct {
+		name  string
+		value float64
+	}{
+		{"shipSize", rules.ShipSize},
+		{"maxHealth", rules.MaxHealth},
+		{"maxEnergy", rules.MaxEnergy},
+		{"maxVelocitySec", rules.MaxVelocitySec},
+		{"maxAngularVelocitySec", rules.MaxAngularVelocitySec},
+		{"shipMass", rules.ShipMass},
+		{"asteroidDensity", rules.AsteroidDensity},
+		{"laserVelocitySec", rules.LaserVelocitySec},
+		{"laserWidth", rules.LaserWidth},
+		{"laserLength", rules.LaserLength},
+		{"laserLifespanSec", rules.LaserLifespanSec},
+		{"rocketSpeedSec", rules.RocketSpeedSec},
+		{"rocketDetonateRadius", rules.RocketDetonateRadius},
+		{"rocketLifespanSec", rules.RocketLifespanSec},
+		{"rocketExplosionRadius", rules.RocketExplosionRadius},
+		{"mineLifespanSec", rules.MineLifespanSec},
+		{"mineTriggerRadius", rules.MineTriggerRadius},
+		{"mineExplosionRadius", rules.MineExplosionRadius},
+		{"powerUpLifespanSec", rules.PowerUpLifespanSec},
+		{"powerUpRadius", rules.PowerUpRadius},
+		{"respawnDelaySec", rules.RespawnDelaySec},
+		{"hillRadius", rules.HillRadius},
+		{"flagRadius", rules.FlagRadius},
+	}
+	for _, rule := range positive {
+		if rule.value <= 0 {
+			return fmt.Errorf("%s must be greater than 0", rule.name)
+		}
+	}
+
+	notNegative := []struct {
+		name  string
+		value float64
+	}{
+		{"minAsteroids", float64(rules.MinAsteroids)},
+		{"minAsteroidSize", rules.MinAsteroidSize},
+		{"minAsteroidSeparation", rules.MinAsteroidSeparation},
+		{"maxAsteroidVelocitySec", rules.MaxAsteroidVelocitySec},
+		{"maxAsteroidAngularVelocitySec", rules.MaxAsteroidAngularVelocitySec},
+		{"asteroidSplitVelocitySec", rules.AsteroidSplitVelocitySec},
+		{"asteroidHealth", rules.AsteroidHealth},
+		{"asteroidRoughness", rules.AsteroidRoughness},
+		{"accelerationCoefficient", rules.AccelerationCoefficient},
+		{"dragCoefficient", rules.DragCoefficient},
+		{"sideThrustPowerCoefficient", rules.SideThrustPowerCoefficient},
+		{"angularAccelerationCoefficient", rules.AngularAccelerationCoefficient},
+		{"angularDragCoefficient", rules.AngularDragCoefficient},
+		{"energyConsumptionMainThrustSec", rules.EnergyConsumptionMainThrustSec},
+		{"energyConsumptionSideThrustSec", rules.EnergyConsumptionSideThrustSec},
+		{"energyConsumptionRotationSec", rules.EnergyConsumptionRotationSec},
+		{"energyRechargeRateSec", rules.EnergyRechargeRateSec},
+		{"shipExplosionRadius", rules.ShipExplosionRadius},
+		{"shipExplosionDurationSec", rules.ShipExplosionDurationSec},
+		{"scorePerKill", rules.ScorePerKill},
+		{"scorePerDamageCoefficient", rules.ScorePerDamageCoefficient},
+		{"restitution", rules.Restitution},
+		{"collisionDamageCoefficient", rules.CollisionDamageCoefficient},
+		{"laserReloadSec", rules.LaserReloadSec},
+		{"energyConsumptionLaser", rules.EnergyConsumptionLaser},
+		{"laserDamage", rules.LaserDamage},
+		{"laserExplosionRadius", rules.LaserExplosionRadius},
+		{"laserExplosionDurationSec", rules.LaserExplosionDurationSec},
+		{"maxRockets", float64(rules.MaxRockets)},
+		{"rocketReloadSec", rules.RocketReloadSec},
+		{"energyConsumptionRocket", rules.EnergyConsumptionRocket},
+		{"rocketDamage", rules.RocketDamage},
+		{"rocketExplosionDurationSec", rules.RocketExplosionDurationSec},
+		{"rocketExplosionDamage", rules.RocketExplosionDamage},
+		{"rocketHomingConeAngle", rules.RocketHomingConeAngle},
+		{"rocketHomingTurnRateSec", rules.RocketHomingTurnRateSec},
+		{"maxMines", float64(rules.MaxMines)},
+		{"mineArmingSec", rules.MineArmingSec},
+		{"mineExplosionDurationSec", rules.MineExplosionDurationSec},
+		{"mineDamage", rules.MineDamage},
+		{"powerUpIntervalSec", rules.PowerUpIntervalSec},
+		{"maxPowerUps", float64(rules.MaxPowerUps)},
+		{"powerUpRockets", float64(rules.PowerUpRockets)},
+		{"powerUpHealth", rules.PowerUpHealth},
+		{"powerUpEnergy", rules.PowerUpEnergy},
+		{"damageBoostMultiplier", rules.DamageBoostMultiplier},
+		{"damageBoostDurationSec", rules.DamageBoostDurationSec},
+		{"energyConsumptionShieldSec", rules.EnergyConsumptionShieldSec},
+		{"shieldAbsorption", rules.ShieldAbsorption},
+		{"shieldCooldownSec", rules.ShieldCooldownSec},
+		{"radarRange", rules.RadarRange},
+		{"radarPrecisionRange", rules.RadarPrecisionRange},
+		{"radarNoiseCoefficient", rules.RadarNoiseCoefficient},
+		{"friendlyFireCoefficient", rules.FriendlyFireCoefficient},
+		{"timeLimitSec", rules.TimeLimitSec},
+		{"suddenDeathDurationSec", rules.SuddenDeathDurationSec},
+		{"safeZoneDamageSec", rules.SafeZoneDamageSec},
+		{"objectiveLimit", rules.ObjectiveLimit},
+		{"hillScoreSec", rules.HillScoreSec},
+		{"flagCaptureScore", rules.FlagCaptureScore},
+		{"respawnInvulnerabilitySec", rules.RespawnInvulnerabilitySec},
+		{"spawnClearance", rules.SpawnClearance},
+	}
+	for _, rule := range notNegative {
+		if rule.value < 0 {
+			return fmt.Errorf("%s must not be negative", rule.name)
+		}
+	}
+
+	if rules.RocketHomingConeAngle > math.Pi {
+		return errors.New("rocketHomingConeAngle must not be greater than π")
+	}
+	if rules.ShieldAbsorption > 1 {
+		return errors.New("shieldAbsorption must not be greater than 1")
+	}
+	if rules.Restitution > 1 {
+		return errors.New("restitution must not be greater than 1")
+	}
+	if rules.MinAsteroids >= rules.MaxAsteroids {
+		return errors.New("minAsteroids must be less than maxAsteroids")
+	}
+	if rules.MinAsteroidSize > rules.MaxAsteroidSize {
+		return errors.New("minAsteroidSize must not be greater than maxAsteroidSize")
+	}
+	switch rules.AsteroidShape {
+	case AsteroidShapeCircle:
+	case AsteroidShapePolygon:
+		if rules.MinAsteroidVertices < 3 {
+			return errors.New("minAsteroidVertices must be at least 3")
+		}
+		if rules.MinAsteroidVertices > rules.MaxAsteroidVertices {
+			return errors.New("minAsteroidVertices must not be greater than maxAsteroidVertices")
+		}
+		if rules.AsteroidRoughness >= 1 {
+			return errors.New("asteroidRoughness must be less than 1")
+		}
+	default:
+		return fmt.Errorf("asteroidShape must be one of %q or %q", AsteroidShapeCircle, AsteroidShapePolygon)
+	}
+	switch rules.CollisionResponse {
+	case CollisionResponseDestroy, CollisionResponsePhysical:
+	default:
+		return fmt.Errorf("collisionResponse must be one of %q or %q", CollisionResponseDestroy, CollisionResponsePhysical)
+	}
+	switch rules.FriendlyFire {
+	case FriendlyFireOff, FriendlyFireReduced, FriendlyFireFull:
+	default:
+		return fmt.Errorf("friendlyFire must be one of %q, %q or %q", FriendlyFireOff, FriendlyFireReduced, FriendlyFireFull)
+	}
+	switch rules.SuddenDeath {
+	case SuddenDeathOff:
+	case SuddenDeathShrinkingZone, SuddenDeathNoRecharge:
+		if rules.SuddenDeathDurationSec <= 0 {
+			return errors.New("suddenDeathDurationSec must be greater than 0 with the sudden death")
+		}
+	default:
+		return fmt.Errorf("suddenDeath must be one of %q, %q or %q", SuddenDeathOff, SuddenDeathShrinkingZone, SuddenDeathNoRecharge)
+	}
+	switch rules.Mode {
+	case ModeLastStanding:
+		// The destroyed spaceships respawn, the last side would never stand alone
+		if rules.Respawn && rules.TimeLimitSec <= 0 {
+			return errors.New("respawn requires timeLimitSec in the last standing mode")
+		}
+	case ModeDeathmatch, ModeKingOfTheHill, ModeCaptureTheFlag:
+		// The destroyed spaceships respawn, the match would never end
+		if rules.ObjectiveLimit <= 0 && rules.TimeLimitSec <= 0 {
+			return fmt.Errorf("mode %q requires objectiveLimit or timeLimitSec", rules.Mode)
+		}
+	default:
+		return fmt.Errorf("mode must be one of %q, %q, %q or %q", ModeLastStanding, ModeDeathmatch, ModeKingOfTheHill, ModeCaptureTheFlag)
+	}
+
+	return nil
+}
+
+// MatchDurationSec returns the longest the match could last, the time limit and the sudden death,
+// 0 when the match is not limited.
+func (rules *Rules) MatchDurationSec() float64 {
+	if rules.TimeLimitSec <= 0 {
+		return 0
+	}
+	if rules.SuddenDeath == SuddenDeathOff {
+		return rules.TimeLimitSec
+	}
+	return rules.TimeLimitSec + rules.SuddenDeathDurationSec
+}
+
+func (rules *Rules) Serialize() map[string]interface{} {
+	// The JSON tags are the serialized names
+	data, _ := json.Marshal(rules)
+	serialized := map[string]interface{}{}
+	json.Unmarshal(data, &serialized)
+	return serialized
+}
@@ -0,0 +1,190 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultRules(t *testing.T) {
+	rules := DefaultRules()
+
+	assert.NoError(t, rules.Validate())
+	assert.Equal(t, float64(MaxHealth), rules.MaxHealth)
+	assert.Equal(t, float64(LaserDamage), rules.LaserDamage)
+	assert.Equal(t, MaxRockets, rules.MaxRockets)
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules([]byte(`{"laserDamage": 25, "maxRockets": 3}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, rules.LaserDamage)
+	assert.Equal(t, 3, rules.MaxRockets)
+	// The omitted values keep their defaults
+	assert.Equal(t, float64(RocketDamage), rules.RocketDamage)
+
+	_, err = ParseRules([]byte(`{"laserDamge": 25}`))
+	assert.EqualError(t, err, `json: unknown field "laserDamge"`)
+
+	_, err = ParseRules([]byte(`{"maxHealth": 0}`))
+	assert.EqualError(t, err, "maxHealth must be greater than 0")
+}
+
+func TestParseRulesOrDefault(t *testing.T) {
+	rules, err := ParseRulesOrDefault([]byte(`{"laserDamage": 25}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, rules.LaserDamage)
+
+	// Neither the invalid JSON nor the invalid values are used
+	for _, data := range []string{`{"laserDamge": 25}`, `{"maxHealth": 0}`, `invalid`} {
+		rules, err = ParseRulesOrDefault([]byte(data))
+		assert.Error(t, err)
+		assert.Equal(t, DefaultRules(), rules)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"maxHealth": 150}`), 0o644))
+
+	rules, err := LoadRules(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 150.0, rules.MaxHealth)
+
+	_, err = LoadRules(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestRules_Validate(t *testing.T) {
+	var tests = []struct {
+		modify      func(rules *Rules)
+		expectedErr string
+	}{
+		{func(rules *Rules) { rules.ShipSize = 0 }, "shipSize must be greater than 0"},
+		{func(rules *Rules) { rules.MaxVelocitySec = -1 }, "maxVelocitySec must be greater than 0"},
+		{func(rules *Rules) { rules.LaserDamage = -1 }, "laserDamage must not be negative"},
+		{func(rules *Rules) { rules.MaxRockets = -1 }, "maxRockets must not be negative"},
+		{func(rules *Rules) { rules.RadarRange = -1 }, "radarRange must not be negative"},
+		{func(rules *Rules) { rules.MinAsteroids = 7 }, "minAsteroids must be less than maxAsteroids"},
+		{func(rules *Rules) { rules.MinAsteroidSize = 40 }, "minAsteroidSize must not be greater than maxAsteroidSize"},
+		{func(rules *Rules) { rules.FriendlyFire = "some" }, `friendlyFire must be one of "off", "reduced" or "full"`},
+		{func(rules *Rules) { rules.TimeLimitSec = -1 }, "timeLimitSec must not be negative"},
+		{func(rules *Rules) { rules.SuddenDeath = "none" }, `suddenDeath must be one of "off", "shrinkingZone" or "noRecharge"`},
+		{func(rules *Rules) { rules.SuddenDeath, rules.SuddenDeathDurationSec = SuddenDeathNoRecharge, 0 }, "suddenDeathDurationSec must be greater than 0 with the sudden death"},
+		{func(rules *Rules) { rules.RespawnDelaySec = 0 }, "respawnDelaySec must be greater than 0"},
+		{func(rules *Rules) { rules.Mode = "battleRoyale" }, `mode must be one of "lastStanding", "deathmatch", "kingOfTheHill" or "captureTheFlag"`},
+		{func(rules *Rules) { rules.Mode = ModeDeathmatch }, `mode "deathmatch" requires objectiveLimit or timeLimitSec`},
+		{func(rules *Rules) { rules.Respawn = true }, "respawn requires timeLimitSec in the last standing mode"},
+		{func(rules *Rules) { rules.SpawnClearance = -1 }, "spawnClearance must not be negative"},
+		{func(rules *Rules) { rules.RocketHomingConeAngle = 4 }, "rocketHomingConeAngle must not be greater than π"},
+		{func(rules *Rules) { rules.ShieldAbsorption = 1.5 }, "shieldAbsorption must not be greater than 1"},
+		{func(rules *Rules) { rules.CollisionResponse = "bounce" }, `collisionResponse must be one of "destroy" or "physical"`},
+		{func(rules *Rules) { rules.ShipMass = 0 }, "shipMass must be greater than 0"},
+		{func(rules *Rules) { rules.Restitution = 1.5 }, "restitution must not be greater than 1"},
+		{func(rules *Rules) { rules.PowerUpRadius = 0 }, "powerUpRadius must be greater than 0"},
+		{func(rules *Rules) { rules.MaxPowerUps = -1 }, "maxPowerUps must not be negative"},
+		{func(rules *Rules) { rules.MaxAsteroidVelocitySec = -1 }, "maxAsteroidVelocitySec must not be negative"},
+		{func(rules *Rules) { rules.AsteroidHealth = -1 }, "asteroidHealth must not be negative"},
+		{func(rules *Rules) { rules.AsteroidShape = "square" }, `asteroidShape must be one of "circle" or "polygon"`},
+		{func(rules *Rules) { rules.AsteroidShape, rules.MinAsteroidVertices = AsteroidShapePolygon, 2 }, "minAsteroidVertices must be at least 3"},
+		{func(rules *Rules) { rules.AsteroidShape, rules.MaxAsteroidVertices = AsteroidShapePolygon, 5 }, "minAsteroidVertices must not be greater than maxAsteroidVertices"},
+		{func(rules *Rules) { rules.AsteroidShape, rules.AsteroidRoughness = AsteroidShapePolygon, 1 }, "asteroidRoughness must be less than 1"},
+	}
+
+	for _, test := range tests {
+		rules := DefaultRules()
+		test.modify(rules)
+		assert.EqualError(t, rules.Validate(), test.expectedErr)
+	}
+
+	// Zero is allowed where it disables a mechanic
+	rules := DefaultRules()
+	rules.MaxRockets = 0
+	rules.RocketExplosionDamage = 0
+	assert.NoError(t, rules.Validate())
+}
+
+func TestRules_MatchDurationSec(t *testing.T) {
+	rules := DefaultRules()
+	assert.Equal(t, 0.0, rules.MatchDurationSec())
+
+	rules.TimeLimitSec = 120
+	assert.Equal(t, 120.0, rules.MatchDurationSec())
+
+	rules.SuddenDeath = SuddenDeathShrinkingZone
+	rules.SuddenDeathDurationSec = 30
+	assert.Equal(t, 150.0, rules.MatchDurationSec())
+}
+
+func TestRules_Serialize(t *testing.T) {
+	rules := DefaultRules()
+	rules.LaserDamage = 25
+
+	serialized := rules.Serialize()
+
+	assert.Equal(t, 25.0, serialized["laserDamage"])
+	assert.Equal(t, float64(MaxRockets), serialized["maxRockets"])
+	assert.Equal(t, false, serialized["radarLineOfSight"])
+	assert.Len(t, serialized, 99)
+}
+
+func TestNewGame_Rules(t *testing.T) {
+	rules := DefaultRules()
+	rules.MaxHealth = 150
+	rules.MaxRockets = 3
+	rules.LaserDamage = 30
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, rules)
+
+	// The game keeps its own copy
+	rules.MaxHealth = 10
+	assert.Equal(t, 150.0, game.Rules().MaxHealth)
+
+	game.AddSpaceship("ship1", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.AddSpaceship("ship2", physics.Vector2{X: 200, Y: 100}, 0, "")
+	ship1, _ := game.manager.GetSpaceship("ship1")
+	ship2, _ := game.manager.GetSpaceship("ship2")
+	assert.Equal(t, 150.0, ship1.health)
+	assert.Equal(t, int32(3), ship1.rockets)
+
+	game.Start()
+	game.ApplyAction("ship1", Action{Type: ActionFireLaser})
+	for i := 0; i < 10; i++ {
+		game.Update(50)
+	}
+	assert.Equal(t, 120.0, ship2.health)
+}
+
+func TestDeserialize_Rules(t *testing.T) {
+	rules := DefaultRules()
+	rules.LaserDamage = 30
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, rules)
+	game.AddSpaceship("ship", physics.Vector2{X: 100, Y: 100}, 0, "")
+
+	serialized, _ := json.Marshal(game.Serialize())
+	deserialized, err := Deserialize(string(serialized))
+
+	assert.NoError(t, err)
+	assert.Equal(t, game.Rules(), deserialized.Rules())
+	spaceship, _ := deserialized.manager.GetSpaceship("ship")
+	assert.Equal(t, deserialized.Rules(), spaceship.rules)
+
+	// States created before the rules were configurable use the defaults
+	state := game.Serialize()
+	delete(state, "schemaVersion")
+	delete(state, "rules")
+	serialized, _ = json.Marshal(state)
+	deserialized, err = Deserialize(string(serialized))
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultRules(), deserialized.Rules())
+
+	state["schemaVersion"] = StateVersion
+	state["rules"] = map[string]interface{}{"maxHealth": -1}
+	serialized, _ = json.Marshal(state)
+	_, err = Deserialize(string(serialized))
+	assert.EqualError(t, err, "rules: maxHealth must be greater than 0")
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"reflect"
+	"strings"
+)
+
+//go:generate go run ../../cmd/schema -o ../../spaceships/state.schema.json
+
+// gameObjectStates are the states of the serialized game objects by their types.
+var gameObjectStates = []struct {
+	types []string
+	state interface{}
+}{
+	{[]string{"asteroid"}, AsteroidState{}},
+	{[]string{"laser", "rocket"}, ProjectileState{}},
+	{[]string{"spaceship"}, SpaceshipState{}},
+	{[]string{"explosion"}, ExplosionState{}},
+	{[]string{"mine"}, MineState{}},
+	{[]string{"powerUp"}, PowerUpState{}},
+	{[]string{"hill"}, HillState{}},
+	{[]string{"flag"}, FlagState{}},
+}
+
+// schemaEnums are the values of the string types.
+var schemaEnums = map[reflect.Type][]interface{}{
+	reflect.TypeOf(Status("")):      {Initialized, Running, Paused, Ended},
+	reflect.TypeOf(LogType("")):     {LogTypeDamage, LogTypeKill, LogTypeCollision, LogTypeGameState, LogTypePowerUp},
+	reflect.TypeOf(EndReason("")):   {EndReasonNone, EndReasonEliminated, EndReasonTimeLimit, EndReasonObjective},
+	reflect.TypeOf(PowerUpKind("")): {PowerUpKindRockets, PowerUpKindHealth, PowerUpKindEnergy, PowerUpKindDamageBoost},
+}
+
+// StateSchema returns the JSON Schema of the serialized state of the current StateVersion,
+// generated from the state structs. The structs are the definitions named after their Go types.
+func StateSchema() map[string]interface{} {
+	definitions := map[string]interface{}{}
+	schema := schemaOf(reflect.TypeOf(State{}), definitions)
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "Space Wars game state"
+
+	properties := schema["properties"].(map[string]interface{})
+	properties["schemaVersion"] = map[string]interface{}{"const": StateVersion}
+
+	gameObjects := []interface{}{}
+	for _, gameObject := range gameObjectStates {
+		stateType := reflect.TypeOf(gameObject.state)
+		reference := schemaOf(stateType, definitions)
+		definition := definitions[stateType.Name()].(map[string]interface{})
+		definition["properties"].(map[string]interface{})["type"] = map[string]interface{}{"enum": gameObject.types}
+		gameObjects = append(gameObjects, reference)
+	}
+	properties["gameObjects"].(map[string]interface{})["items"] = map[string]interface{}{"oneOf": gameObjects}
+	properties["logs"].(map[string]interface{})["items"] = schemaOf(reflect.TypeOf(LogState{}), definitions)
+
+	// The omitted rules keep their defaults
+	delete(definitions["Rules"].(map[string]interface{}), "required")
+
+	schema["$defs"] = definitions
+	return schema
+}
+
+// schemaOf returns the schema of the type, the structs are added to the definitions
+// and referenced, except the root State.
+func schemaOf(valueType reflect.Type, definitions map[string]interface{}) map[string]interface{} {
+	if values, ok := schemaEnums[valueType]; ok {
+		return map[string]interface{}{"enum": values}
+	}
+
+	switch valueType.Kind() {
+	case reflect.Ptr:
+		return schemaOf(valueType.Elem(), definitions)
+	case reflect.Struct:
+		if valueType == reflect.TypeOf(State{}) {
+			return objectSchema(valueType, definitions)
+		}
+		if _, ok := definitions[valueType.Name()]; !ok {
+			// Reserved first, the structs could be recursive
+			definitions[valueType.Name()] = nil
+			definitions[valueType.Name()] = objectSchema(valueType, definitions)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + valueType.Name()}
+	case reflect.Slice:
+		items := map[string]interface{}{}
+		if valueType.Elem().Kind() != reflect.Interface {
+			items = schemaOf(valueType.Elem(), definitions)
+		}
+		return map[string]interface{}{"type": "array", "items": items}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	default:
+		panic("unsupported state field kind: " + valueType.Kind().String())
+	}
+}
+
+func objectSchema(valueType reflect.Type, definitions map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	addFields(valueType, properties, &required, definitions)
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// addFields adds the fields of the struct, including the embedded ones, the same way
+// the stateDecoder reads them.
+func addFields(valueType reflect.Type, properties map[string]interface{}, required *[]string, definitions map[string]interface{}) {
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if field.Anonymous {
+			addFields(field.Type, properties, required, definitions)
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		properties[name] = schemaOf(field.Type, definitions)
+		if options != "omitempty" {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateSchema_UpToDate(t *testing.T) {
+	expected, err := json.MarshalIndent(StateSchema(), "", "  ")
+	assert.NoError(t, err)
+
+	actual, err := os.ReadFile("../../spaceships/state.schema.json")
+	assert.NoError(t, err)
+
+	assert.Equal(t, string(expected)+"\n", string(actual), "the schema is outdated, run: go generate ./kernel/game")
+}
+
+func TestStateSchema_Serialize(t *testing.T) {
+	schema := toJSONValue(t, StateSchema()).(map[string]interface{})
+	definitions := schema["$defs"].(map[string]interface{})
+	state := newTestState(t)
+
+	assertKeys := func(definition map[string]interface{}, object map[string]interface{}, name string) {
+		properties := keys(definition["properties"].(map[string]interface{}))
+		required := []string{}
+		for _, key := range definition["required"].([]interface{}) {
+			required = append(required, key.(string))
+		}
+		for _, key := range keys(object) {
+			assert.Contains(t, properties, key, name)
+		}
+		for _, key := range required {
+			assert.Contains(t, object, key, name)
+		}
+	}
+
+	assertKeys(schema, state, "state")
+	assertKeys(definitions["LogState"].(map[string]interface{}), state["logs"].([]interface{})[0].(map[string]interface{}), "log")
+	for _, gameObject := range state["gameObjects"].([]interface{}) {
+		object := gameObject.(map[string]interface{})
+		name := map[string]string{
+			"asteroid":  "AsteroidState",
+			"laser":     "ProjectileState",
+			"rocket":    "ProjectileState",
+			"spaceship": "SpaceshipState",
+			"explosion": "ExplosionState",
+			"mine":      "MineState",
+		}[object["type"].(string)]
+		assertKeys(definitions[name].(map[string]interface{}), object, name)
+	}
+}
+
+func keys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,188 @@
+package game
+
+import "sort"
+
+type ScoreboardEntry struct {
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Team        string  `json:"team,omitempty"`
+	Objective   float64 `json:"objective"`
+	Score       float64 `json:"score"`
+	Health      float64 `json:"health"`
+	DamageDealt float64 `json:"damageDealt"`
+	Kills       int32   `json:"kills"`
+	Deaths      int32   `json:"deaths"`
+	Respawns    int32   `json:"respawns"`
+	Destroyed   bool    `json:"destroyed"`
+}
+
+// standing is what the spaceships and the teams are ranked by.
+type standing struct {
+	eliminated  bool
+	objective   float64
+	score       float64
+	health      float64
+	damageDealt float64
+}
+
+// compare returns a negative number when the standing ranks above the other one, a positive one
+// when below and 0 on a tie. The surviving rank first when the game mode eliminates the destroyed,
+// then the objective of the game mode and the tiebreakers decide in order: the score, the health
+// and the damage dealt.
+func (a standing) compare(b standing) float64 {
+	if a.eliminated != b.eliminated {
+		if a.eliminated {
+			return 1
+		}
+		return -1
+	}
+	if a.objective != b.objective {
+		return b.objective - a.objective
+	}
+	if a.score != b.score {
+		return b.score - a.score
+	}
+	if a.health != b.health {
+		return b.health - a.health
+	}
+	return b.damageDealt - a.damageDealt
+}
+
+func (entry *ScoreboardEntry) standing(mode GameMode) standing {
+	return standing{entry.Destroyed && mode.Eliminates(), entry.Objective, entry.Score, entry.Health, entry.DamageDealt}
+}
+
+// Scoreboard returns the spaceships ordered the same way as the client does,
+// surviving ships first, then by the objective and the tiebreakers, see standing.compare.
+func (game *Game) Scoreboard() []ScoreboardEntry {
+	entries := make([]ScoreboardEntry, 0)
+	for _, gameObject := range game.manager.GameObjects() {
+		spaceship, ok := gameObject.(*Spaceship)
+		if !ok {
+			continue
+		}
+		entries = append(entries, ScoreboardEntry{
+			ID:          spaceship.id,
+			Name:        spaceship.name,
+			Team:        spaceship.team,
+			Objective:   game.manager.mode.Objective(spaceship),
+			Score:       spaceship.score,
+			Health:      spaceship.health,
+			DamageDealt: spaceship.damageDealt,
+			Kills:       spaceship.kills,
+			Deaths:      spaceship.deaths,
+			Respawns:    spaceship.respawns,
+			Destroyed:   spaceship.health <= 0,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].standing(game.manager.mode).compare(entries[j].standing(game.manager.mode)) < 0
+	})
+
+	return entries
+}
+
+// TeamEntry sums the scores of the spaceships in a team, the team is destroyed
+// when all its spaceships are. The health is the average of the spaceships,
+// a bigger team does not win the tiebreaker by its size.
+type TeamEntry struct {
+	Name        string   `json:"name"`
+	Objective   float64  `json:"objective"`
+	Score       float64  `json:"score"`
+	Health      float64  `json:"health"`
+	DamageDealt float64  `json:"damageDealt"`
+	Kills       int32    `json:"kills"`
+	Deaths      int32    `json:"deaths"`
+	Respawns    int32    `json:"respawns"`
+	Destroyed   bool     `json:"destroyed"`
+	Spaceships  []string `json:"spaceships"`
+}
+
+func (entry *TeamEntry) standing(mode GameMode) standing {
+	return standing{entry.Destroyed && mode.Eliminates(), entry.Objective, entry.Score, entry.Health, entry.DamageDealt}
+}
+
+// Teams returns the teams ordered the same way as the Scoreboard,
+// the spaceships without a team are left out.
+func (game *Game) Teams() []TeamEntry {
+	entries := make([]TeamEntry, 0)
+	indexes := map[string]int{}
+	for _, gameObject := range game.manager.GameObjects() {
+		spaceship, ok := gameObject.(*Spaceship)
+		if !ok || spaceship.team == "" {
+			continue
+		}
+		index, ok := indexes[spaceship.team]
+		if !ok {
+			index = len(entries)
+			indexes[spaceship.team] = index
+			entries = append(entries, TeamEntry{Name: spaceship.team, Destroyed: true, Spaceships: []string{}})
+		}
+
+		entry := &entries[index]
+		entry.Objective += game.manager.mode.Objective(spaceship)
+		entry.Score += spaceship.score
+		entry.Health += spaceship.health
+		entry.DamageDealt += spaceship.damageDealt
+		entry.Kills += spaceship.kills
+		entry.Deaths += spaceship.deaths
+		entry.Respawns += spaceship.respawns
+		entry.Destroyed = entry.Destroyed && spaceship.health <= 0
+		entry.Spaceships = append(entry.Spaceships, spaceship.name)
+	}
+	for i := range entries {
+		entries[i].Health /= float64(len(entries[i].Spaceships))
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].standing(game.manager.mode).compare(entries[j].standing(game.manager.mode)) < 0
+	})
+
+	return entries
+}
+
+// Winner returns the name of the best ranked team, or spaceship playing on its own,
+// as if the match ended now. Empty on a draw, when the best two are tied.
+func (game *Game) Winner() string {
+	type side struct {
+		name     string
+		standing standing
+	}
+	sides := []side{}
+	for _, team := range game.Teams() {
+		sides = append(sides, side{team.Name, team.standing(game.manager.mode)})
+	}
+	for _, entry := range game.Scoreboard() {
+		if entry.Team == "" {
+			sides = append(sides, side{entry.Name, entry.standing(game.manager.mode)})
+		}
+	}
+
+	sort.SliceStable(sides, func(i, j int) bool {
+		return sides[i].standing.compare(sides[j].standing) < 0
+	})
+	if len(sides) == 0 || len(sides) > 1 && sides[0].standing.compare(sides[1].standing) == 0 {
+		return ""
+	}
+	return sides[0].name
+}
+
+func (entry *TeamEntry) Serialize() map[string]interface{} {
+	spaceships := make([]interface{}, len(entry.Spaceships))
+	for i, name := range entry.Spaceships {
+		spaceships[i] = name
+	}
+	return map[string]interface{}{
+		"name":        entry.Name,
+		"objective":   entry.Objective,
+		"score":       entry.Score,
+		"health":      entry.Health,
+		"damageDealt": entry.DamageDealt,
+		"kills":       entry.Kills,
+		"deaths":      entry.Deaths,
+		"respawns":    entry.Respawns,
+		"destroyed":   entry.Destroyed,
+		"spaceships":  spaceships,
+	}
+}
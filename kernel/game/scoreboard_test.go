@@ -0,0 +1,139 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame_Scoreboard(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.SeedAsteroids()
+	game.AddSpaceship("low", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.AddSpaceship("high", physics.Vector2{X: 200, Y: 200}, 0, "")
+	game.AddSpaceship("destroyed", physics.Vector2{X: 300, Y: 300}, 0, "")
+
+	high, _ := game.manager.GetSpaceship("high")
+	high.AddScore(50)
+	destroyed, _ := game.manager.GetSpaceship("destroyed")
+	destroyed.AddScore(500)
+	destroyed.health = 0
+
+	scoreboard := game.Scoreboard()
+
+	assert.Len(t, scoreboard, 3)
+	assert.Equal(t, "high", scoreboard[0].Name)
+	assert.Equal(t, 50.0, scoreboard[0].Score)
+	assert.Equal(t, "low", scoreboard[1].Name)
+	assert.Equal(t, "destroyed", scoreboard[2].Name)
+	assert.True(t, scoreboard[2].Destroyed)
+}
+
+func TestGame_Scoreboard_Tiebreakers(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	for _, name := range []string{"damaged", "dealer", "healthy", "scorer"} {
+		game.AddSpaceship(name, physics.Vector2{X: 100, Y: 100}, 0, "")
+		spaceship, _ := game.manager.GetSpaceship(name)
+		spaceship.score = 10
+		spaceship.health = 50
+	}
+	scorer, _ := game.manager.GetSpaceship("scorer")
+	scorer.score = 20
+	healthy, _ := game.manager.GetSpaceship("healthy")
+	healthy.health = 80
+	dealer, _ := game.manager.GetSpaceship("dealer")
+	dealer.damageDealt = 30
+
+	names := []string{}
+	for _, entry := range game.Scoreboard() {
+		names = append(names, entry.Name)
+	}
+
+	assert.Equal(t, []string{"scorer", "healthy", "dealer", "damaged"}, names)
+}
+
+func TestGame_Teams(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.AddSpaceship("red1", physics.Vector2{X: 100, Y: 100}, 0, "red")
+	game.AddSpaceship("blue1", physics.Vector2{X: 200, Y: 200}, 0, "blue")
+	game.AddSpaceship("loner", physics.Vector2{X: 300, Y: 300}, 0, "")
+	game.AddSpaceship("red2", physics.Vector2{X: 400, Y: 400}, 0, "red")
+	game.AddSpaceship("green1", physics.Vector2{X: 500, Y: 500}, 0, "green")
+
+	red1, _ := game.manager.GetSpaceship("red1")
+	red1.AddScore(20)
+	red1.kills = 1
+	red2, _ := game.manager.GetSpaceship("red2")
+	red2.AddScore(30)
+	red2.health = 0
+	blue1, _ := game.manager.GetSpaceship("blue1")
+	blue1.AddScore(40)
+	green1, _ := game.manager.GetSpaceship("green1")
+	green1.AddScore(500)
+	green1.health = 0
+
+	teams := game.Teams()
+
+	assert.Equal(t, []TeamEntry{
+		{Name: "red", Score: 50, Health: 50, Kills: 1, Destroyed: false, Spaceships: []string{"red1", "red2"}},
+		{Name: "blue", Score: 40, Health: 100, Kills: 0, Destroyed: false, Spaceships: []string{"blue1"}},
+		{Name: "green", Score: 500, Kills: 0, Destroyed: true, Spaceships: []string{"green1"}},
+	}, teams)
+	assert.Equal(t, "red", game.Scoreboard()[1].Team)
+}
+
+func TestGame_Teams_AverageHealth(t *testing.T) {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.AddSpaceship("red1", physics.Vector2{X: 100, Y: 100}, 0, "red")
+	game.AddSpaceship("red2", physics.Vector2{X: 200, Y: 200}, 0, "red")
+	game.AddSpaceship("blue1", physics.Vector2{X: 300, Y: 300}, 0, "blue")
+	red1, _ := game.manager.GetSpaceship("red1")
+	red1.health = 20
+	blue1, _ := game.manager.GetSpaceship("blue1")
+	blue1.health = 80
+
+	// The healthier ships win the tie against the bigger team
+	teams := game.Teams()
+	assert.Equal(t, "blue", teams[0].Name)
+	assert.Equal(t, 80.0, teams[0].Health)
+	assert.Equal(t, "red", teams[1].Name)
+	assert.Equal(t, 60.0, teams[1].Health)
+	assert.Equal(t, "blue", game.Winner())
+}
+
+func TestGame_Winner(t *testing.T) {
+	var tests = []struct {
+		name     string
+		teams    []string
+		scores   []float64
+		expected string
+	}{
+		{"ship", []string{"", "", ""}, []float64{10, 30, 20}, "ship1"},
+		{"team", []string{"red", "red", ""}, []float64{10, 15, 20}, "red"},
+		{"ship against a team", []string{"red", "red", ""}, []float64{10, 5, 20}, "ship2"},
+		{"draw", []string{"", "", ""}, []float64{30, 30, 20}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+			for i, team := range test.teams {
+				name := fmt.Sprintf("ship%d", i)
+				game.AddSpaceship(name, physics.Vector2{X: 100, Y: 100}, 0, team)
+				spaceship, _ := game.manager.GetSpaceship(name)
+				spaceship.score = test.scores[i]
+				// The teams sum up the health, the ships on their own are even with them
+				if team != "" {
+					spaceship.health = MaxHealth / 2
+				}
+			}
+
+			assert.Equal(t, test.expected, game.Winner())
+		})
+	}
+
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	assert.Equal(t, "", game.Winner())
+}
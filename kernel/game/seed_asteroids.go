@@ -1,24 +1,25 @@
 package game
 
 import (
+	"math"
 	"math/rand"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
 )
 
-func SeedAsteroids(random *rand.Rand, width, height float64, maxAttempts int) []GameObject {
+func SeedAsteroids(ids *IDGenerator, random *rand.Rand, rules *Rules, width, height float64, maxAttempts int) []GameObject {
 	asteroids := make([]GameObject, 0)
-	count := random.Intn(MaxAsteroids-MinAsteroids) + MinAsteroids
+	count := random.Intn(rules.MaxAsteroids-rules.MinAsteroids) + rules.MinAsteroids
 	for i := 0; i <= count && maxAttempts > 0; i++ {
 		maxAttempts--
-		radius := random.Float64()*(MaxAsteroidSize-MinAsteroidSize) + MinAsteroidSize
+		radius := random.Float64()*(rules.MaxAsteroidSize-rules.MinAsteroidSize) + rules.MinAsteroidSize
 		x := radius + (random.Float64() * (width - 2*radius))
 		y := radius + (random.Float64() * (height - 2*radius))
 
 		regenerate := false
 		for _, asteroid := range asteroids {
 			position := asteroid.Position()
-			if position.Distance(physics.Vector2{X: x, Y: y}) < radius+asteroid.(*Asteroid).radius+MinAsteroidSeparation {
+			if position.Distance(physics.Vector2{X: x, Y: y}) < radius+asteroid.(*Asteroid).radius+rules.MinAsteroidSeparation {
 				regenerate = true
 				break
 			}
@@ -29,8 +30,41 @@ func SeedAsteroids(random *rand.Rand, width, height float64, maxAttempts int) []
 			continue
 		}
 
-		asteroids = append(asteroids, NewAsteroid(NewUUID(), physics.Vector2{X: x, Y: y}, radius))
+		asteroids = append(asteroids, NewAsteroid(ids.Next(), physics.Vector2{X: x, Y: y}, radius))
+	}
+
+	// The motion is drawn after the positions, the layout of a seed does not depend on the motion rules
+	for _, asteroid := range asteroids {
+		direction := physics.Vector2{X: 1, Y: 0}
+		direction = direction.Rotate(random.Float64() * 2 * math.Pi)
+		velocity := direction.Multiply(random.Float64() * rules.MaxAsteroidVelocitySec)
+		asteroid.(*Asteroid).SetMotion(velocity, (random.Float64()*2-1)*rules.MaxAsteroidAngularVelocitySec)
+	}
+
+	// The shapes are drawn last too, the polygons fit into the circles of the layout
+	if rules.AsteroidShape == AsteroidShapePolygon {
+		for i, asteroid := range asteroids {
+			circle := asteroid.(*Asteroid)
+			polygon := NewPolygonAsteroid(circle.id, circle.position, asteroidVertices(random, rules, circle.radius))
+			polygon.SetMotion(circle.velocity, circle.angularVelocity)
+			asteroids[i] = polygon
+		}
 	}
 
 	return asteroids
 }
+
+// asteroidVertices draws the clockwise ordered vertices of an irregular rock around the origin,
+// each at a jittered angle and pulled in from the radius by up to the roughness, which makes it concave.
+func asteroidVertices(random *rand.Rand, rules *Rules, radius float64) []physics.Vector2 {
+	count := rules.MinAsteroidVertices + random.Intn(rules.MaxAsteroidVertices-rules.MinAsteroidVertices+1)
+	step := 2 * math.Pi / float64(count)
+	vertices := make([]physics.Vector2, count)
+	for i := range vertices {
+		// Within the middle half of its step, the vertices keep their order
+		angle := (float64(i) + (random.Float64()-0.5)/2) * step
+		distance := radius * (1 - rules.AsteroidRoughness*random.Float64())
+		vertices[i] = physics.Vector2{X: math.Cos(angle) * distance, Y: math.Sin(angle) * distance}
+	}
+	return vertices
+}
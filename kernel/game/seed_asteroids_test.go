@@ -1,9 +1,11 @@
 package game
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 
+	"github.com/davidhorak/space-wars/kernel/physics"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -11,7 +13,7 @@ func TestSeedAsteroids(t *testing.T) {
 	random := rand.New(rand.NewSource(1))
 	width, height := 1000.0, 1000.0
 
-	asteroids := SeedAsteroids(random, width, height, 1000)
+	asteroids := SeedAsteroids(NewIDGenerator(), random, DefaultRules(), width, height, 1000)
 
 	assert.GreaterOrEqual(t, len(asteroids), MinAsteroids)
 	assert.LessOrEqual(t, len(asteroids), MaxAsteroids)
@@ -52,8 +54,63 @@ func TestSeedAsteroids_Regenerate(t *testing.T) {
 	random := rand.New(rand.NewSource(1))
 	width, height := 100.0, 100.0
 
-	asteroids := SeedAsteroids(random, width, height, 100)
+	asteroids := SeedAsteroids(NewIDGenerator(), random, DefaultRules(), width, height, 100)
 
 	assert.GreaterOrEqual(t, len(asteroids), MinAsteroids)
 	assert.LessOrEqual(t, len(asteroids), MaxAsteroids)
 }
+
+func TestSeedAsteroids_Motion(t *testing.T) {
+	rules := DefaultRules()
+	static := SeedAsteroids(NewIDGenerator(), rand.New(rand.NewSource(1)), rules, 1000, 1000, 1000)
+	for _, asteroid := range static {
+		assert.Equal(t, physics.Vector2{}, asteroid.(*Asteroid).velocity)
+		assert.Equal(t, 0.0, asteroid.(*Asteroid).angularVelocity)
+	}
+
+	rules.MaxAsteroidVelocitySec = 20
+	rules.MaxAsteroidAngularVelocitySec = 1
+	moving := SeedAsteroids(NewIDGenerator(), rand.New(rand.NewSource(1)), rules, 1000, 1000, 1000)
+	again := SeedAsteroids(NewIDGenerator(), rand.New(rand.NewSource(1)), rules, 1000, 1000, 1000)
+	assert.Equal(t, again, moving)
+	assert.Len(t, moving, len(static))
+	for i, asteroid := range moving {
+		// The same layout as the static asteroids
+		assert.Equal(t, static[i].Position(), asteroid.Position())
+		velocity := asteroid.(*Asteroid).velocity
+		assert.LessOrEqual(t, velocity.Magnitude(), 20.0)
+		assert.LessOrEqual(t, math.Abs(asteroid.(*Asteroid).angularVelocity), 1.0)
+	}
+}
+
+func TestSeedAsteroids_Polygon(t *testing.T) {
+	rules := DefaultRules()
+	circles := SeedAsteroids(NewIDGenerator(), rand.New(rand.NewSource(1)), rules, 1000, 1000, 1000)
+
+	rules.AsteroidShape = AsteroidShapePolygon
+	polygons := SeedAsteroids(NewIDGenerator(), rand.New(rand.NewSource(1)), rules, 1000, 1000, 1000)
+	again := SeedAsteroids(NewIDGenerator(), rand.New(rand.NewSource(1)), rules, 1000, 1000, 1000)
+	assert.Equal(t, again, polygons)
+	assert.Len(t, polygons, len(circles))
+
+	for i, asteroid := range polygons {
+		// The same layout, the rocks fit into the circles
+		circle, polygon := circles[i].(*Asteroid), asteroid.(*Asteroid)
+		assert.Equal(t, circle.Position(), polygon.Position())
+		assert.LessOrEqual(t, polygon.radius, circle.radius)
+		assert.GreaterOrEqual(t, len(polygon.vertices), MinAsteroidVertices)
+		assert.LessOrEqual(t, len(polygon.vertices), MaxAsteroidVertices)
+
+		previous := -math.Pi
+		for _, vertex := range polygon.vertices {
+			assert.GreaterOrEqual(t, vertex.Magnitude(), circle.radius*(1-AsteroidRoughness))
+			// Clockwise on the screen, the y axis points down
+			angle := math.Atan2(vertex.Y, vertex.X)
+			if angle < previous {
+				angle += 2 * math.Pi
+			}
+			assert.Greater(t, angle, previous)
+			previous = angle
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+// SimulationTime is the time elapsed inside the simulation, independent of the wall clock,
+// so identical inputs always produce identical timestamps.
+type SimulationTime struct {
+	Tick      int64
+	ElapsedMs float64
+}
+
+// Advance returns the time after a tick of the given length.
+func (time SimulationTime) Advance(deltaTimeMs float64) SimulationTime {
+	return SimulationTime{
+		Tick:      time.Tick + 1,
+		ElapsedMs: time.ElapsedMs + deltaTimeMs,
+	}
+}
+
+// String formats the elapsed time as hh:mm:ss.mmm
+func (time SimulationTime) String() string {
+	ms := int64(math.Round(math.Max(time.ElapsedMs, 0)))
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
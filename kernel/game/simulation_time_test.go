@@ -0,0 +1,34 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulationTime_Advance(t *testing.T) {
+	time := SimulationTime{}
+
+	time = time.Advance(50)
+	time = time.Advance(25.5)
+
+	assert.Equal(t, SimulationTime{Tick: 2, ElapsedMs: 75.5}, time)
+}
+
+func TestSimulationTime_String(t *testing.T) {
+	var tests = []struct {
+		elapsedMs float64
+		expected  string
+	}{
+		{0, "00:00:00.000"},
+		{50, "00:00:00.050"},
+		{1250.4, "00:00:01.250"},
+		{61000, "00:01:01.000"},
+		{3723004, "01:02:03.004"},
+		{-10, "00:00:00.000"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, SimulationTime{ElapsedMs: test.elapsedMs}.String())
+	}
+}
@@ -3,40 +3,53 @@ package game
 import (
 	"errors"
 	"math"
-	"time"
 
 	"github.com/davidhorak/space-wars/kernel/physics"
 	"github.com/davidhorak/space-wars/kernel/physics/collider"
 )
 
 type Engine struct {
-	mainThrust  float64 // 0-100
-	leftThrust  float64 // 0-100
-	rightThrust float64 // 0-100
+	mainThrust     float64 // 0-100
+	leftThrust     float64 // 0-100
+	rightThrust    float64 // 0-100
+	rotationThrust float64 // -100-100, positive turns clockwise
 }
 
 type Spaceship struct {
-	id                   int64
-	name                 string
-	enabled              bool
-	rotation             float64
-	startRotation        float64
-	position             physics.Vector2
-	startPosition        physics.Vector2
-	gunPosition          physics.Vector2 // Relative to the ship's position, orientation to rad 0
-	velocity             physics.Vector2
-	health               float64 // 0-100
-	energy               float64 // 0-100
-	engine               Engine
-	rockets              int32
-	kills                int32
-	score                float64
-	collider             collider.CircleCollider
-	laserReloadTimerSec  float64
-	rocketReloadTimerSec float64
-}
-
-func NewSpaceship(id int64, name string, position physics.Vector2, rotation float64) *Spaceship {
+	id                     int64
+	name                   string
+	team                   string // Empty for the ships playing on their own
+	enabled                bool
+	rotation               float64
+	startRotation          float64
+	position               physics.Vector2
+	startPosition          physics.Vector2
+	gunPosition            physics.Vector2 // Relative to the ship's position, orientation to rad 0
+	velocity               physics.Vector2
+	angularVelocity        float64 // Radians per second
+	health                 float64 // 0-100
+	energy                 float64 // 0-100
+	engine                 Engine
+	rockets                int32
+	mines                  int32
+	kills                  int32
+	score                  float64
+	damageDealt            float64 // To the opponents, a tiebreaker
+	objective              float64 // Progress towards the objective of the game mode, see GameMode.Objective
+	respawnTimerSec        float64 // Until the destroyed ship respawns, 0 when it does not
+	invulnerableTimerSec   float64 // Until the respawned ship takes damage again
+	deaths                 int32
+	respawns               int32
+	collider               collider.CircleCollider
+	laserReloadTimerSec    float64
+	rocketReloadTimerSec   float64
+	shield                 bool    // Whether the shield is up, absorbing the damage
+	shieldCooldownTimerSec float64 // Until the shield could be raised again
+	damageBoostTimerSec    float64 // Until the damage boost power-up wears off
+	rules                  *Rules
+}
+
+func NewSpaceship(id int64, name string, position physics.Vector2, rotation float64, rules *Rules) *Spaceship {
 	ship := &Spaceship{
 		id:            id,
 		enabled:       true,
@@ -46,8 +59,9 @@ func NewSpaceship(id int64, name string, position physics.Vector2, rotation floa
 		rotation:      rotation,
 		startRotation: rotation,
 		// TODO: Create polygon collider
-		collider:    *collider.NewCircleCollider(position, ShipSize/2),
-		gunPosition: physics.Vector2{X: ShipSize / 2, Y: 0},
+		collider:    *collider.NewCircleCollider(position, rules.ShipSize/2),
+		gunPosition: physics.Vector2{X: rules.ShipSize / 2, Y: 0},
+		rules:       rules,
 	}
 	ship.Reset()
 	return ship
@@ -57,6 +71,15 @@ func (ship *Spaceship) ID() int64 {
 	return ship.id
 }
 
+func (ship *Spaceship) Team() string {
+	return ship.team
+}
+
+// IsTeammate checks if the other spaceship is in the same team, a ship is not its own teammate.
+func (ship *Spaceship) IsTeammate(other *Spaceship) bool {
+	return ship.team != "" && ship.team == other.team && ship.id != other.id
+}
+
 func (ship *Spaceship) Enabled() bool {
 	return ship.enabled
 }
@@ -66,25 +89,43 @@ func (ship *Spaceship) SetEnabled(enabled bool) {
 }
 
 func (ship *Spaceship) Reset() {
+	ship.Respawn(ship.startPosition)
+	ship.invulnerableTimerSec = 0
+	ship.deaths = 0
+	ship.respawns = 0
+	ship.kills = 0
+	ship.score = 0
+	ship.damageDealt = 0
+	ship.objective = 0
+}
+
+// Respawn brings the ship back at the position as good as new, it keeps its score and stats.
+func (ship *Spaceship) Respawn(position physics.Vector2) {
 	ship.enabled = true
-	ship.position = ship.startPosition
+	ship.position = position
 	ship.rotation = ship.startRotation
-	ship.health = MaxHealth
-	ship.energy = MaxEnergy
-	ship.rockets = MaxRockets
+	ship.health = ship.rules.MaxHealth
+	ship.energy = ship.rules.MaxEnergy
+	ship.rockets = int32(ship.rules.MaxRockets)
+	ship.mines = int32(ship.rules.MaxMines)
 	ship.engine = Engine{
-		mainThrust:  0,
-		leftThrust:  0,
-		rightThrust: 0,
+		mainThrust:     0,
+		leftThrust:     0,
+		rightThrust:    0,
+		rotationThrust: 0,
 	}
-	ship.kills = 0
-	ship.score = 0
 	ship.velocity = physics.Vector2{
 		X: 0,
 		Y: 0,
 	}
+	ship.angularVelocity = 0
 	ship.laserReloadTimerSec = 0
 	ship.rocketReloadTimerSec = 0
+	ship.shield = false
+	ship.shieldCooldownTimerSec = 0
+	ship.damageBoostTimerSec = 0
+	ship.respawnTimerSec = 0
+	ship.collider.SetPosition(ship.position)
 }
 
 func (ship *Spaceship) Position() physics.Vector2 {
@@ -93,6 +134,7 @@ func (ship *Spaceship) Position() physics.Vector2 {
 
 func (ship *Spaceship) SetPosition(position physics.Vector2) {
 	ship.position = position
+	ship.collider.SetPosition(position)
 }
 
 func (ship *Spaceship) SetStartPosition(position physics.Vector2) {
@@ -107,9 +149,14 @@ func (ship *Spaceship) Update(deltaTimeMs float64, gameManager *GameManager) {
 	deltaTimeSec := deltaTimeMs / 1000
 
 	ship.gunManagement(deltaTimeSec)
-	ship.energyManagement(deltaTimeSec)
+	ship.invulnerableTimerSec = math.Max(ship.invulnerableTimerSec-deltaTimeSec, 0)
+	ship.shieldCooldownTimerSec = math.Max(ship.shieldCooldownTimerSec-deltaTimeSec, 0)
+	ship.damageBoostTimerSec = math.Max(ship.damageBoostTimerSec-deltaTimeSec, 0)
+	ship.energyManagement(deltaTimeSec, gameManager.SuddenDeath() != SuddenDeathNoRecharge)
 	if ship.energy <= 0 {
 		ship.SetEngineThrust(0, 0, 0)
+		ship.SetRotationThrust(0)
+		ship.SetShield(false)
 		return
 	}
 	ship.move(deltaTimeSec)
@@ -136,8 +183,19 @@ func (ship *Spaceship) SetEngineThrust(main, left, right float64) error {
 	return nil
 }
 
+// SetRotationThrust sets the thrust of the rotation engine,
+// positive turns the ship clockwise, negative counterclockwise.
+func (ship *Spaceship) SetRotationThrust(thrust float64) error {
+	if thrust < -100 || thrust > 100 {
+		return errors.New("rotation thrust must be between -100 and 100")
+	}
+
+	ship.engine.rotationThrust = thrust
+	return nil
+}
+
 func (ship *Spaceship) FireLaser(gameManager *GameManager) error {
-	if ship.energy < EnergyConsumptionLaser {
+	if ship.energy < ship.rules.EnergyConsumptionLaser {
 		return errors.New("not enough energy")
 	}
 
@@ -145,11 +203,11 @@ func (ship *Spaceship) FireLaser(gameManager *GameManager) error {
 		return errors.New("laser is still cooling down")
 	}
 
-	ship.energy -= EnergyConsumptionLaser
-	ship.laserReloadTimerSec = LaserReloadSec
+	ship.energy -= ship.rules.EnergyConsumptionLaser
+	ship.laserReloadTimerSec = ship.rules.LaserReloadSec
 
 	gameManager.AddGameObject(NewLaserProjectile(
-		NewUUID(),
+		gameManager.NewID(),
 		ship.position.Add(ship.gunPosition.Rotate(ship.rotation)),
 		ship.rotation,
 		ship,
@@ -161,7 +219,7 @@ func (ship *Spaceship) FireRocket(gameManager *GameManager) error {
 	if ship.rockets == 0 {
 		return errors.New("not enough rockets")
 	}
-	if ship.energy < EnergyConsumptionRocket {
+	if ship.energy < ship.rules.EnergyConsumptionRocket {
 		return errors.New("not enough energy")
 	}
 	if ship.rocketReloadTimerSec > 0 {
@@ -169,47 +227,125 @@ func (ship *Spaceship) FireRocket(gameManager *GameManager) error {
 	}
 
 	ship.rockets--
-	ship.energy -= EnergyConsumptionRocket
-	ship.rocketReloadTimerSec = RocketReloadSec
-	gameManager.AddGameObject(NewRocketProjectile(
-		NewUUID(),
+	ship.energy -= ship.rules.EnergyConsumptionRocket
+	ship.rocketReloadTimerSec = ship.rules.RocketReloadSec
+	rocket := NewRocketProjectile(
+		gameManager.NewID(),
 		ship.position.Add(ship.gunPosition.Rotate(ship.rotation)),
 		ship.rotation,
 		ship,
-	))
+	)
+	if ship.rules.RocketHoming {
+		rocket.lockOn(gameManager)
+	}
+	gameManager.AddGameObject(rocket)
+	return nil
+}
+
+// DropMine leaves a mine at the position of the ship, see Mine.
+func (ship *Spaceship) DropMine(gameManager *GameManager) error {
+	if ship.mines == 0 {
+		return errors.New("not enough mines")
+	}
+
+	ship.mines--
+	gameManager.AddGameObject(NewMine(gameManager.NewID(), ship.position, ship))
+	return nil
+}
+
+// SetShield raises or lowers the shield. The shield drains the energy while up,
+// once lowered it cools down before it could be raised again.
+func (ship *Spaceship) SetShield(up bool) error {
+	if up == ship.shield {
+		return nil
+	}
+	if !up {
+		ship.shield = false
+		ship.shieldCooldownTimerSec = ship.rules.ShieldCooldownSec
+		return nil
+	}
+
+	if ship.shieldCooldownTimerSec > 0 {
+		return errors.New("shield is still cooling down")
+	}
+	if ship.energy <= 0 {
+		return errors.New("not enough energy")
+	}
+	ship.shield = true
 	return nil
 }
 
 func (ship *Spaceship) HasKilled(target *Spaceship) {
 	ship.kills++
-	ship.score += ScorePerKill
+	ship.score += ship.rules.ScorePerKill
 }
 
 func (ship *Spaceship) OnCollision(other GameObject, gameManager *GameManager, order int) {
 	switch other.(type) {
 	case *Asteroid:
-		ship.TakeDamage(MaxHealth, gameManager, nil)
-		gameManager.Logger().Collision(time.Now(), ship.name, "an asteroid")
+		ship.TakeDamage(ship.rules.MaxHealth, DamageTypeUnknown, gameManager, nil)
+		gameManager.Logger().Collision(gameManager.Time(), ship, other)
 	case *Spaceship:
-		ship.TakeDamage(MaxHealth, gameManager, nil)
+		ship.TakeDamage(ship.rules.MaxHealth*gameManager.DamageMultiplier(other.(*Spaceship), ship), DamageTypeUnknown, gameManager, nil)
 		if order == 0 {
-			gameManager.Logger().Collision(time.Now(), ship.name, other.(*Spaceship).name)
+			gameManager.Logger().Collision(gameManager.Time(), ship, other)
 		}
+	case *PowerUp:
+		other.(*PowerUp).collect(ship, gameManager)
 	default:
 		return
 	}
 }
 
-func (ship *Spaceship) TakeDamage(damage float64, gameManager *GameManager, damageDealer *Spaceship) {
+// DamageBoost returns the multiplier of the damage the ship deals, see PowerUpKindDamageBoost.
+func (ship *Spaceship) DamageBoost() float64 {
+	if ship.damageBoostTimerSec > 0 {
+		return ship.rules.DamageBoostMultiplier
+	}
+	return 1
+}
+
+// Invulnerable reports whether the ship has just respawned and takes no damage.
+func (ship *Spaceship) Invulnerable() bool {
+	return ship.invulnerableTimerSec > 0
+}
+
+// TakeDamage returns the damage the ship took, the shield absorbs a part of the damage of the weapons.
+// The damage dealt by another spaceship is logged, the absorbed part too.
+// shieldedDamageTypes are the damage types the shield absorbs, the lasers and the rockets
+// with the splash of their explosions. The mines and the collisions are not absorbed.
+var shieldedDamageTypes = map[DamageType]bool{
+	DamageTypeLaser:     true,
+	DamageTypeRocket:    true,
+	DamageTypeExplosion: true,
+}
+
+func (ship *Spaceship) TakeDamage(damage float64, damageType DamageType, gameManager *GameManager, damageDealer *Spaceship) float64 {
+	if ship.Invulnerable() {
+		return 0
+	}
+	absorbed := 0.0
+	if ship.shield && shieldedDamageTypes[damageType] {
+		absorbed = damage * ship.rules.ShieldAbsorption
+	}
+	damage -= absorbed
+	if damageDealer != nil {
+		gameManager.Logger().Damage(gameManager.Time(), damage, absorbed, damageDealer, ship, damageType)
+	}
+
 	ship.health -= damage
 	ship.health = math.Max(ship.health, 0)
 	if ship.health <= 0 {
 		ship.destroy(gameManager)
 		if damageDealer != nil {
-			gameManager.Logger().Kill(time.Now(), ship.name, damageDealer.name)
-			damageDealer.HasKilled(ship)
+			gameManager.Logger().Kill(gameManager.Time(), ship, damageDealer)
+			// Killing a teammate is not rewarded
+			if !damageDealer.IsTeammate(ship) {
+				gameManager.OnKill(damageDealer, ship)
+			}
 		}
 	}
+	return damage
 }
 
 func (ship *Spaceship) AddScore(score float64) {
@@ -223,6 +359,7 @@ func (ship *Spaceship) Serialize() map[string]interface{} {
 		"enabled":   ship.enabled,
 		"destroyed": ship.health <= 0,
 		"name":      ship.name,
+		"team":      ship.team,
 		"startPosition": map[string]interface{}{
 			"x": ship.startPosition.X,
 			"y": ship.startPosition.Y,
@@ -236,19 +373,31 @@ func (ship *Spaceship) Serialize() map[string]interface{} {
 			"x": ship.velocity.X,
 			"y": ship.velocity.Y,
 		},
-		"health": ship.health,
-		"energy": ship.energy,
+		"angularVelocity": ship.angularVelocity,
+		"health":          ship.health,
+		"energy":          ship.energy,
 		"engine": map[string]interface{}{
-			"mainThrust":  ship.engine.mainThrust,
-			"leftThrust":  ship.engine.leftThrust,
-			"rightThrust": ship.engine.rightThrust,
+			"mainThrust":     ship.engine.mainThrust,
+			"leftThrust":     ship.engine.leftThrust,
+			"rightThrust":    ship.engine.rightThrust,
+			"rotationThrust": ship.engine.rotationThrust,
 		},
-		"rockets":              ship.rockets,
-		"kills":                ship.kills,
-		"score":                ship.score,
-		"laserReloadTimerSec":  ship.laserReloadTimerSec,
-		"rocketReloadTimerSec": ship.rocketReloadTimerSec,
-		"collider":             ship.collider.Serialize(),
+		"rockets":                ship.rockets,
+		"mines":                  ship.mines,
+		"kills":                  ship.kills,
+		"score":                  ship.score,
+		"damageDealt":            ship.damageDealt,
+		"objective":              ship.objective,
+		"respawnTimerSec":        ship.respawnTimerSec,
+		"invulnerableTimerSec":   ship.invulnerableTimerSec,
+		"deaths":                 ship.deaths,
+		"respawns":               ship.respawns,
+		"laserReloadTimerSec":    ship.laserReloadTimerSec,
+		"rocketReloadTimerSec":   ship.rocketReloadTimerSec,
+		"shield":                 ship.shield,
+		"shieldCooldownTimerSec": ship.shieldCooldownTimerSec,
+		"damageBoostTimerSec":    ship.damageBoostTimerSec,
+		"collider":               ship.collider.Serialize(),
 		// TODO: Add collider, if polygon
 	}
 }
@@ -265,42 +414,49 @@ func (ship *Spaceship) gunManagement(deltaTimeSec float64) {
 	}
 }
 
-func (ship *Spaceship) energyManagement(deltaTimeSec float64) {
+func (ship *Spaceship) energyManagement(deltaTimeSec float64, recharge bool) {
 	// TODO: Investigate if this is needed
 	// if ship.engine.mainThrust == 0 && ship.engine.leftThrust == 0 && ship.engine.rightThrust == 0 {
-	ship.energy += deltaTimeSec * EnergyRechargeRateSec
-	ship.energy = math.Min(ship.energy, MaxEnergy)
+	if recharge {
+		ship.energy += deltaTimeSec * ship.rules.EnergyRechargeRateSec
+		ship.energy = math.Min(ship.energy, ship.rules.MaxEnergy)
+	}
 	// 	return
 	// }
 
-	ship.energy -= ship.engine.mainThrust / MaxThrust * deltaTimeSec * EnergyConsumptionMainThrustSec
-	ship.energy -= ship.engine.leftThrust / MaxThrust * deltaTimeSec * EnergyConsumptionSideThrustSec
-	ship.energy -= ship.engine.rightThrust / MaxThrust * deltaTimeSec * EnergyConsumptionSideThrustSec
+	ship.energy -= ship.engine.mainThrust / MaxThrust * deltaTimeSec * ship.rules.EnergyConsumptionMainThrustSec
+	ship.energy -= ship.engine.leftThrust / MaxThrust * deltaTimeSec * ship.rules.EnergyConsumptionSideThrustSec
+	ship.energy -= ship.engine.rightThrust / MaxThrust * deltaTimeSec * ship.rules.EnergyConsumptionSideThrustSec
+	ship.energy -= math.Abs(ship.engine.rotationThrust) / MaxThrust * deltaTimeSec * ship.rules.EnergyConsumptionRotationSec
+	if ship.shield {
+		ship.energy -= deltaTimeSec * ship.rules.EnergyConsumptionShieldSec
+	}
 	ship.energy = math.Max(ship.energy, 0)
 }
 
 func (ship *Spaceship) move(deltaTimeSec float64) {
+	ship.rotate(deltaTimeSec)
 	direction := physics.Vector2{X: 1, Y: 0}
 
 	mainThrust := direction.Rotate(ship.rotation)
-	mainThrust = mainThrust.Multiply(ship.engine.mainThrust / MaxThrust * deltaTimeSec * AccelerationCoefficient)
+	mainThrust = mainThrust.Multiply(ship.engine.mainThrust / MaxThrust * deltaTimeSec * ship.rules.AccelerationCoefficient)
 	leftThrust := direction.Rotate(ship.rotation + math.Pi/2)
-	leftThrust = leftThrust.Multiply(ship.engine.leftThrust / MaxThrust * SideThrustPowerCoefficient * deltaTimeSec * AccelerationCoefficient)
+	leftThrust = leftThrust.Multiply(ship.engine.leftThrust / MaxThrust * ship.rules.SideThrustPowerCoefficient * deltaTimeSec * ship.rules.AccelerationCoefficient)
 	rightThrust := direction.Rotate(ship.rotation - math.Pi/2)
-	rightThrust = rightThrust.Multiply(ship.engine.rightThrust / MaxThrust * SideThrustPowerCoefficient * deltaTimeSec * AccelerationCoefficient)
+	rightThrust = rightThrust.Multiply(ship.engine.rightThrust / MaxThrust * ship.rules.SideThrustPowerCoefficient * deltaTimeSec * ship.rules.AccelerationCoefficient)
 
 	drag := direction.Rotate(ship.rotation + math.Pi)
 	// TODO: investigate if this should be divided by deltaTimeSec
-	drag = drag.Multiply(ship.velocity.Magnitude() / MaxVelocitySec * deltaTimeSec * DragCoefficient)
+	drag = drag.Multiply(ship.velocity.Magnitude() / ship.rules.MaxVelocitySec * deltaTimeSec * ship.rules.DragCoefficient)
 
 	ship.velocity = ship.velocity.Add(mainThrust)
 	ship.velocity = ship.velocity.Add(leftThrust)
 	ship.velocity = ship.velocity.Add(rightThrust)
 	ship.velocity = ship.velocity.Add(drag)
-	ship.velocity = ship.velocity.Clamp(MaxVelocitySec / deltaTimeSec)
+	ship.velocity = ship.velocity.Clamp(ship.rules.MaxVelocitySec / deltaTimeSec)
 
 	ship.position = ship.position.Add(ship.velocity.Multiply(deltaTimeSec))
-	if ship.velocity.Magnitude() > 0 {
+	if ship.rules.HeadingFollowsVelocity && ship.velocity.Magnitude() > 0 {
 		ship.rotation = math.Atan2(ship.velocity.Y, ship.velocity.X)
 	}
 
@@ -308,16 +464,32 @@ func (ship *Spaceship) move(deltaTimeSec float64) {
 	// TODO: Apply rotation for the polygon collider
 }
 
+// rotate applies the rotation thrust and the angular drag, the ship keeps turning
+// after the rotation engine is turned off until the drag stops it.
+func (ship *Spaceship) rotate(deltaTimeSec float64) {
+	acceleration := ship.engine.rotationThrust / MaxThrust * deltaTimeSec * ship.rules.AngularAccelerationCoefficient
+	drag := ship.angularVelocity / ship.rules.MaxAngularVelocitySec * deltaTimeSec * ship.rules.AngularDragCoefficient
+	// The drag never reverses the rotation
+	if math.Abs(drag) > math.Abs(ship.angularVelocity) {
+		drag = ship.angularVelocity
+	}
+
+	ship.angularVelocity += acceleration - drag
+	ship.angularVelocity = math.Max(math.Min(ship.angularVelocity, ship.rules.MaxAngularVelocitySec), -ship.rules.MaxAngularVelocitySec)
+	ship.rotation = math.Remainder(ship.rotation+ship.angularVelocity*deltaTimeSec, 2*math.Pi)
+}
+
 func (ship *Spaceship) destroy(gameManager *GameManager) {
 	ship.enabled = false
+	ship.deaths++
 	gameManager.AddGameObject(NewExplosion(
-		NewUUID(),
+		gameManager.NewID(),
 		physics.Vector2{
-			X: ship.position.X - float64(ShipExplosionRadius),
-			Y: ship.position.Y - float64(ShipExplosionRadius),
+			X: ship.position.X - ship.rules.ShipExplosionRadius,
+			Y: ship.position.Y - ship.rules.ShipExplosionRadius,
 		},
-		float64(ShipExplosionRadius),
-		float64(ShipExplosionDurationSec),
+		ship.rules.ShipExplosionRadius,
+		ship.rules.ShipExplosionDurationSec,
 	))
-	gameManager.OnShipDestroyed()
+	gameManager.OnShipDestroyed(ship)
 }
@@ -11,7 +11,7 @@ import (
 )
 
 func TestSpaceship_NewSpaceship(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	assert.Equal(t, int64(0), ship.ID())
 	assert.Equal(t, true, ship.enabled)
@@ -28,30 +28,32 @@ func TestSpaceship_NewSpaceship(t *testing.T) {
 	assert.Equal(t, float64(100), ship.health)
 	assert.Equal(t, float64(100), ship.energy)
 	assert.Equal(t, int32(10), ship.rockets)
+	assert.Equal(t, int32(3), ship.mines)
 	assert.Equal(t, Engine{
 		mainThrust:  0,
 		leftThrust:  0,
 		rightThrust: 0,
 	}, ship.engine)
 	assert.Equal(t, physics.Vector2{X: 0, Y: 0}, ship.velocity)
+	assert.Equal(t, float64(0), ship.angularVelocity)
 	assert.Equal(t, float64(0), ship.laserReloadTimerSec)
 	assert.Equal(t, float64(0), ship.rocketReloadTimerSec)
 }
 
 func TestSpaceship_ID(t *testing.T) {
-	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	assert.Equal(t, int64(1), ship.ID())
 }
 
 func TestSpaceship_Enabled(t *testing.T) {
-	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	assert.Equal(t, true, ship.Enabled())
 }
 
 func TestSpaceship_SetEnabled(t *testing.T) {
-	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	ship.SetEnabled(false)
 
@@ -59,7 +61,7 @@ func TestSpaceship_SetEnabled(t *testing.T) {
 }
 
 func TestSpaceship_Reset(t *testing.T) {
-	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(1, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	ship.Reset()
 
@@ -82,13 +84,13 @@ func TestSpaceship_Reset(t *testing.T) {
 }
 
 func TestSpaceship_Position(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	assert.Equal(t, physics.Vector2{X: 0, Y: 0}, ship.Position())
 }
 
 func TestSpaceship_SetPosition(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	newPosition := physics.Vector2{X: 15, Y: 25}
 	ship.SetPosition(newPosition)
@@ -97,7 +99,7 @@ func TestSpaceship_SetPosition(t *testing.T) {
 }
 
 func TestSpaceship_SetStartPosition(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	newPosition := physics.Vector2{X: 15, Y: 25}
 	ship.SetStartPosition(newPosition)
@@ -106,7 +108,7 @@ func TestSpaceship_SetStartPosition(t *testing.T) {
 }
 
 func TestSpaceship_SetStartRotation(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	newRotation := math.Pi / 3
 	ship.SetStartRotation(newRotation)
@@ -116,7 +118,7 @@ func TestSpaceship_SetStartRotation(t *testing.T) {
 
 func TestSpaceship_Update(t *testing.T) {
 	gameManager := NewGameManager()
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 	ship.SetEngineThrust(100, 0, 0)
 	assert.Equal(t, 100.0, ship.engine.mainThrust)
 
@@ -131,7 +133,7 @@ func TestSpaceship_Update(t *testing.T) {
 }
 
 func TestSpaceship_SetEngineThrust(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 	ship.SetEngineThrust(100, 50, 33)
 
 	assert.Equal(t, 100.0, ship.engine.mainThrust)
@@ -158,9 +160,20 @@ func TestSpaceship_SetEngineThrust(t *testing.T) {
 	}
 }
 
+func TestSpaceship_SetRotationThrust(t *testing.T) {
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
+
+	assert.NoError(t, ship.SetRotationThrust(-40))
+	assert.Equal(t, -40.0, ship.engine.rotationThrust)
+
+	assert.EqualError(t, ship.SetRotationThrust(101), "rotation thrust must be between -100 and 100")
+	assert.EqualError(t, ship.SetRotationThrust(-101), "rotation thrust must be between -100 and 100")
+	assert.Equal(t, -40.0, ship.engine.rotationThrust)
+}
+
 func TestSpaceship_FireLaser(t *testing.T) {
 	gameManager := NewGameManager()
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	ship.energy = MaxEnergy
 	ship.FireLaser(&gameManager)
@@ -190,7 +203,7 @@ func TestSpaceship_FireLaser(t *testing.T) {
 
 func TestSpaceship_FireRocket(t *testing.T) {
 	gameManager := NewGameManager()
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	ship.energy = MaxEnergy
 	ship.FireRocket(&gameManager)
@@ -223,9 +236,26 @@ func TestSpaceship_FireRocket(t *testing.T) {
 	assert.Contains(t, "not enough rockets", err.Error())
 }
 
+func TestSpaceship_DropMine(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 10, Y: 20}, math.Pi/2, DefaultRules())
+
+	assert.NoError(t, ship.DropMine(&gameManager))
+	assert.Equal(t, int32(2), ship.mines)
+
+	mine := gameManager.gameObjects[0].(*Mine)
+	assert.Equal(t, physics.Vector2{X: 10, Y: 20}, mine.position)
+	assert.Equal(t, ship, mine.owner)
+
+	ship.mines = 0
+	err := ship.DropMine(&gameManager)
+	assert.EqualError(t, err, "not enough mines")
+	assert.Equal(t, 1, gameManager.GameObjectSize())
+}
+
 func TestSpaceship_HasKilled(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
-	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
+	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	assert.Equal(t, int32(0), ship.kills)
 	assert.Equal(t, float64(0), ship.score)
@@ -238,24 +268,82 @@ func TestSpaceship_HasKilled(t *testing.T) {
 
 func TestSpaceship_TakeDamage(t *testing.T) {
 	gameManager := NewGameManager()
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
-	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
+	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
-	ship.TakeDamage(10, &gameManager, other)
+	ship.TakeDamage(10, DamageTypeUnknown, &gameManager, other)
 
 	assert.Equal(t, float64(90), ship.health)
 
-	ship.TakeDamage(100, &gameManager, other)
+	ship.TakeDamage(100, DamageTypeUnknown, &gameManager, other)
 
 	assert.Equal(t, 0.0, ship.health)
 	assert.Equal(t, int32(1), other.kills)
 	assert.Equal(t, float64(100), other.score)
 }
 
+func TestSpaceship_TakeDamage_Shield(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
+	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
+	assert.NoError(t, ship.SetShield(true))
+
+	assert.Equal(t, 10*(1-ShieldAbsorption), ship.TakeDamage(10, DamageTypeLaser, &gameManager, other))
+	assert.Equal(t, 100-10*(1-ShieldAbsorption), ship.health)
+	log := gameManager.Logger().Logs()[0]
+	assert.Equal(t, "5.00", log.meta["damage"])
+	assert.Equal(t, "5.00", log.meta["absorbed"])
+
+	// The rockets and their explosions are absorbed
+	assert.Equal(t, 5.0, ship.TakeDamage(10, DamageTypeRocket, &gameManager, other))
+	assert.Equal(t, 5.0, ship.TakeDamage(10, DamageTypeExplosion, &gameManager, other))
+	assert.Equal(t, 85.0, ship.health)
+
+	// The collisions and the mines are not absorbed
+	assert.Equal(t, 20.0, ship.TakeDamage(20, DamageTypeUnknown, &gameManager, nil))
+	assert.Equal(t, 65.0, ship.health)
+	assert.Equal(t, 20.0, ship.TakeDamage(20, DamageTypeMine, &gameManager, other))
+	assert.Equal(t, 45.0, ship.health)
+	assert.NotContains(t, gameManager.Logger().Logs()[3].meta, "absorbed")
+}
+
+func TestSpaceship_SetShield(t *testing.T) {
+	gameManager := NewGameManager()
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0, DefaultRules())
+
+	assert.NoError(t, ship.SetShield(true))
+	assert.True(t, ship.shield)
+	assert.Equal(t, true, ship.Serialize()["shield"])
+
+	// Drains the energy faster than it recharges
+	ship.energy = 50
+	ship.Update(1000, &gameManager)
+	assert.Equal(t, float64(50+EnergyRechargeRateSec-EnergyConsumptionShieldSec), ship.energy)
+
+	assert.NoError(t, ship.SetShield(false))
+	assert.Equal(t, float64(ShieldCooldownSec), ship.shieldCooldownTimerSec)
+	assert.EqualError(t, ship.SetShield(true), "shield is still cooling down")
+	ship.Update(ShieldCooldownSec*1000, &gameManager)
+	assert.NoError(t, ship.SetShield(true))
+
+	// Goes down when the energy runs out
+	ship.energy = 1
+	ship.Update(1000, &gameManager)
+	assert.False(t, ship.shield)
+	assert.Equal(t, float64(ShieldCooldownSec), ship.shieldCooldownTimerSec)
+
+	ship.shieldCooldownTimerSec = 0
+	ship.energy = 0
+	assert.EqualError(t, ship.SetShield(true), "not enough energy")
+
+	ship.Reset()
+	assert.False(t, ship.shield)
+}
+
 func TestSpaceship_OnCollision(t *testing.T) {
 	gameManager := NewGameManager()
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
-	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
+	other := NewSpaceship(1, "other", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	ship.OnCollision(other, &gameManager, 0)
 	other.OnCollision(ship, &gameManager, 0)
@@ -264,7 +352,7 @@ func TestSpaceship_OnCollision(t *testing.T) {
 	assert.Equal(t, 0.0, other.health)
 
 	// Unexpected object
-	ship = NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship = NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 	explosion := NewExplosion(0, physics.Vector2{X: 0, Y: 0}, 10, 1)
 	ship.OnCollision(explosion, &gameManager, 0)
 
@@ -282,6 +370,7 @@ func TestSpaceship_Move_Basic(t *testing.T) {
 		expectedPosition physics.Vector2
 		expectedRotation float64
 	}{
+		// The heading follows the velocity by default
 		// 100 main thrust, no side thrust, no drag, 1 tick, 1 second
 		{100, 0, 0, 1, 1, physics.Vector2{X: 0, Y: 62.4}, physics.Vector2{X: 0, Y: 62.4}, math.Pi / 2},
 		// 100 left thrust, no main thrust, no drag, 1 tick, 1 second
@@ -295,7 +384,7 @@ func TestSpaceship_Move_Basic(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+		ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 		ship.SetEngineThrust(test.mainThrust, test.leftThrust, test.rightThrust)
 
 		for i := 0; i < test.ticks; i++ {
@@ -316,8 +405,26 @@ func TestSpaceship_Move_Basic(t *testing.T) {
 	}
 }
 
+func TestSpaceship_Move_KeepsHeading(t *testing.T) {
+	rules := DefaultRules()
+	rules.HeadingFollowsVelocity = false
+
+	// Without the rotation thrust the side thrusters strafe, the heading is kept
+	for _, thrust := range [][3]float64{{100, 0, 0}, {0, 100, 0}, {0, 0, 100}, {100, 100, 0}, {100, 0, 100}} {
+		ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, rules)
+		ship.SetEngineThrust(thrust[0], thrust[1], thrust[2])
+
+		for i := 0; i < 10; i++ {
+			ship.move(0.1)
+		}
+
+		assert.Greater(t, ship.velocity.Magnitude(), 0.0)
+		assert.Equal(t, math.Pi/2, ship.rotation, fmt.Sprintf("thrust: %v", thrust))
+	}
+}
+
 func TestSpaceship_Move_MaxVelocity(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 	ship.SetEngineThrust(100, 0, 0)
 
 	for i := 0; i < 5; i++ {
@@ -328,7 +435,7 @@ func TestSpaceship_Move_MaxVelocity(t *testing.T) {
 }
 
 func TestSpaceship_Move_Drag(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 	ship.SetEngineThrust(100, 0, 0)
 
 	for i := 0; i < 5; i++ {
@@ -347,54 +454,120 @@ func TestSpaceship_Move_Drag(t *testing.T) {
 	assert.LessOrEqual(t, ship.velocity.Magnitude(), threshold)
 }
 
+func TestSpaceship_Move_Rotation(t *testing.T) {
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0, DefaultRules())
+	ship.SetRotationThrust(100)
+
+	ship.move(0.05)
+	assert.Greater(t, ship.angularVelocity, 0.0)
+	assert.Greater(t, ship.rotation, 0.0)
+
+	// Close to the max angular velocity in 1 second
+	for i := 1; i < 20; i++ {
+		ship.move(0.05)
+	}
+	assert.InDelta(t, MaxAngularVelocitySec, ship.angularVelocity, MaxAngularVelocitySec*0.1)
+	assert.LessOrEqual(t, ship.angularVelocity, float64(MaxAngularVelocitySec))
+
+	// The rotation stays within -Pi and Pi
+	for i := 0; i < 100; i++ {
+		ship.move(0.05)
+		assert.LessOrEqual(t, math.Abs(ship.rotation), math.Pi)
+	}
+
+	// The drag stops the rotation, never reversing it
+	ship.SetRotationThrust(0)
+	for i := 0; i < 40; i++ {
+		ship.move(0.05)
+		assert.GreaterOrEqual(t, ship.angularVelocity, 0.0)
+	}
+	assert.Less(t, ship.angularVelocity, MaxAngularVelocitySec*0.01)
+	ship.move(1)
+	assert.Equal(t, 0.0, ship.angularVelocity)
+
+	// Counterclockwise
+	ship.SetRotationThrust(-100)
+	ship.move(0.05)
+	assert.Less(t, ship.angularVelocity, 0.0)
+}
+
+func TestSpaceship_Move_RotationDirectsThrust(t *testing.T) {
+	rules := DefaultRules()
+	rules.HeadingFollowsVelocity = false
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, 0, rules)
+	ship.SetEngineThrust(100, 0, 0)
+	ship.move(1)
+	assert.InDelta(t, 0, ship.velocity.Y, 0.0001)
+
+	// Turn around while moving, the main thruster slows the ship down
+	ship.rotation = math.Pi
+	speed := ship.velocity.X
+	ship.move(0.1)
+
+	assert.Equal(t, math.Pi, ship.rotation)
+	assert.Less(t, ship.velocity.X, speed)
+}
+
 func TestSpaceship_EnergyManagement_Recharge(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 	ship.energy = 0
 
 	// +1 to make sure we go over the max
 	for i := 0; i < int(MaxEnergy/EnergyRechargeRateSec)+1; i++ {
-		ship.energyManagement(1)
+		ship.energyManagement(1, true)
 	}
 
 	assert.Equal(t, float64(MaxEnergy), ship.energy)
+
+	// The sudden death without the recharge
+	ship.energy = 0
+	ship.energyManagement(1, false)
+	assert.Equal(t, 0.0, ship.energy)
 }
 
 func TestSpaceship_EnergyManagement_Thrust(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 
 	ship.energy = MaxEnergy
 	ship.SetEngineThrust(100, 0, 0)
-	ship.energyManagement(1)
+	ship.energyManagement(1, true)
 
 	assert.Equal(t, float64(MaxEnergy-EnergyConsumptionMainThrustSec), ship.energy)
 
 	ship.energy = MaxEnergy
 	ship.SetEngineThrust(0, 100, 0)
-	ship.energyManagement(1)
+	ship.energyManagement(1, true)
 
 	assert.Equal(t, float64(MaxEnergy-EnergyConsumptionSideThrustSec), ship.energy)
 
 	ship.energy = MaxEnergy
 	ship.SetEngineThrust(0, 0, 100)
-	ship.energyManagement(1)
+	ship.energyManagement(1, true)
 	assert.Equal(t, float64(MaxEnergy-EnergyConsumptionSideThrustSec), ship.energy)
 
+	ship.energy = MaxEnergy
+	ship.SetEngineThrust(0, 0, 0)
+	ship.SetRotationThrust(-100)
+	ship.energyManagement(1, true)
+	assert.Equal(t, float64(MaxEnergy-EnergyConsumptionRotationSec), ship.energy)
+	ship.SetRotationThrust(0)
+
 	ship.energy = MaxEnergy
 	ship.SetEngineThrust(50, 50, 50)
-	ship.energyManagement(1)
+	ship.energyManagement(1, true)
 	expected := MaxEnergy - 0.5*(EnergyConsumptionMainThrustSec+EnergyConsumptionSideThrustSec+EnergyConsumptionSideThrustSec)
 
 	assert.Equal(t, expected, ship.energy)
 
 	ship.energy = MaxEnergy
 	ship.SetEngineThrust(100, 0, 0)
-	ship.energyManagement(105)
+	ship.energyManagement(105, true)
 
 	assert.Equal(t, 0.0, ship.energy)
 }
 
 func TestSpaceship_GunManagement(t *testing.T) {
-	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2)
+	ship := NewSpaceship(0, "ship", physics.Vector2{X: 0, Y: 0}, math.Pi/2, DefaultRules())
 	ship.laserReloadTimerSec = LaserReloadSec
 	ship.rocketReloadTimerSec = RocketReloadSec
 
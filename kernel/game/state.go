@@ -0,0 +1,353 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// The serialized state, see Game.Serialize. The states of the older schema versions
+// are migrated first, see MigrateState.
+
+type VectorState struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type SizeState struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+type State struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	Status        Status        `json:"status"`
+	EndReason     EndReason     `json:"endReason"`
+	Seed          int64         `json:"seed"`
+	Tick          int64         `json:"tick"`
+	ElapsedMs     float64       `json:"elapsedMs"`
+	Size          SizeState     `json:"size"`
+	Rules         *Rules        `json:"rules"`
+	GameObjects   []interface{} `json:"gameObjects"`
+	Teams         []TeamState   `json:"teams"`
+	Logs          []interface{} `json:"logs"`
+}
+
+// TeamState is derived from the spaceships, it is not restored.
+type TeamState struct {
+	Name        string   `json:"name"`
+	Objective   float64  `json:"objective"`
+	Score       float64  `json:"score"`
+	Health      float64  `json:"health"`
+	DamageDealt float64  `json:"damageDealt"`
+	Kills       int32    `json:"kills"`
+	Deaths      int32    `json:"deaths"`
+	Respawns    int32    `json:"respawns"`
+	Destroyed   bool     `json:"destroyed"`
+	Spaceships  []string `json:"spaceships"`
+}
+
+// GameObjectState holds the fields common to all the game objects,
+// the type selects the state of the object. The explosions have no collider.
+type GameObjectState struct {
+	Type     string                 `json:"type"`
+	ID       int64                  `json:"id"`
+	Enabled  bool                   `json:"enabled"`
+	Position VectorState            `json:"position"`
+	Collider map[string]interface{} `json:"collider,omitempty"`
+}
+
+type AsteroidState struct {
+	GameObjectState
+	StartPosition   VectorState `json:"startPosition"`
+	Radius          float64     `json:"radius"`
+	Rotation        float64     `json:"rotation"`
+	Velocity        VectorState `json:"velocity"`
+	StartVelocity   VectorState `json:"startVelocity"`
+	AngularVelocity float64     `json:"angularVelocity"`
+	Damage          float64     `json:"damage"`
+	Fragment        bool        `json:"fragment"`
+	// Empty for the circles
+	Vertices []VectorState `json:"vertices"`
+}
+
+type ProjectileState struct {
+	GameObjectState
+	Rotation    float64     `json:"rotation"`
+	Velocity    VectorState `json:"velocity"`
+	LifespanSec float64     `json:"lifespanSec"`
+	Damage      float64     `json:"damage"`
+	Owner       int64       `json:"owner"`
+	// 0 when the projectile does not home in
+	Target int64 `json:"target"`
+}
+
+type MineState struct {
+	GameObjectState
+	ArmingTimerSec float64 `json:"armingTimerSec"`
+	LifespanSec    float64 `json:"lifespanSec"`
+	Owner          int64   `json:"owner"`
+}
+
+type PowerUpState struct {
+	GameObjectState
+	Kind        PowerUpKind `json:"kind"`
+	LifespanSec float64     `json:"lifespanSec"`
+}
+
+type EngineState struct {
+	MainThrust     float64 `json:"mainThrust"`
+	LeftThrust     float64 `json:"leftThrust"`
+	RightThrust    float64 `json:"rightThrust"`
+	RotationThrust float64 `json:"rotationThrust"`
+}
+
+type SpaceshipState struct {
+	GameObjectState
+	Destroyed              bool        `json:"destroyed"`
+	Name                   string      `json:"name"`
+	Team                   string      `json:"team"`
+	StartPosition          VectorState `json:"startPosition"`
+	Rotation               float64     `json:"rotation"`
+	Velocity               VectorState `json:"velocity"`
+	AngularVelocity        float64     `json:"angularVelocity"`
+	Health                 float64     `json:"health"`
+	Energy                 float64     `json:"energy"`
+	Engine                 EngineState `json:"engine"`
+	Rockets                int32       `json:"rockets"`
+	Mines                  int32       `json:"mines"`
+	Kills                  int32       `json:"kills"`
+	Score                  float64     `json:"score"`
+	DamageDealt            float64     `json:"damageDealt"`
+	Objective              float64     `json:"objective"`
+	RespawnTimerSec        float64     `json:"respawnTimerSec"`
+	InvulnerableTimerSec   float64     `json:"invulnerableTimerSec"`
+	Deaths                 int32       `json:"deaths"`
+	Respawns               int32       `json:"respawns"`
+	LaserReloadTimerSec    float64     `json:"laserReloadTimerSec"`
+	RocketReloadTimerSec   float64     `json:"rocketReloadTimerSec"`
+	Shield                 bool        `json:"shield"`
+	ShieldCooldownTimerSec float64     `json:"shieldCooldownTimerSec"`
+	DamageBoostTimerSec    float64     `json:"damageBoostTimerSec"`
+}
+
+type ExplosionState struct {
+	GameObjectState
+	Radius      float64 `json:"radius"`
+	DurationSec float64 `json:"durationSec"`
+	LifespanSec float64 `json:"lifespanSec"`
+}
+
+type HillState struct {
+	GameObjectState
+	Radius     float64 `json:"radius"`
+	Controller string  `json:"controller"`
+}
+
+type FlagState struct {
+	GameObjectState
+	Side    string      `json:"side"`
+	Base    VectorState `json:"base"`
+	Carrier int64       `json:"carrier"`
+}
+
+type LogState struct {
+	ID        int64                  `json:"id"`
+	LogType   LogType                `json:"logType"`
+	Tick      int64                  `json:"tick"`
+	ElapsedMs float64                `json:"elapsedMs"`
+	Time      string                 `json:"time"`
+	Message   string                 `json:"message"`
+	Meta      map[string]interface{} `json:"meta"`
+}
+
+type DecodeMode int
+
+const (
+	// Strict fails on the first problem, including the unknown fields and game object types.
+	Strict DecodeMode = iota
+	// Lenient ignores the unknown fields and skips the game objects and the logs
+	// it cannot restore, the problems are reported as warnings.
+	Lenient
+)
+
+// StateError is a problem with the serialized state, the path names
+// the offending JSON value, e.g. "gameObjects[3].position.x".
+type StateError struct {
+	Path    string
+	Message string
+}
+
+func (err *StateError) Error() string {
+	if err.Path == "" {
+		return err.Message
+	}
+	return fmt.Sprintf("%s: %s", err.Path, err.Message)
+}
+
+// stateDecoder fills the state structs from the generic JSON values,
+// the JSON tags name the fields, omitempty marks the optional ones.
+type stateDecoder struct {
+	mode     DecodeMode
+	warnings []*StateError
+}
+
+// warn reports a problem the lenient mode can recover from, the strict mode fails.
+func (decoder *stateDecoder) warn(err *StateError) error {
+	if decoder.mode == Strict {
+		return err
+	}
+	decoder.warnings = append(decoder.warnings, err)
+	return nil
+}
+
+// decode fills the target, a pointer to a state struct, from the JSON value.
+// The fields already set on the target are kept when they are missing in the value.
+func (decoder *stateDecoder) decode(value interface{}, target interface{}, path string) error {
+	return decoder.decodeValue(value, reflect.ValueOf(target).Elem(), path)
+}
+
+func (decoder *stateDecoder) decodeValue(value interface{}, target reflect.Value, path string) error {
+	switch target.Kind() {
+	case reflect.Ptr:
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return decoder.decodeValue(value, target.Elem(), path)
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return typeError(path, "an object", value)
+		}
+		known := map[string]bool{}
+		if err := decoder.decodeFields(object, target, path, known); err != nil {
+			return err
+		}
+		return decoder.checkUnknown(object, path, known)
+	case reflect.Slice:
+		array, ok := value.([]interface{})
+		if !ok {
+			return typeError(path, "an array", value)
+		}
+		if target.Type().Elem().Kind() == reflect.Interface {
+			target.Set(reflect.ValueOf(array))
+			return nil
+		}
+		slice := reflect.MakeSlice(target.Type(), len(array), len(array))
+		for i, item := range array {
+			if err := decoder.decodeValue(item, slice.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return typeError(path, "an object", value)
+		}
+		target.Set(reflect.ValueOf(object))
+	case reflect.Float64:
+		number, ok := value.(float64)
+		if !ok {
+			return typeError(path, "a number", value)
+		}
+		target.SetFloat(number)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return typeError(path, "an integer", value)
+		}
+		if target.OverflowInt(int64(number)) {
+			return &StateError{Path: path, Message: fmt.Sprintf("%v is out of range", number)}
+		}
+		target.SetInt(int64(number))
+	case reflect.String:
+		text, ok := value.(string)
+		if !ok {
+			return typeError(path, "a string", value)
+		}
+		target.SetString(text)
+	case reflect.Bool:
+		boolean, ok := value.(bool)
+		if !ok {
+			return typeError(path, "a boolean", value)
+		}
+		target.SetBool(boolean)
+	default:
+		panic(fmt.Sprintf("unsupported state field kind: %s", target.Kind()))
+	}
+	return nil
+}
+
+// decodeFields decodes the fields of the struct, including the embedded ones,
+// and marks their names as known.
+func (decoder *stateDecoder) decodeFields(object map[string]interface{}, target reflect.Value, path string, known map[string]bool) error {
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		if field.Anonymous {
+			if err := decoder.decodeFields(object, target.Field(i), path, known); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		known[name] = true
+		value, ok := object[name]
+		if !ok {
+			if options == "omitempty" || target.Type() == reflect.TypeOf(Rules{}) {
+				// The rules omitted in a state keep their defaults
+				continue
+			}
+			return &StateError{Path: joinPath(path, name), Message: "missing"}
+		}
+		if err := decoder.decodeValue(value, target.Field(i), joinPath(path, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (decoder *stateDecoder) checkUnknown(object map[string]interface{}, path string, known map[string]bool) error {
+	// Sorted, the map order would make the first error random
+	names := make([]string, 0, len(object))
+	for name := range object {
+		if !known[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := decoder.warn(&StateError{Path: joinPath(path, name), Message: "unknown field"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func typeError(path string, expected string, value interface{}) *StateError {
+	var actual string
+	switch value := value.(type) {
+	case nil:
+		actual = "null"
+	case map[string]interface{}:
+		actual = "an object"
+	case []interface{}:
+		actual = "an array"
+	case string:
+		actual = fmt.Sprintf("%q", value)
+	default:
+		actual = fmt.Sprintf("%v", value)
+	}
+	return &StateError{Path: path, Message: fmt.Sprintf("expected %s, got %s", expected, actual)}
+}
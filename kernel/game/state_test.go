@@ -0,0 +1,176 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestState(t *testing.T) map[string]interface{} {
+	game := NewGame(physics.Size{Width: 1024, Height: 768}, 1234567890, DefaultRules())
+	game.AddSpaceship("ship", physics.Vector2{X: 100, Y: 100}, 0, "")
+	game.manager.AddGameObject(NewExplosion(game.manager.NewID(), physics.Vector2{X: 500, Y: 500}, 10, 1))
+	game.Start()
+	game.spaceshipAction("ship", func(spaceShip *Spaceship, gameManager *GameManager) {
+		spaceShip.FireLaser(gameManager)
+		spaceShip.FireRocket(gameManager)
+		spaceShip.DropMine(gameManager)
+	})
+	game.manager.time = SimulationTime{Tick: 1, ElapsedMs: 50}
+
+	// Through JSON, as the state is passed around
+	state := map[string]interface{}{}
+	serialized, err := json.Marshal(game.Serialize())
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(serialized, &state))
+	return state
+}
+
+func deserializeTestState(t *testing.T, state map[string]interface{}, mode DecodeMode) (*Game, []*StateError, error) {
+	serialized, err := json.Marshal(state)
+	assert.NoError(t, err)
+	return DeserializeState(string(serialized), mode)
+}
+
+func gameObjectState(state map[string]interface{}, objectType string) map[string]interface{} {
+	for _, gameObject := range state["gameObjects"].([]interface{}) {
+		if gameObject.(map[string]interface{})["type"] == objectType {
+			return gameObject.(map[string]interface{})
+		}
+	}
+	return nil
+}
+
+func TestDeserializeState_Strict(t *testing.T) {
+	state := newTestState(t)
+
+	game, warnings, err := deserializeTestState(t, state, Strict)
+
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, state, toJSONValue(t, game.Serialize()))
+}
+
+func TestDeserializeState_Errors(t *testing.T) {
+	var tests = []struct {
+		name        string
+		modify      func(state map[string]interface{})
+		expectedErr string
+	}{
+		{"missing field", func(state map[string]interface{}) { delete(state, "seed") }, "seed: missing"},
+		{"mistyped field", func(state map[string]interface{}) { state["size"] = "big" }, `size: expected an object, got "big"`},
+		{"unknown status", func(state map[string]interface{}) { state["status"] = "over" }, `status: unknown status "over"`},
+		{"invalid rules", func(state map[string]interface{}) { state["rules"].(map[string]interface{})["maxHealth"] = 0 }, "rules: maxHealth must be greater than 0"},
+		{"fractional integer", func(state map[string]interface{}) { state["tick"] = 1.5 }, "tick: expected an integer, got 1.5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, mode := range []DecodeMode{Strict, Lenient} {
+				state := newTestState(t)
+				test.modify(state)
+
+				_, _, err := deserializeTestState(t, state, mode)
+
+				assert.EqualError(t, err, test.expectedErr)
+				assert.IsType(t, &StateError{}, err)
+			}
+		})
+	}
+
+	// The lenient mode skips the game object instead
+	state := newTestState(t)
+	gameObjectState(state, "spaceship")["engine"].(map[string]interface{})["mainThrust"] = nil
+	_, _, err := deserializeTestState(t, state, Strict)
+	assert.EqualError(t, err, "gameObjects[0].engine.mainThrust: expected a number, got null")
+}
+
+func TestDeserializeState_Lenient(t *testing.T) {
+	var tests = []struct {
+		name            string
+		modify          func(state map[string]interface{})
+		expectedWarning string
+		expectedSkipped int
+		expectedLogSkip int
+	}{
+		{"unknown field", func(state map[string]interface{}) { state["extra"] = true }, "extra: unknown field", 0, 0},
+		{"unknown game object", func(state map[string]interface{}) {
+			state["gameObjects"] = append(state["gameObjects"].([]interface{}), map[string]interface{}{"type": "comet"})
+		}, `gameObjects[5].type: unknown game object type "comet"`, 0, 0},
+		{"projectile without owner", func(state map[string]interface{}) {
+			gameObjectState(state, "laser")["owner"] = 999
+		}, "gameObjects[2].owner: spaceship 999 not found", 1, 0},
+		{"invalid game object", func(state map[string]interface{}) {
+			delete(gameObjectState(state, "explosion"), "radius")
+		}, "gameObjects[1].radius: missing", 1, 0},
+		{"log without simulation time", func(state map[string]interface{}) {
+			delete(state["logs"].([]interface{})[0].(map[string]interface{}), "tick")
+		}, "logs[0].tick: missing", 0, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := newTestState(t)
+			gameObjects := len(state["gameObjects"].([]interface{}))
+			logs := len(state["logs"].([]interface{}))
+			test.modify(state)
+
+			_, _, err := deserializeTestState(t, state, Strict)
+			assert.EqualError(t, err, test.expectedWarning)
+
+			game, warnings, err := deserializeTestState(t, state, Lenient)
+			assert.NoError(t, err)
+			assert.Len(t, warnings, 1)
+			assert.EqualError(t, warnings[0], test.expectedWarning)
+			assert.Equal(t, gameObjects-test.expectedSkipped, len(game.manager.GameObjects()))
+			assert.Equal(t, logs-test.expectedLogSkip, len(game.manager.Logger().Logs()))
+		})
+	}
+}
+
+func TestDeserializeState_Unversioned(t *testing.T) {
+	state := newTestState(t)
+	delete(state, "schemaVersion")
+	delete(state, "tick")
+	delete(state, "elapsedMs")
+	delete(state, "rules")
+	spaceship := gameObjectState(state, "spaceship")
+	delete(spaceship, "angularVelocity")
+	delete(spaceship["engine"].(map[string]interface{}), "rotationThrust")
+	log := state["logs"].([]interface{})[0].(map[string]interface{})
+	delete(log, "tick")
+	delete(log, "elapsedMs")
+
+	game, warnings, err := deserializeTestState(t, state, Strict)
+
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, SimulationTime{}, game.Time())
+	assert.Equal(t, DefaultRules(), game.Rules())
+	assert.Equal(t, SimulationTime{}, game.manager.Logger().Logs()[0].time)
+
+	// The fields are required since the state is versioned
+	state = newTestState(t)
+	delete(gameObjectState(state, "spaceship"), "angularVelocity")
+	_, _, err = deserializeTestState(t, state, Strict)
+	assert.EqualError(t, err, "gameObjects[0].angularVelocity: missing")
+}
+
+func TestDeserializeState_DuplicateIDs(t *testing.T) {
+	state := newTestState(t)
+	gameObjectState(state, "explosion")["id"] = gameObjectState(state, "spaceship")["id"]
+
+	_, _, err := deserializeTestState(t, state, Strict)
+
+	assert.EqualError(t, err, "gameObjects[1].id: duplicate id 1")
+}
+
+func toJSONValue(t *testing.T, value interface{}) interface{} {
+	serialized, err := json.Marshal(value)
+	assert.NoError(t, err)
+	var decoded interface{}
+	assert.NoError(t, json.Unmarshal(serialized, &decoded))
+	return decoded
+}
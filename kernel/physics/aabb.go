@@ -0,0 +1,29 @@
+package physics
+
+// AABB is an axis-aligned bounding box.
+type AABB struct {
+	Min Vector2
+	Max Vector2
+}
+
+// NewAABB returns the bounding box of a circle, or a square when the radius is half its side.
+func NewAABB(center Vector2, radius float64) AABB {
+	return AABB{
+		Min: Vector2{X: center.X - radius, Y: center.Y - radius},
+		Max: Vector2{X: center.X + radius, Y: center.Y + radius},
+	}
+}
+
+func (aabb AABB) Center() Vector2 {
+	return Vector2{X: (aabb.Min.X + aabb.Max.X) / 2, Y: (aabb.Min.Y + aabb.Max.Y) / 2}
+}
+
+func (aabb AABB) Translate(offset Vector2) AABB {
+	return AABB{Min: aabb.Min.Add(offset), Max: aabb.Max.Add(offset)}
+}
+
+// Overlaps checks if two bounding boxes overlap, touching boxes overlap.
+func (aabb AABB) Overlaps(other AABB) bool {
+	return aabb.Min.X <= other.Max.X && aabb.Max.X >= other.Min.X &&
+		aabb.Min.Y <= other.Max.Y && aabb.Max.Y >= other.Min.Y
+}
@@ -0,0 +1,31 @@
+package physics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAABB(t *testing.T) {
+	aabb := NewAABB(Vector2{X: 10, Y: 20}, 5)
+	assert.Equal(t, AABB{Min: Vector2{X: 5, Y: 15}, Max: Vector2{X: 15, Y: 25}}, aabb)
+}
+
+func TestAABB_Overlaps(t *testing.T) {
+	aabb := AABB{Min: Vector2{X: 0, Y: 0}, Max: Vector2{X: 10, Y: 10}}
+	tests := []struct {
+		other    AABB
+		expected bool
+	}{
+		{AABB{Min: Vector2{X: 5, Y: 5}, Max: Vector2{X: 15, Y: 15}}, true},
+		{AABB{Min: Vector2{X: 2, Y: 2}, Max: Vector2{X: 3, Y: 3}}, true},
+		{AABB{Min: Vector2{X: 10, Y: 10}, Max: Vector2{X: 20, Y: 20}}, true},
+		{AABB{Min: Vector2{X: 11, Y: 0}, Max: Vector2{X: 20, Y: 10}}, false},
+		{AABB{Min: Vector2{X: 0, Y: -10}, Max: Vector2{X: 10, Y: -1}}, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, aabb.Overlaps(test.other), "%v", test.other)
+		assert.Equal(t, test.expected, test.other.Overlaps(aabb), "%v", test.other)
+	}
+}
@@ -59,6 +59,10 @@ func (circle *CircleCollider) CollidesWith(other Collider) bool {
 	}
 }
 
+func (circle *CircleCollider) Bounds() physics.AABB {
+	return physics.NewAABB(circle.position, circle.radius)
+}
+
 func (circle *CircleCollider) Serialize() map[string]interface{} {
 	return map[string]interface{}{
 		"type":    "circle",
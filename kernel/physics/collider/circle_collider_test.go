@@ -24,6 +24,12 @@ func TestCircleCollider_Position(t *testing.T) {
 	assert.Equal(t, expected, circle_collider.Position())
 }
 
+func TestCircleCollider_Bounds(t *testing.T) {
+	circle_collider := NewCircleCollider(physics.Vector2{X: 10, Y: 20}, 5)
+	expected := physics.AABB{Min: physics.Vector2{X: 5, Y: 15}, Max: physics.Vector2{X: 15, Y: 25}}
+	assert.Equal(t, expected, circle_collider.Bounds())
+}
+
 func TestCircleCollider_SetRotation(t *testing.T) {
 	circle_collider := NewCircleCollider(physics.Vector2{X: 0, Y: 0}, 1)
 	circle_collider.SetPosition(physics.Vector2{X: 1, Y: 1})
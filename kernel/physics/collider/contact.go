@@ -0,0 +1,160 @@
+package collider
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// Contact describes how two overlapping colliders touch, for the collision response.
+type Contact struct {
+	// Unit direction from the first collider to the second one, pushing the second one
+	// along it separates them
+	Normal physics.Vector2
+	// How deep the colliders overlap along the normal
+	Penetration float64
+}
+
+// Collide returns the contact of the colliders, false when they do not overlap.
+// The squares are handled as polygons.
+func Collide(a Collider, b Collider) (Contact, bool) {
+	circleA, isCircleA := a.(*CircleCollider)
+	circleB, isCircleB := b.(*CircleCollider)
+	switch {
+	case isCircleA && isCircleB:
+		return circleContact(circleA.position, circleA.radius, circleB.position, circleB.radius)
+	case isCircleB:
+		polygon, ok := absolutePolygon(a)
+		if !ok {
+			return Contact{}, false
+		}
+		return polygonCircleContact(polygon, circleB.position, circleB.radius)
+	case isCircleA:
+		polygon, ok := absolutePolygon(b)
+		if !ok {
+			return Contact{}, false
+		}
+		contact, hit := polygonCircleContact(polygon, circleA.position, circleA.radius)
+		return contact.flipped(), hit
+	default:
+		polygonA, okA := absolutePolygon(a)
+		polygonB, okB := absolutePolygon(b)
+		if !okA || !okB {
+			return Contact{}, false
+		}
+		return polygonContact(polygonA, polygonB)
+	}
+}
+
+func (contact Contact) flipped() Contact {
+	return Contact{Normal: contact.Normal.Multiply(-1), Penetration: contact.Penetration}
+}
+
+func absolutePolygon(collider Collider) (physics.Polygon, bool) {
+	switch collider := collider.(type) {
+	case *SquareCollider:
+		return collider.Absolute(), true
+	case *PolygonCollider:
+		return collider.Absolute(), true
+	default:
+		return physics.Polygon{}, false
+	}
+}
+
+// circleContact separates the circles along the line of their centers,
+// the concentric circles along the x axis.
+func circleContact(center physics.Vector2, radius float64, other physics.Vector2, otherRadius float64) (Contact, bool) {
+	offset := other.Subtract(center)
+	distance := offset.Magnitude()
+	if distance > radius+otherRadius {
+		return Contact{}, false
+	}
+	normal := physics.Vector2{X: 1, Y: 0}
+	if distance > 0 {
+		normal = offset.Multiply(1 / distance)
+	}
+	return Contact{Normal: normal, Penetration: radius + otherRadius - distance}, true
+}
+
+// polygonCircleContact separates the circle from the nearest edge of the polygon,
+// the circle with its center inside the polygon is pushed out through that edge.
+func polygonCircleContact(polygon physics.Polygon, center physics.Vector2, radius float64) (Contact, bool) {
+	closest, distance := nearestEdgePoint(polygon, center)
+	inside := polygon.Contains(center)
+	if !inside && distance > radius {
+		return Contact{}, false
+	}
+
+	offset := center.Subtract(closest)
+	switch {
+	case distance == 0:
+		// The center on the edge, away from the middle of the polygon
+		offset = center.Subtract(middle(polygon))
+		return Contact{Normal: offset.Normalize(), Penetration: radius}, true
+	case inside:
+		return Contact{Normal: offset.Multiply(-1 / distance), Penetration: radius + distance}, true
+	default:
+		return Contact{Normal: offset.Multiply(1 / distance), Penetration: radius - distance}, true
+	}
+}
+
+// polygonContact separates the polygons along the axis of the least overlap, the separating axis theorem.
+// The concave polygons are separated as their convex hulls.
+func polygonContact(polygon physics.Polygon, other physics.Polygon) (Contact, bool) {
+	contact := Contact{Penetration: math.Inf(1)}
+	for _, edges := range [][]physics.Edge{polygon.Edges(), other.Edges()} {
+		for _, edge := range edges {
+			direction := edge.End.Subtract(edge.Start)
+			axis := physics.Vector2{X: -direction.Y, Y: direction.X}
+			axis = axis.Normalize()
+			if axis.X == 0 && axis.Y == 0 {
+				continue
+			}
+
+			low, high := project(polygon, axis)
+			otherLow, otherHigh := project(other, axis)
+			// Pushing the other polygon along the axis or against it
+			forward, backward := high-otherLow, otherHigh-low
+			if forward <= 0 || backward <= 0 {
+				return Contact{}, false
+			}
+			if forward < contact.Penetration {
+				contact = Contact{Normal: axis, Penetration: forward}
+			}
+			if backward < contact.Penetration {
+				contact = Contact{Normal: axis.Multiply(-1), Penetration: backward}
+			}
+		}
+	}
+	return contact, !math.IsInf(contact.Penetration, 1)
+}
+
+// project returns the interval the polygon covers along the axis.
+func project(polygon physics.Polygon, axis physics.Vector2) (float64, float64) {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, vertex := range polygon.Vertices {
+		projection := vertex.Dot(axis)
+		low, high = math.Min(low, projection), math.Max(high, projection)
+	}
+	return low, high
+}
+
+func nearestEdgePoint(polygon physics.Polygon, point physics.Vector2) (physics.Vector2, float64) {
+	nearest, nearestDistance := physics.Vector2{}, math.Inf(1)
+	for _, edge := range polygon.Edges() {
+		closest := edge.ClosestPoint(point)
+		if distance := point.Distance(closest); distance < nearestDistance {
+			nearest, nearestDistance = closest, distance
+		}
+	}
+	return nearest, nearestDistance
+}
+
+// middle returns the average of the vertices of the polygon.
+func middle(polygon physics.Polygon) physics.Vector2 {
+	sum := physics.Vector2{}
+	for _, vertex := range polygon.Vertices {
+		sum = sum.Add(vertex)
+	}
+	return sum.Multiply(1 / float64(len(polygon.Vertices)))
+}
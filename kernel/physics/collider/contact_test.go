@@ -0,0 +1,128 @@
+package collider
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollide(t *testing.T) {
+	square := []physics.Vector2{{X: -10, Y: -10}, {X: 10, Y: -10}, {X: 10, Y: 10}, {X: -10, Y: 10}}
+	tests := []struct {
+		name    string
+		a       Collider
+		b       Collider
+		contact Contact
+		hit     bool
+	}{
+		{
+			name:    "circle overlapping circle",
+			a:       NewCircleCollider(physics.Vector2{X: 0, Y: 0}, 10),
+			b:       NewCircleCollider(physics.Vector2{X: 15, Y: 0}, 10),
+			contact: Contact{Normal: physics.Vector2{X: 1, Y: 0}, Penetration: 5},
+			hit:     true,
+		},
+		{
+			name:    "circle apart from circle",
+			a:       NewCircleCollider(physics.Vector2{X: 0, Y: 0}, 10),
+			b:       NewCircleCollider(physics.Vector2{X: 0, Y: 25}, 10),
+			contact: Contact{},
+			hit:     false,
+		},
+		{
+			name:    "concentric circles",
+			a:       NewCircleCollider(physics.Vector2{X: 5, Y: 5}, 10),
+			b:       NewCircleCollider(physics.Vector2{X: 5, Y: 5}, 5),
+			contact: Contact{Normal: physics.Vector2{X: 1, Y: 0}, Penetration: 15},
+			hit:     true,
+		},
+		{
+			name:    "polygon overlapping circle",
+			a:       NewPolygonCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Polygon{Vertices: square}),
+			b:       NewCircleCollider(physics.Vector2{X: 0, Y: 15}, 10),
+			contact: Contact{Normal: physics.Vector2{X: 0, Y: 1}, Penetration: 5},
+			hit:     true,
+		},
+		{
+			name:    "circle overlapping polygon",
+			a:       NewCircleCollider(physics.Vector2{X: -15, Y: 0}, 10),
+			b:       NewPolygonCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Polygon{Vertices: square}),
+			contact: Contact{Normal: physics.Vector2{X: 1, Y: 0}, Penetration: 5},
+			hit:     true,
+		},
+		{
+			name:    "circle center inside polygon",
+			a:       NewPolygonCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Polygon{Vertices: square}),
+			b:       NewCircleCollider(physics.Vector2{X: 7, Y: 0}, 5),
+			contact: Contact{Normal: physics.Vector2{X: 1, Y: 0}, Penetration: 8},
+			hit:     true,
+		},
+		{
+			name:    "circle center on polygon edge",
+			a:       NewPolygonCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Polygon{Vertices: square}),
+			b:       NewCircleCollider(physics.Vector2{X: 0, Y: 10}, 5),
+			contact: Contact{Normal: physics.Vector2{X: 0, Y: 1}, Penetration: 5},
+			hit:     true,
+		},
+		{
+			name:    "circle apart from polygon",
+			a:       NewPolygonCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Polygon{Vertices: square}),
+			b:       NewCircleCollider(physics.Vector2{X: 20, Y: 20}, 10),
+			contact: Contact{},
+			hit:     false,
+		},
+		{
+			name:    "square overlapping circle",
+			a:       NewSquareCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Size{Width: 20, Height: 20}),
+			b:       NewCircleCollider(physics.Vector2{X: 18, Y: 0}, 10),
+			contact: Contact{Normal: physics.Vector2{X: 1, Y: 0}, Penetration: 2},
+			hit:     true,
+		},
+		{
+			name:    "polygon overlapping polygon",
+			a:       NewPolygonCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Polygon{Vertices: square}),
+			b:       NewPolygonCollider(physics.Vector2{X: 17, Y: 2}, 0, physics.Polygon{Vertices: square}),
+			contact: Contact{Normal: physics.Vector2{X: 1, Y: 0}, Penetration: 3},
+			hit:     true,
+		},
+		{
+			name:    "polygon apart from square",
+			a:       NewPolygonCollider(physics.Vector2{X: 0, Y: 0}, 0, physics.Polygon{Vertices: square}),
+			b:       NewSquareCollider(physics.Vector2{X: 0, Y: 40}, 0, physics.Size{Width: 20, Height: 20}),
+			contact: Contact{},
+			hit:     false,
+		},
+		{
+			name:    "circle and other",
+			a:       NewCircleCollider(physics.Vector2{X: 0, Y: 0}, 10),
+			b:       &MockCollider{},
+			contact: Contact{},
+			hit:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			contact, hit := Collide(test.a, test.b)
+			assert.Equal(t, test.hit, hit)
+			assert.InDelta(t, test.contact.Normal.X, contact.Normal.X, 1e-9)
+			assert.InDelta(t, test.contact.Normal.Y, contact.Normal.Y, 1e-9)
+			assert.InDelta(t, test.contact.Penetration, contact.Penetration, 1e-9)
+		})
+	}
+}
+
+func TestCollide_Separates(t *testing.T) {
+	// Pushing the second collider along the normal by the penetration separates the colliders
+	a := NewPolygonCollider(physics.Vector2{X: 0, Y: 0}, 0.3, physics.Polygon{Vertices: []physics.Vector2{
+		{X: -12, Y: -8}, {X: 3, Y: -14}, {X: 14, Y: 2}, {X: 1, Y: 12}, {X: -10, Y: 6},
+	}})
+	b := NewCircleCollider(physics.Vector2{X: 9, Y: 7}, 6)
+
+	contact, hit := Collide(a, b)
+	assert.True(t, hit)
+	position := b.Position()
+	b.SetPosition(position.Add(contact.Normal.Multiply(contact.Penetration + 1e-9)))
+	assert.False(t, a.CollidesWith(b))
+}
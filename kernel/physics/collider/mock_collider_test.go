@@ -41,6 +41,11 @@ func (m *MockCollider) CollidesWith(other Collider) bool {
 	return args.Bool(0)
 }
 
+func (m *MockCollider) Bounds() physics.AABB {
+	args := m.Called()
+	return args.Get(0).(physics.AABB)
+}
+
 func (m *MockCollider) Serialize() map[string]interface{} {
 	args := m.Called()
 	return args.Get(0).(map[string]interface{})
@@ -61,6 +61,12 @@ func (polygon *PolygonCollider) CollidesWith(other Collider) bool {
 	}
 }
 
+func (polygon *PolygonCollider) Bounds() physics.AABB {
+	absolute := polygon.Absolute()
+	minX, minY, maxX, maxY := absolute.Bounds()
+	return physics.AABB{Min: physics.Vector2{X: minX, Y: minY}, Max: physics.Vector2{X: maxX, Y: maxY}}
+}
+
 func (polygon *PolygonCollider) IsRotated() bool {
 	return polygon.rotation != 0
 }
@@ -114,6 +120,9 @@ func polygonCollidesWithCircle(polygon PolygonCollider, circle CircleCollider) b
 	circleCenter := circle.position
 	circleRadius := circle.radius
 	abs := polygon.Absolute()
+	if abs.Contains(circleCenter) {
+		return true
+	}
 
 	for _, edge := range abs.Edges() {
 		closestPoint := edge.ClosestPoint(circleCenter)
@@ -44,6 +44,19 @@ func TestPolygonCollider_SetRotation(t *testing.T) {
 	assert.Equal(t, math.Pi/2, polygon.Rotation())
 }
 
+func TestPolygonCollider_Bounds(t *testing.T) {
+	polygon := NewPolygonCollider(physics.Vector2{X: 10, Y: 20}, 0, physics.Polygon{Vertices: []physics.Vector2{{X: 0, Y: -2}, {X: 3, Y: 1}, {X: -1, Y: 1}}})
+	expected := physics.AABB{Min: physics.Vector2{X: 9, Y: 18}, Max: physics.Vector2{X: 13, Y: 21}}
+	assert.Equal(t, expected, polygon.Bounds())
+
+	polygon.SetRotation(math.Pi)
+	bounds := polygon.Bounds()
+	assert.InDelta(t, 7, bounds.Min.X, 1e-9)
+	assert.InDelta(t, 19, bounds.Min.Y, 1e-9)
+	assert.InDelta(t, 11, bounds.Max.X, 1e-9)
+	assert.InDelta(t, 22, bounds.Max.Y, 1e-9)
+}
+
 func TestPolygonCollider_IsRotated(t *testing.T) {
 	var tests = []struct {
 		rotation float64
@@ -266,6 +279,19 @@ func TestPolygonCollider_CollidesWithCircle(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "Circle inside the polygon",
+			polygon: PolygonCollider{
+				position: physics.Vector2{X: 0, Y: 0},
+				rotation: 0,
+				polygon:  polygonSquare,
+			},
+			circle: CircleCollider{
+				position: physics.Vector2{X: 0.5, Y: 0.25},
+				radius:   0.1,
+			},
+			expected: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -68,6 +68,18 @@ func (square *SquareCollider) CollidesWith(other Collider) bool {
 	}
 }
 
+func (square *SquareCollider) Bounds() physics.AABB {
+	if !square.IsRotated() {
+		return physics.AABB{
+			Min: physics.Vector2{X: square.position.X - square.size.Width/2, Y: square.position.Y - square.size.Height/2},
+			Max: physics.Vector2{X: square.position.X + square.size.Width/2, Y: square.position.Y + square.size.Height/2},
+		}
+	}
+	polygon := square.Absolute()
+	minX, minY, maxX, maxY := polygon.Bounds()
+	return physics.AABB{Min: physics.Vector2{X: minX, Y: minY}, Max: physics.Vector2{X: maxX, Y: maxY}}
+}
+
 func (square *SquareCollider) IsRotated() bool {
 	return square.rotation != 0
 }
@@ -55,6 +55,19 @@ func TestSquareCollider_SetSize(t *testing.T) {
 	assert.Equal(t, physics.Size{Width: 3, Height: 3}, square.Size())
 }
 
+func TestSquareCollider_Bounds(t *testing.T) {
+	square := NewSquareCollider(physics.Vector2{X: 10, Y: 20}, 0, physics.Size{Width: 4, Height: 2})
+	expected := physics.AABB{Min: physics.Vector2{X: 8, Y: 19}, Max: physics.Vector2{X: 12, Y: 21}}
+	assert.Equal(t, expected, square.Bounds())
+
+	square.SetRotation(math.Pi / 2)
+	bounds := square.Bounds()
+	assert.InDelta(t, 9, bounds.Min.X, 1e-9)
+	assert.InDelta(t, 18, bounds.Min.Y, 1e-9)
+	assert.InDelta(t, 11, bounds.Max.X, 1e-9)
+	assert.InDelta(t, 22, bounds.Max.Y, 1e-9)
+}
+
 func TestSquareCollider_IsRotated(t *testing.T) {
 	var tests = []struct {
 		rotation float64
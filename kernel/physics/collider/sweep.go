@@ -0,0 +1,152 @@
+package collider
+
+import (
+	"math"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+// Sweep checks the collision of a moving collider along its path, so fast objects
+// do not tunnel through the others between two ticks.
+// The moving collider is at the end of the displacement, the other one is static,
+// for two moving colliders pass the relative displacement.
+//
+// Returns the earliest fraction of the displacement (0 to 1) at which the colliders touch.
+// The path is swept with a circle (a capsule), see sweepRadius, if it misses but
+// the colliders overlap at the end, the fraction is 1.
+func Sweep(moving Collider, displacement physics.Vector2, other Collider) (float64, bool) {
+	if displacement.X != 0 || displacement.Y != 0 {
+		end := moving.Position()
+		start := end.Subtract(displacement)
+		if time, hit := sweepCircle(start, end, sweepRadius(moving), other); hit {
+			return time, true
+		}
+	}
+
+	if moving.CollidesWith(other) {
+		return 1, true
+	}
+	return 0, false
+}
+
+// sweepRadius returns the radius of the circle swept along the path,
+// the largest circle fitting into the collider.
+func sweepRadius(collider Collider) float64 {
+	switch collider := collider.(type) {
+	case *CircleCollider:
+		return collider.radius
+	case *SquareCollider:
+		return math.Min(collider.size.Width, collider.size.Height) / 2
+	default:
+		return 0
+	}
+}
+
+func sweepCircle(start physics.Vector2, end physics.Vector2, radius float64, other Collider) (float64, bool) {
+	switch other := other.(type) {
+	case *CircleCollider:
+		return segmentCircleTime(start, end, other.position, radius+other.radius)
+	case *SquareCollider:
+		return sweepCircleAgainstPolygon(start, end, radius, other.Absolute())
+	case *PolygonCollider:
+		return sweepCircleAgainstPolygon(start, end, radius, other.Absolute())
+	default:
+		return 0, false
+	}
+}
+
+func sweepCircleAgainstPolygon(start physics.Vector2, end physics.Vector2, radius float64, polygon physics.Polygon) (float64, bool) {
+	if polygon.Contains(start) {
+		return 0, true
+	}
+
+	earliest := math.Inf(1)
+	for _, edge := range polygon.Edges() {
+		if time, hit := segmentCapsuleTime(start, end, edge, radius); hit && time < earliest {
+			earliest = time
+		}
+	}
+	return earliest, !math.IsInf(earliest, 1)
+}
+
+// segmentCapsuleTime returns the earliest time along the segment from start to end
+// at which it is within the radius of the edge, i.e. enters the capsule around the edge.
+func segmentCapsuleTime(start physics.Vector2, end physics.Vector2, edge physics.Edge, radius float64) (float64, bool) {
+	closest := edge.ClosestPoint(start)
+	if start.Distance(closest) <= radius {
+		return 0, true
+	}
+
+	earliest := math.Inf(1)
+	// The rounded ends
+	for _, center := range []physics.Vector2{edge.Start, edge.End} {
+		if time, hit := segmentCircleTime(start, end, center, radius); hit && time < earliest {
+			earliest = time
+		}
+	}
+
+	// The sides, the edge shifted by the radius along its normal
+	direction := edge.End.Subtract(edge.Start)
+	normal := physics.Vector2{X: -direction.Y, Y: direction.X}
+	normal = normal.Normalize()
+	offset := normal.Multiply(radius)
+	for _, side := range []physics.Vector2{offset, offset.Multiply(-1)} {
+		if time, hit := segmentSegmentTime(start, end, edge.Start.Add(side), edge.End.Add(side)); hit && time < earliest {
+			earliest = time
+		}
+	}
+
+	return earliest, !math.IsInf(earliest, 1)
+}
+
+// segmentCircleTime returns the earliest time along the segment from start to end
+// at which it is within the radius of the center.
+func segmentCircleTime(start physics.Vector2, end physics.Vector2, center physics.Vector2, radius float64) (float64, bool) {
+	direction := end.Subtract(start)
+	offset := start.Subtract(center)
+
+	c := offset.Dot(offset) - radius*radius
+	if c <= 0 {
+		return 0, true
+	}
+	a := direction.Dot(direction)
+	if a == 0 {
+		return 0, false
+	}
+	b := 2 * offset.Dot(direction)
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return 0, false
+	}
+
+	time := (-b - math.Sqrt(discriminant)) / (2 * a)
+	if time < 0 || time > 1 {
+		return 0, false
+	}
+	return time, true
+}
+
+// segmentSegmentTime returns the time along the segment from start to end
+// at which it crosses the segment from a to b, parallel segments never cross.
+func segmentSegmentTime(start physics.Vector2, end physics.Vector2, a physics.Vector2, b physics.Vector2) (float64, bool) {
+	direction := end.Subtract(start)
+	other := b.Subtract(a)
+	denominator := direction.Cross(other)
+	if denominator == 0 {
+		return 0, false
+	}
+
+	offset := a.Subtract(start)
+	time := offset.Cross(other) / denominator
+	position := offset.Cross(direction) / denominator
+	if time < 0 || time > 1 || position < 0 || position > 1 {
+		return 0, false
+	}
+	return time, true
+}
+
+// Raycast returns the earliest fraction of the segment from start to end (0 to 1)
+// at which it touches the collider, 0 when the start is within the collider.
+func Raycast(start physics.Vector2, end physics.Vector2, other Collider) (float64, bool) {
+	return sweepCircle(start, end, 0, other)
+}
@@ -0,0 +1,155 @@
+package collider
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/physics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweep(t *testing.T) {
+	tests := []struct {
+		name         string
+		moving       Collider
+		displacement physics.Vector2
+		other        Collider
+		time         float64
+		hit          bool
+	}{
+		{
+			name:         "circle tunneling through circle",
+			moving:       NewCircleCollider(physics.Vector2{X: 100, Y: 0}, 1),
+			displacement: physics.Vector2{X: 100, Y: 0},
+			other:        NewCircleCollider(physics.Vector2{X: 50, Y: 0}, 15),
+			time:         0.34,
+			hit:          true,
+		},
+		{
+			name:         "circle passing by circle",
+			moving:       NewCircleCollider(physics.Vector2{X: 100, Y: 0}, 1),
+			displacement: physics.Vector2{X: 100, Y: 0},
+			other:        NewCircleCollider(physics.Vector2{X: 50, Y: 20}, 15),
+			hit:          false,
+		},
+		{
+			name:         "circle stopping before circle",
+			moving:       NewCircleCollider(physics.Vector2{X: 30, Y: 0}, 1),
+			displacement: physics.Vector2{X: 30, Y: 0},
+			other:        NewCircleCollider(physics.Vector2{X: 50, Y: 0}, 15),
+			hit:          false,
+		},
+		{
+			name:         "circle starting inside circle",
+			moving:       NewCircleCollider(physics.Vector2{X: 150, Y: 0}, 1),
+			displacement: physics.Vector2{X: 100, Y: 0},
+			other:        NewCircleCollider(physics.Vector2{X: 50, Y: 0}, 15),
+			time:         0,
+			hit:          true,
+		},
+		{
+			name:         "laser tunneling through square",
+			moving:       NewSquareCollider(physics.Vector2{X: 100, Y: 0}, 0, physics.Size{Width: 2, Height: 12}),
+			displacement: physics.Vector2{X: 64, Y: 0},
+			other:        NewSquareCollider(physics.Vector2{X: 60, Y: 0}, 0, physics.Size{Width: 10, Height: 10}),
+			time:         0.28125,
+			hit:          true,
+		},
+		{
+			name:         "laser tunneling through the corner of a rotated square",
+			moving:       NewSquareCollider(physics.Vector2{X: 100, Y: 9}, 0, physics.Size{Width: 2, Height: 12}),
+			displacement: physics.Vector2{X: 100, Y: 0},
+			other:        NewSquareCollider(physics.Vector2{X: 50, Y: 0}, 0.7853981633974483, physics.Size{Width: 20, Height: 20}),
+			hit:          true,
+		},
+		{
+			name:         "rocket tunneling through polygon",
+			moving:       NewCircleCollider(physics.Vector2{X: 0, Y: 100}, 5),
+			displacement: physics.Vector2{X: 0, Y: 100},
+			other:        NewPolygonCollider(physics.Vector2{X: 0, Y: 50}, 0, physics.Polygon{Vertices: []physics.Vector2{{X: -10, Y: -10}, {X: 10, Y: -10}, {X: 0, Y: 10}}}),
+			time:         0.35,
+			hit:          true,
+		},
+		{
+			name:         "rocket passing by polygon",
+			moving:       NewCircleCollider(physics.Vector2{X: 20, Y: 100}, 5),
+			displacement: physics.Vector2{X: 0, Y: 100},
+			other:        NewPolygonCollider(physics.Vector2{X: 0, Y: 50}, 0, physics.Polygon{Vertices: []physics.Vector2{{X: -10, Y: -10}, {X: 10, Y: -10}, {X: 0, Y: 10}}}),
+			hit:          false,
+		},
+		{
+			name:         "not moving, overlapping",
+			moving:       NewCircleCollider(physics.Vector2{X: 0, Y: 0}, 5),
+			displacement: physics.Vector2{X: 0, Y: 0},
+			other:        NewCircleCollider(physics.Vector2{X: 8, Y: 0}, 5),
+			time:         1,
+			hit:          true,
+		},
+		{
+			name:         "not moving, apart",
+			moving:       NewCircleCollider(physics.Vector2{X: 0, Y: 0}, 5),
+			displacement: physics.Vector2{X: 0, Y: 0},
+			other:        NewCircleCollider(physics.Vector2{X: 20, Y: 0}, 5),
+			hit:          false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			time, hit := Sweep(test.moving, test.displacement, test.other)
+			assert.Equal(t, test.hit, hit)
+			if test.hit && test.time != 0 {
+				assert.InDelta(t, test.time, time, 1e-9)
+			}
+		})
+	}
+}
+
+func TestRaycast(t *testing.T) {
+	tests := []struct {
+		name  string
+		end   physics.Vector2
+		other Collider
+		time  float64
+		hit   bool
+	}{
+		{"through circle", physics.Vector2{X: 100, Y: 0}, NewCircleCollider(physics.Vector2{X: 50, Y: 0}, 10), 0.4, true},
+		{"passing by circle", physics.Vector2{X: 100, Y: 0}, NewCircleCollider(physics.Vector2{X: 50, Y: 20}, 10), 0, false},
+		{"stopping before circle", physics.Vector2{X: 30, Y: 0}, NewCircleCollider(physics.Vector2{X: 50, Y: 0}, 10), 0, false},
+		{"starting inside circle", physics.Vector2{X: 100, Y: 0}, NewCircleCollider(physics.Vector2{X: 0, Y: 0}, 10), 0, true},
+		{"through square", physics.Vector2{X: 100, Y: 0}, NewSquareCollider(physics.Vector2{X: 50, Y: 0}, 0, physics.Size{Width: 20, Height: 20}), 0.4, true},
+		{"passing by square", physics.Vector2{X: 100, Y: 0}, NewSquareCollider(physics.Vector2{X: 50, Y: 20}, 0, physics.Size{Width: 20, Height: 20}), 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			time, hit := Raycast(physics.Vector2{X: 0, Y: 0}, test.end, test.other)
+			assert.Equal(t, test.hit, hit)
+			if test.hit {
+				assert.InDelta(t, test.time, time, 1e-9)
+			}
+		})
+	}
+}
+
+func TestSweep_Other(t *testing.T) {
+	collider_mocked := new(MockCollider)
+	moving := NewCircleCollider(physics.Vector2{X: 100, Y: 0}, 1)
+
+	collider_mocked.On("CollidesWith", moving).Return(false)
+	_, hit := Sweep(moving, physics.Vector2{X: 100, Y: 0}, collider_mocked)
+	assert.False(t, hit)
+}
+
+func TestSegmentSegmentTime(t *testing.T) {
+	time, hit := segmentSegmentTime(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 10, Y: 0}, physics.Vector2{X: 4, Y: -5}, physics.Vector2{X: 4, Y: 5})
+	assert.True(t, hit)
+	assert.Equal(t, 0.4, time)
+
+	// Parallel
+	_, hit = segmentSegmentTime(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 10, Y: 0}, physics.Vector2{X: 0, Y: 1}, physics.Vector2{X: 10, Y: 1})
+	assert.False(t, hit)
+
+	// Crossing the line, but not the segment
+	_, hit = segmentSegmentTime(physics.Vector2{X: 0, Y: 0}, physics.Vector2{X: 10, Y: 0}, physics.Vector2{X: 4, Y: 1}, physics.Vector2{X: 4, Y: 5})
+	assert.False(t, hit)
+}
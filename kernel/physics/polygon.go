@@ -116,3 +116,12 @@ func (p *Polygon) Bounds() (minX, minY, maxX, maxY float64) {
 
 	return p.minX, p.minY, p.maxX, p.maxY
 }
+
+// Area returns the area of the polygon, the shoelace formula, regardless of the order of the vertices.
+func (p *Polygon) Area() float64 {
+	area := 0.0
+	for _, edge := range p.Edges() {
+		area += edge.Start.Cross(edge.End)
+	}
+	return math.Abs(area) / 2
+}
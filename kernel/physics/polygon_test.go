@@ -414,3 +414,23 @@ func TestPolygon_Bounds(t *testing.T) {
 	assert.Equal(t, 1.0, polygon.maxX)
 	assert.Equal(t, 1.0, polygon.maxY)
 }
+
+func TestPolygon_Area(t *testing.T) {
+	square := Polygon{Vertices: []Vector2{
+		{X: -1, Y: -1},
+		{X: 1, Y: -1},
+		{X: 1, Y: 1},
+		{X: -1, Y: 1},
+	}}
+	assert.Equal(t, 4.0, square.Area())
+
+	// Concave
+	arrow := Polygon{Vertices: []Vector2{
+		{X: 0, Y: 0},
+		{X: 2, Y: 1},
+		{X: 0, Y: 2},
+		{X: 1, Y: 1},
+	}}
+	assert.Equal(t, 1.0, arrow.Area())
+	assert.Equal(t, 0.0, (&Polygon{}).Area())
+}
@@ -0,0 +1,147 @@
+package physics
+
+import (
+	"math"
+	"sort"
+)
+
+// Pair of indices of two potentially colliding objects, A < B.
+type Pair struct {
+	A int
+	B int
+}
+
+type cell struct {
+	x int
+	y int
+}
+
+// SpatialHash is a uniform grid broad-phase. Objects are inserted by their bounding box
+// into every cell the box covers, only objects sharing a cell are candidates for
+// the narrow-phase collision check.
+//
+// The cell size should be around the size of the largest common object, too small cells
+// put big objects into many cells, too big cells put many objects into the same cell.
+//
+// The space wraps around its edges when a size is given, see Wrap, the cells are then
+// resized to fit the space exactly.
+type SpatialHash struct {
+	cellWidth  float64
+	cellHeight float64
+	columns    int
+	rows       int
+	size       Size
+	cells      map[cell][]int
+	bounds     map[int]AABB
+	used       []cell
+}
+
+func NewSpatialHash(cellSize float64, size Size) *SpatialHash {
+	hash := &SpatialHash{
+		cellWidth:  cellSize,
+		cellHeight: cellSize,
+		size:       size,
+		cells:      map[cell][]int{},
+		bounds:     map[int]AABB{},
+		used:       []cell{},
+	}
+	if size.Width > 0 {
+		hash.columns = int(math.Max(1, math.Floor(size.Width/cellSize)))
+		hash.cellWidth = size.Width / float64(hash.columns)
+	}
+	if size.Height > 0 {
+		hash.rows = int(math.Max(1, math.Floor(size.Height/cellSize)))
+		hash.cellHeight = size.Height / float64(hash.rows)
+	}
+	return hash
+}
+
+// Clear removes all the objects, the allocated cells are kept for reuse.
+func (hash *SpatialHash) Clear() {
+	for _, key := range hash.used {
+		hash.cells[key] = hash.cells[key][:0]
+	}
+	hash.used = hash.used[:0]
+	for index := range hash.bounds {
+		delete(hash.bounds, index)
+	}
+}
+
+// Insert adds the object with the given index and bounding box.
+func (hash *SpatialHash) Insert(index int, bounds AABB) {
+	hash.bounds[index] = bounds
+	minX, maxX := span(bounds.Min.X, bounds.Max.X, hash.cellWidth, hash.columns)
+	minY, maxY := span(bounds.Min.Y, bounds.Max.Y, hash.cellHeight, hash.rows)
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			key := cell{x: wrapIndex(x, hash.columns), y: wrapIndex(y, hash.rows)}
+			if len(hash.cells[key]) == 0 {
+				hash.used = append(hash.used, key)
+			}
+			hash.cells[key] = append(hash.cells[key], index)
+		}
+	}
+}
+
+// Pairs returns the pairs of objects with overlapping bounding boxes,
+// each pair once, ordered by A and then B.
+func (hash *SpatialHash) Pairs() []Pair {
+	pairs := []Pair{}
+	seen := map[Pair]bool{}
+	for _, key := range hash.used {
+		indices := hash.cells[key]
+		for i := 0; i < len(indices)-1; i++ {
+			for j := i + 1; j < len(indices); j++ {
+				pair := Pair{A: indices[i], B: indices[j]}
+				if pair.A > pair.B {
+					pair.A, pair.B = pair.B, pair.A
+				}
+				if seen[pair] {
+					continue
+				}
+				seen[pair] = true
+				if hash.overlaps(hash.bounds[pair.A], hash.bounds[pair.B]) {
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+	return pairs
+}
+
+// overlaps checks the bounds overlap, with the second one moved next to the first one
+// across the edges of the space.
+func (hash *SpatialHash) overlaps(a AABB, b AABB) bool {
+	centerA, centerB := a.Center(), b.Center()
+	nearest := centerA.Add(WrappedDisplacement(centerA, centerB, hash.size))
+	return a.Overlaps(b.Translate(nearest.Subtract(centerB)))
+}
+
+// span returns the range of cell indices covered by the interval,
+// at most count cells when the space wraps.
+func span(min float64, max float64, cellSize float64, count int) (int, int) {
+	first := int(math.Floor(min / cellSize))
+	last := int(math.Floor(max / cellSize))
+	if count > 0 && last-first >= count {
+		last = first + count - 1
+	}
+	return first, last
+}
+
+func wrapIndex(index int, count int) int {
+	if count <= 0 {
+		return index
+	}
+	index %= count
+	if index < 0 {
+		index += count
+	}
+	return index
+}
@@ -0,0 +1,107 @@
+package physics
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpatialHash_Pairs(t *testing.T) {
+	hash := NewSpatialHash(10, Size{})
+	hash.Insert(3, NewAABB(Vector2{X: 5, Y: 5}, 2))
+	hash.Insert(1, NewAABB(Vector2{X: 8, Y: 8}, 2))
+	// Spans 4 cells, overlaps both above and the one below
+	hash.Insert(0, NewAABB(Vector2{X: 10, Y: 10}, 4))
+	hash.Insert(2, NewAABB(Vector2{X: 13, Y: 13}, 1))
+	// Shares the cell, but does not overlap
+	hash.Insert(4, NewAABB(Vector2{X: 1, Y: 9}, 0.5))
+	// Far away
+	hash.Insert(5, NewAABB(Vector2{X: -100, Y: 100}, 5))
+
+	assert.Equal(t, []Pair{{A: 0, B: 1}, {A: 0, B: 2}, {A: 0, B: 3}, {A: 1, B: 3}}, hash.Pairs())
+}
+
+func TestSpatialHash_Clear(t *testing.T) {
+	hash := NewSpatialHash(10, Size{})
+	hash.Insert(0, NewAABB(Vector2{X: 5, Y: 5}, 2))
+	hash.Insert(1, NewAABB(Vector2{X: 6, Y: 6}, 2))
+	assert.Len(t, hash.Pairs(), 1)
+
+	hash.Clear()
+	assert.Empty(t, hash.Pairs())
+
+	hash.Insert(0, NewAABB(Vector2{X: 5, Y: 5}, 2))
+	hash.Insert(2, NewAABB(Vector2{X: 6, Y: 6}, 2))
+	assert.Equal(t, []Pair{{A: 0, B: 2}}, hash.Pairs())
+}
+
+func TestSpatialHash_Pairs_Wrapped(t *testing.T) {
+	hash := NewSpatialHash(64, Size{Width: 1000, Height: 500})
+	// Across the left and right edges
+	hash.Insert(0, NewAABB(Vector2{X: 5, Y: 100}, 10))
+	hash.Insert(1, NewAABB(Vector2{X: 992, Y: 100}, 10))
+	// Across the corner
+	hash.Insert(2, NewAABB(Vector2{X: 3, Y: 497}, 5))
+	hash.Insert(3, NewAABB(Vector2{X: 997, Y: 3}, 5))
+	// Outside of the battlefield, overlaps 0
+	hash.Insert(4, NewAABB(Vector2{X: 1010, Y: 110}, 5))
+	// Near the edge, but too far
+	hash.Insert(5, NewAABB(Vector2{X: 970, Y: 300}, 5))
+
+	assert.Equal(t, []Pair{{A: 0, B: 1}, {A: 0, B: 4}, {A: 2, B: 3}}, hash.Pairs())
+}
+
+func TestSpatialHash_Pairs_MatchesBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	bounds := make([]AABB, 200)
+	for i := range bounds {
+		bounds[i] = NewAABB(Vector2{X: random.Float64() * 1000, Y: random.Float64() * 1000}, random.Float64()*40)
+	}
+
+	hash := NewSpatialHash(64, Size{})
+	for i, aabb := range bounds {
+		hash.Insert(i, aabb)
+	}
+
+	assert.Equal(t, bruteForcePairs(bounds), hash.Pairs())
+}
+
+func BenchmarkSpatialHash_Pairs(b *testing.B) {
+	for _, count := range []int{32, 128, 512} {
+		random := rand.New(rand.NewSource(42))
+		bounds := make([]AABB, count)
+		for i := range bounds {
+			bounds[i] = NewAABB(Vector2{X: random.Float64() * 1920, Y: random.Float64() * 1080}, 15)
+		}
+
+		b.Run(fmt.Sprintf("objects=%d/spatial-hash", count), func(b *testing.B) {
+			hash := NewSpatialHash(64, Size{})
+			for n := 0; n < b.N; n++ {
+				hash.Clear()
+				for i, aabb := range bounds {
+					hash.Insert(i, aabb)
+				}
+				hash.Pairs()
+			}
+		})
+		b.Run(fmt.Sprintf("objects=%d/brute-force", count), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bruteForcePairs(bounds)
+			}
+		})
+	}
+}
+
+func bruteForcePairs(bounds []AABB) []Pair {
+	pairs := []Pair{}
+	for i := 0; i < len(bounds)-1; i++ {
+		for j := i + 1; j < len(bounds); j++ {
+			if bounds[i].Overlaps(bounds[j]) {
+				pairs = append(pairs, Pair{A: i, B: j})
+			}
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,56 @@
+package physics
+
+import "math"
+
+// The battlefield wraps around its edges, i.e. it is a torus. The helpers below
+// take the size of the battlefield, a zero width or height disables the wrapping
+// along that axis.
+
+// Wrap returns the position moved into the battlefield, from 0 (inclusive) to the size (exclusive).
+func Wrap(position Vector2, size Size) Vector2 {
+	return Vector2{X: wrap(position.X, size.Width), Y: wrap(position.Y, size.Height)}
+}
+
+// WrappedDisplacement returns the shortest displacement from one position to another,
+// possibly across the edges of the battlefield.
+func WrappedDisplacement(from Vector2, to Vector2, size Size) Vector2 {
+	return Vector2{
+		X: wrappedDelta(to.X-from.X, size.Width),
+		Y: wrappedDelta(to.Y-from.Y, size.Height),
+	}
+}
+
+// WrappedDistance returns the shortest distance between two positions,
+// possibly across the edges of the battlefield.
+func WrappedDistance(from Vector2, to Vector2, size Size) float64 {
+	displacement := WrappedDisplacement(from, to, size)
+	return displacement.Magnitude()
+}
+
+func wrap(value float64, length float64) float64 {
+	if length <= 0 {
+		return value
+	}
+	value = math.Mod(value, length)
+	if value < 0 {
+		value += length
+	}
+	// A tiny negative value wraps to the length itself
+	if value >= length {
+		value = 0
+	}
+	return value
+}
+
+func wrappedDelta(delta float64, length float64) float64 {
+	if length <= 0 {
+		return delta
+	}
+	delta = math.Mod(delta, length)
+	if delta > length/2 {
+		delta -= length
+	} else if delta < -length/2 {
+		delta += length
+	}
+	return delta
+}
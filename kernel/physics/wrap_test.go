@@ -0,0 +1,57 @@
+package physics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap(t *testing.T) {
+	size := Size{Width: 1000, Height: 500}
+	tests := []struct {
+		position Vector2
+		expected Vector2
+	}{
+		{Vector2{X: 10, Y: 20}, Vector2{X: 10, Y: 20}},
+		{Vector2{X: 1010, Y: 520}, Vector2{X: 10, Y: 20}},
+		{Vector2{X: -10, Y: -20}, Vector2{X: 990, Y: 480}},
+		{Vector2{X: 1000, Y: 500}, Vector2{X: 0, Y: 0}},
+		{Vector2{X: 2500, Y: -1100}, Vector2{X: 500, Y: 400}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, Wrap(test.position, size), "%v", test.position)
+	}
+
+	assert.Equal(t, Vector2{X: -10, Y: 20}, Wrap(Vector2{X: -10, Y: 520}, Size{Width: 0, Height: 500}))
+}
+
+func TestWrappedDisplacement(t *testing.T) {
+	size := Size{Width: 1000, Height: 500}
+	tests := []struct {
+		from     Vector2
+		to       Vector2
+		expected Vector2
+	}{
+		{Vector2{X: 100, Y: 100}, Vector2{X: 200, Y: 150}, Vector2{X: 100, Y: 50}},
+		{Vector2{X: 10, Y: 100}, Vector2{X: 990, Y: 100}, Vector2{X: -20, Y: 0}},
+		{Vector2{X: 990, Y: 100}, Vector2{X: 10, Y: 100}, Vector2{X: 20, Y: 0}},
+		{Vector2{X: 100, Y: 490}, Vector2{X: 100, Y: 5}, Vector2{X: 0, Y: 15}},
+		{Vector2{X: 5, Y: 5}, Vector2{X: 995, Y: 495}, Vector2{X: -10, Y: -10}},
+		// Positions outside of the battlefield
+		{Vector2{X: 1010, Y: 100}, Vector2{X: 20, Y: 100}, Vector2{X: 10, Y: 0}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, WrappedDisplacement(test.from, test.to, size), "%v -> %v", test.from, test.to)
+	}
+
+	// Not wrapping
+	assert.Equal(t, Vector2{X: 980, Y: 0}, WrappedDisplacement(Vector2{X: 10, Y: 0}, Vector2{X: 990, Y: 0}, Size{}))
+}
+
+func TestWrappedDistance(t *testing.T) {
+	size := Size{Width: 1000, Height: 500}
+	assert.Equal(t, 20.0, WrappedDistance(Vector2{X: 10, Y: 100}, Vector2{X: 990, Y: 100}, size))
+	assert.Equal(t, 5.0, WrappedDistance(Vector2{X: 998, Y: 498}, Vector2{X: 1, Y: 2}, size))
+}
@@ -0,0 +1,126 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/davidhorak/space-wars/kernel/bot"
+	"github.com/davidhorak/space-wars/kernel/game"
+)
+
+const (
+	DefaultWidth          = 1024
+	DefaultHeight         = 768
+	DefaultTickMs         = 50
+	DefaultMaxDurationSec = 300
+)
+
+// ShipConfig describes a ship in the match. When Command is set, the ship is
+// controlled by a bot process speaking the stdin/stdout protocol of the bot package,
+// otherwise the ship stays idle. The ships with the same team play together.
+type ShipConfig struct {
+	Name     string   `json:"name"`
+	X        float64  `json:"x"`
+	Y        float64  `json:"y"`
+	Rotation float64  `json:"rotation"`
+	Team     string   `json:"team,omitempty"`
+	Command  []string `json:"command,omitempty"`
+}
+
+type Config struct {
+	Width          float64      `json:"width"`
+	Height         float64      `json:"height"`
+	Seed           int64        `json:"seed"`
+	TickMs         float64      `json:"tickMs"`
+	MaxDurationSec float64      `json:"maxDurationSec"`
+	Ships          []ShipConfig `json:"ships"`
+	// Rules of the match, the omitted values keep their defaults,
+	// nil means the default rules.
+	Rules *game.Rules `json:"rules,omitempty"`
+	// Bot settings, see bot.Options
+	BotTimeoutMs      float64 `json:"botTimeoutMs"`
+	BotStartTimeoutMs float64 `json:"botStartTimeoutMs"`
+	BotMaxTimeouts    int     `json:"botMaxTimeouts"`
+	// FogOfWar limits the bots to what their spaceships observe,
+	// see the radar rules and game.Game.Observe.
+	FogOfWar bool `json:"fogOfWar"`
+}
+
+// LoadConfig reads and parses a JSON match configuration file.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses a JSON match configuration, fills in the defaults
+// for the omitted values and validates the result.
+// Unknown values are rejected, in the rules as well, see game.ParseRules.
+func ParseConfig(data []byte) (Config, error) {
+	config := Config{Rules: game.DefaultRules()}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, err
+	}
+
+	if config.Width == 0 {
+		config.Width = DefaultWidth
+	}
+	if config.Height == 0 {
+		config.Height = DefaultHeight
+	}
+	if config.TickMs == 0 {
+		config.TickMs = DefaultTickMs
+	}
+	if config.MaxDurationSec == 0 {
+		config.MaxDurationSec = DefaultMaxDurationSec
+	}
+	if config.BotMaxTimeouts == 0 {
+		config.BotMaxTimeouts = bot.DefaultMaxTimeouts
+	}
+
+	return config, config.Validate()
+}
+
+func (config Config) Validate() error {
+	if config.Width <= 0 || config.Height <= 0 {
+		return errors.New("width and height must be greater than 0")
+	}
+	if config.TickMs <= 0 {
+		return errors.New("tickMs must be greater than 0")
+	}
+	if config.MaxDurationSec <= 0 {
+		return errors.New("maxDurationSec must be greater than 0")
+	}
+	if config.BotTimeoutMs < 0 || config.BotStartTimeoutMs < 0 || config.BotMaxTimeouts < 0 {
+		return errors.New("bot settings must not be negative")
+	}
+	if len(config.Ships) < 2 {
+		return errors.New("there must be at least two ships")
+	}
+
+	if config.Rules != nil {
+		if err := config.Rules.Validate(); err != nil {
+			return fmt.Errorf("invalid rules: %w", err)
+		}
+	}
+
+	names := map[string]bool{}
+	for _, ship := range config.Ships {
+		if ship.Name == "" {
+			return errors.New("ship name must not be empty")
+		}
+		if names[ship.Name] {
+			return fmt.Errorf("duplicate ship name: %s", ship.Name)
+		}
+		names[ship.Name] = true
+	}
+
+	return nil
+}
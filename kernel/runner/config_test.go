@@ -0,0 +1,85 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfig_Defaults(t *testing.T) {
+	config, err := ParseConfig([]byte(`{"seed": 42, "ships": [{"name": "a"}, {"name": "b"}]}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), config.Seed)
+	assert.Equal(t, float64(DefaultWidth), config.Width)
+	assert.Equal(t, float64(DefaultHeight), config.Height)
+	assert.Equal(t, float64(DefaultTickMs), config.TickMs)
+	assert.Equal(t, float64(DefaultMaxDurationSec), config.MaxDurationSec)
+	assert.Len(t, config.Ships, 2)
+	assert.Equal(t, game.DefaultRules(), config.Rules)
+}
+
+func TestParseConfig_Rules(t *testing.T) {
+	config, err := ParseConfig([]byte(`{"ships": [{"name": "a"}, {"name": "b"}], "rules": {"laserDamage": 25}}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, config.Rules.LaserDamage)
+	assert.Equal(t, float64(game.RocketDamage), config.Rules.RocketDamage)
+
+	_, err = ParseConfig([]byte(`{"ships": [{"name": "a"}, {"name": "b"}], "rules": {"maxHealth": -1}}`))
+	assert.EqualError(t, err, "invalid rules: maxHealth must be greater than 0")
+
+	// A typo does not silently fall back to the default
+	_, err = ParseConfig([]byte(`{"ships": [{"name": "a"}, {"name": "b"}], "rules": {"laserDamge": 25}}`))
+	assert.EqualError(t, err, `json: unknown field "laserDamge"`)
+
+	_, err = ParseConfig([]byte(`{"ships": [{"name": "a"}, {"name": "b"}], "tickMss": 20}`))
+	assert.EqualError(t, err, `json: unknown field "tickMss"`)
+}
+
+func TestParseConfig_InvalidJSON(t *testing.T) {
+	_, err := ParseConfig([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	var tests = []struct {
+		config      Config
+		expectedErr string
+	}{
+		{Config{Width: 0, Height: 1, TickMs: 1, MaxDurationSec: 1}, "width and height must be greater than 0"},
+		{Config{Width: 1, Height: 1, TickMs: 0, MaxDurationSec: 1}, "tickMs must be greater than 0"},
+		{Config{Width: 1, Height: 1, TickMs: 1, MaxDurationSec: -1}, "maxDurationSec must be greater than 0"},
+		{Config{Width: 1, Height: 1, TickMs: 1, MaxDurationSec: 1, Ships: []ShipConfig{{Name: "a"}}}, "there must be at least two ships"},
+		{Config{Width: 1, Height: 1, TickMs: 1, MaxDurationSec: 1, Ships: []ShipConfig{{Name: "a"}, {Name: ""}}}, "ship name must not be empty"},
+		{Config{Width: 1, Height: 1, TickMs: 1, MaxDurationSec: 1, Ships: []ShipConfig{{Name: "a"}, {Name: "a"}}}, "duplicate ship name: a"},
+	}
+
+	for _, test := range tests {
+		err := test.config.Validate()
+		assert.EqualError(t, err, test.expectedErr)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "match.json")
+	err := os.WriteFile(path, []byte(`{"width": 800, "height": 600, "ships": [{"name": "a"}, {"name": "b"}]}`), 0o644)
+	assert.NoError(t, err)
+
+	config, err := LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 800.0, config.Width)
+	assert.Equal(t, 600.0, config.Height)
+
+	_, err = LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	// Unknown rules in the file
+	err = os.WriteFile(path, []byte(`{"ships": [{"name": "a"}, {"name": "b"}], "rules": {"laserDamge": 25}}`), 0o644)
+	assert.NoError(t, err)
+	_, err = LoadConfig(path)
+	assert.EqualError(t, err, `json: unknown field "laserDamge"`)
+}
@@ -0,0 +1,126 @@
+package runner
+
+import (
+	"io"
+	"time"
+
+	"github.com/davidhorak/space-wars/kernel/bot"
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/davidhorak/space-wars/kernel/physics"
+)
+
+type Result struct {
+	Ticks      int64                  `json:"ticks"`
+	ElapsedMs  float64                `json:"elapsedMs"`
+	State      map[string]interface{} `json:"state"`
+	Scoreboard []game.ScoreboardEntry `json:"scoreboard"`
+	Teams      []game.TeamEntry       `json:"teams,omitempty"`
+	Winner     string                 `json:"winner,omitempty"` // Empty on a draw or when the match did not end
+	Bots       []bot.Report           `json:"bots,omitempty"`
+	Replay     *game.Replay           `json:"-"`
+}
+
+// NewMatch creates the game described by the configuration,
+// with the asteroids seeded and the ships placed, ready to be started.
+func NewMatch(config Config) (*game.Game, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	rules := config.Rules
+	if rules == nil {
+		rules = game.DefaultRules()
+	}
+
+	instance := game.NewGame(physics.Size{Width: config.Width, Height: config.Height}, config.Seed, rules)
+	instance.SeedAsteroids()
+	for _, ship := range config.Ships {
+		err := instance.AddSpaceship(ship.Name, physics.Vector2{X: ship.X, Y: ship.Y}, ship.Rotation, ship.Team)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return instance, nil
+}
+
+// Run simulates a full match with a fixed tick length until the game ends
+// or the maximum duration is reached. The ships with a command are controlled
+// by bot processes, their stderr is forwarded to botStderr (discarded when nil).
+func Run(config Config, botStderr io.Writer) (*Result, error) {
+	instance, err := NewMatch(config)
+	if err != nil {
+		return nil, err
+	}
+
+	bots, err := startBots(config, botStderr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := instance.StartRecording(); err != nil {
+		bots.Close(0, game.Ended)
+		return nil, err
+	}
+	instance.Start()
+	bots.Start(instance)
+
+	maxDurationMs := config.MaxDurationSec * 1000
+	for instance.Status() != game.Ended && instance.Time().ElapsedMs < maxDurationMs {
+		instance.Update(config.TickMs)
+		bots.Update(instance, instance.Time().Tick, config.TickMs)
+	}
+	bots.Close(instance.Time().Tick, instance.Status())
+
+	result := newResult(instance)
+	result.Replay = instance.StopRecording()
+	if reports := bots.Reports(); len(reports) > 0 {
+		result.Bots = reports
+	}
+	return result, nil
+}
+
+// Verify re-runs the recorded match and checks it ends in the recorded state.
+func Verify(replay *game.Replay) (*Result, error) {
+	instance, err := replay.Verify()
+	if err != nil {
+		return nil, err
+	}
+	return newResult(instance), nil
+}
+
+func newResult(instance *game.Game) *Result {
+	result := &Result{
+		Ticks:      instance.Time().Tick,
+		ElapsedMs:  instance.Time().ElapsedMs,
+		State:      instance.Serialize(),
+		Scoreboard: instance.Scoreboard(),
+		Teams:      instance.Teams(),
+	}
+	if instance.Status() == game.Ended {
+		result.Winner = instance.Winner()
+	}
+	return result
+}
+
+func startBots(config Config, stderr io.Writer) (*bot.Controller, error) {
+	controller := bot.NewController(bot.Options{
+		Timeout:      time.Duration(config.BotTimeoutMs * float64(time.Millisecond)),
+		StartTimeout: time.Duration(config.BotStartTimeoutMs * float64(time.Millisecond)),
+		MaxTimeouts:  config.BotMaxTimeouts,
+		Stderr:       stderr,
+		FogOfWar:     config.FogOfWar,
+	})
+
+	for _, ship := range config.Ships {
+		if len(ship.Command) == 0 {
+			continue
+		}
+		if err := controller.Add(ship.Name, ship.Command); err != nil {
+			controller.Close(0, game.Ended)
+			return nil, err
+		}
+	}
+
+	return controller, nil
+}
@@ -0,0 +1,142 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/davidhorak/space-wars/kernel/bot"
+	"github.com/davidhorak/space-wars/kernel/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfig() Config {
+	return Config{
+		Width:          1024,
+		Height:         768,
+		Seed:           1234567890,
+		TickMs:         50,
+		MaxDurationSec: 2,
+		Ships: []ShipConfig{
+			{Name: "Ship 1", X: 300, Y: 200, Rotation: 0},
+			{Name: "Ship 2", X: 700, Y: 500, Rotation: 3.14},
+		},
+	}
+}
+
+func TestNewMatch(t *testing.T) {
+	instance, err := NewMatch(testConfig())
+
+	assert.NoError(t, err)
+	assert.Equal(t, game.Initialized, instance.Status())
+	assert.Len(t, instance.Scoreboard(), 2)
+
+	_, err = NewMatch(Config{})
+	assert.Error(t, err)
+}
+
+func TestRun_MaxDuration(t *testing.T) {
+	result, err := Run(testConfig(), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(40), result.Ticks)
+	assert.Equal(t, 2000.0, result.ElapsedMs)
+	assert.Equal(t, "running", result.State["status"])
+	assert.Len(t, result.Scoreboard, 2)
+}
+
+func TestRun_Ended(t *testing.T) {
+	config := testConfig()
+	// Ships placed on top of each other collide on the first tick
+	config.Ships[1].X = config.Ships[0].X
+	config.Ships[1].Y = config.Ships[0].Y
+
+	result, err := Run(config, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ended", result.State["status"])
+	assert.Equal(t, "eliminated", result.State["endReason"])
+	assert.Less(t, result.ElapsedMs, config.MaxDurationSec*1000)
+	assert.Equal(t, "", result.Winner)
+}
+
+func TestRun_TimeLimit(t *testing.T) {
+	config := testConfig()
+	config.Rules = game.DefaultRules()
+	config.Rules.TimeLimitSec = 1
+
+	result, err := Run(config, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ended", result.State["status"])
+	assert.Equal(t, "timeLimit", result.State["endReason"])
+	assert.Equal(t, 1000.0, result.ElapsedMs)
+	// Nobody scored or got hurt
+	assert.Equal(t, "", result.Winner)
+}
+
+func TestRun_Teams(t *testing.T) {
+	config := testConfig()
+	config.Ships = append(config.Ships, ShipConfig{Name: "Ship 3", X: 300, Y: 200, Rotation: 0})
+	config.Ships[0].Team, config.Ships[2].Team = "red", "red"
+	config.Ships[1].Team = "blue"
+
+	result, err := Run(config, nil)
+
+	assert.NoError(t, err)
+	// The teammates on top of each other pass through each other
+	assert.Equal(t, "running", result.State["status"])
+	assert.Equal(t, []game.TeamEntry{
+		{Name: "red", Health: 100, Spaceships: []string{"Ship 1", "Ship 3"}},
+		{Name: "blue", Health: 100, Spaceships: []string{"Ship 2"}},
+	}, result.Teams)
+}
+
+func TestRun_Replay(t *testing.T) {
+	result, err := Run(testConfig(), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Replay)
+
+	verified, err := Verify(result.Replay)
+
+	assert.NoError(t, err)
+	assert.Equal(t, result.State, verified.State)
+	assert.Equal(t, result.Ticks, verified.Ticks)
+
+	result.Replay.Seed++
+	_, err = Verify(result.Replay)
+	assert.ErrorContains(t, err, "final state hash mismatch")
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	_, err := Run(Config{}, nil)
+	assert.Error(t, err)
+}
+
+func TestRun_Bots(t *testing.T) {
+	config := testConfig()
+	// Replies to every message with full main thrust
+	config.Ships[0].Command = []string{"sh", "-c", `while read -r line; do
+		tick=$(echo "$line" | sed 's/.*"tick":\([0-9]*\).*/\1/')
+		echo "{\"tick\": $tick, \"actions\": [[\"setEngineThrust\", 100, 0, 0]]}"
+	done`}
+	config.BotTimeoutMs = 1000
+	config.MaxDurationSec = 0.5
+
+	result, err := Run(config, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bot.Report{{Name: "Ship 1"}}, result.Bots)
+	for _, gameObject := range result.State["gameObjects"].([]interface{}) {
+		spaceship := gameObject.(map[string]interface{})
+		if spaceship["name"] == "Ship 1" {
+			assert.Equal(t, 100.0, spaceship["engine"].(map[string]interface{})["mainThrust"])
+		}
+	}
+}
+
+func TestRun_InvalidBotCommand(t *testing.T) {
+	config := testConfig()
+	config.Ships[0].Command = []string{"./does-not-exist"}
+
+	_, err := Run(config, nil)
+	assert.ErrorContains(t, err, "failed to start bot Ship 1")
+}
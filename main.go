@@ -1,3 +1,5 @@
+//go:build js && wasm
+
 package main
 
 import (
@@ -9,6 +11,17 @@ import (
 	"github.com/davidhorak/space-wars/kernel/physics"
 )
 
+// actionArgNames are the names of the arguments of the spaceship actions, after the action and the ship name.
+var actionArgNames = map[game.ActionType][]string{
+	game.ActionSetEngineThrust:   {"mainEngineThrust", "leftEngineThrust", "rightEngineThrust"},
+	game.ActionSetRotationThrust: {"rotationThrust"},
+	game.ActionSetStartPosition:  {"x", "y", "rotation"},
+	game.ActionFireLaser:         {},
+	game.ActionFireRocket:        {},
+	game.ActionDropMine:          {},
+	game.ActionSetShield:         {"shield"},
+}
+
 func main() {
 	fmt.Println("Space Wars")
 	fmt.Println("Copyright (C) 2024 David Horak")
@@ -30,14 +43,26 @@ func main() {
 		}
 
 		seed := time.Now().UnixNano()
-		if len(args) == 3 {
+		if len(args) >= 3 && !args[2].IsUndefined() {
 			seed, err = method.IntArg(2, "seed")
 			if err != nil {
 				fmt.Println(err)
 			}
 		}
 
-		instance = game.NewGame(physics.Size{Width: width, Height: height}, seed)
+		// The invalid rules fall back to the defaults, the game is always created
+		rules := game.DefaultRules()
+		if len(args) >= 4 && !args[3].IsUndefined() {
+			data, err := method.StringArg(3, "rules")
+			if err == nil {
+				rules, err = game.ParseRulesOrDefault([]byte(data))
+			}
+			if err != nil {
+				fmt.Println(err, "- using the default rules")
+			}
+		}
+
+		instance = game.NewGame(physics.Size{Width: width, Height: height}, seed, rules)
 		instance.SeedAsteroids()
 	})
 	tickCb := JsFuncIn(func(args []js.Value) {
@@ -52,16 +77,31 @@ func main() {
 	pauseGameCb := JsFunc(func() { instance.Pause() })
 	resetGameCb := JsFunc(func() { instance.Reset() })
 	gameStateCb := JsFuncOut(func() any { return instance.Serialize() })
-	fromStateCb := JsFuncIn(func(args []js.Value) {
+	fromStateCb := JsFuncInOut(func(args []js.Value) any {
 		method := Method("fromState", args)
 		state, err := method.StringArg(0, "state")
 		if err != nil {
 			fmt.Println(err)
+			return map[string]interface{}{"error": err.Error(), "warnings": []interface{}{}}
+		}
+		mode := game.Lenient
+		if len(args) > 1 && args[1].Truthy() {
+			mode = game.Strict
+		}
+
+		restored, stateWarnings, err := game.DeserializeState(state, mode)
+		warnings := make([]interface{}, len(stateWarnings))
+		for i, warning := range stateWarnings {
+			fmt.Println(warning)
+			warnings[i] = warning.Error()
 		}
-		instance, err = game.Deserialize(state)
+		// The current game is kept when the state cannot be restored
 		if err != nil {
 			fmt.Println(err)
+			return map[string]interface{}{"error": err.Error(), "warnings": warnings}
 		}
+		instance = restored
+		return map[string]interface{}{"error": nil, "warnings": warnings}
 	})
 	addSpaceshipCb := JsFuncIn(func(args []js.Value) {
 		method := Method("addSpaceship", args)
@@ -83,7 +123,15 @@ func main() {
 			fmt.Println(err)
 		}
 
-		instance.AddSpaceship(shipName, physics.Vector2{X: x, Y: y}, rotation)
+		team := ""
+		if len(args) >= 5 && !args[4].IsUndefined() {
+			team, err = method.StringArg(4, "team")
+			if err != nil {
+				fmt.Println(err)
+			}
+		}
+
+		instance.AddSpaceship(shipName, physics.Vector2{X: x, Y: y}, rotation, team)
 	})
 	spaceShipActionCb := JsFuncIn(func(args []js.Value) {
 		method := Method("action", args)
@@ -97,53 +145,62 @@ func main() {
 			fmt.Println(err)
 		}
 
-		instance.SpaceshipAction(shipName, func(spaceShip *game.Spaceship, gameManager *game.GameManager) {
-			switch action {
-			case "setEngineThrust":
-				mainEngineThrust, err := method.FloatArg(2, "mainEngineThrust")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				leftEngineThrust, err := method.FloatArg(3, "leftEngineThrust")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				rightEngineThrust, err := method.FloatArg(4, "rightEngineThrust")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-
-				spaceShip.SetEngineThrust(mainEngineThrust, leftEngineThrust, rightEngineThrust)
-			case "setStartPosition":
-				x, err := method.FloatArg(2, "x")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				y, err := method.FloatArg(3, "y")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				rotation, err := method.FloatArg(4, "rotation")
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-
-				spaceShip.SetStartPosition(physics.Vector2{X: x, Y: y})
-				spaceShip.SetStartRotation(rotation)
-			case "fireLaser":
-				spaceShip.FireLaser(gameManager)
-			case "fireRocket":
-				spaceShip.FireRocket(gameManager)
-			default:
-				fmt.Errorf("invalid action: %s", action)
+		names, ok := actionArgNames[game.ActionType(action)]
+		if !ok {
+			fmt.Printf("invalid action: %s\n", action)
+			return
+		}
+		actionArgs := make([]float64, len(names))
+		for i, name := range names {
+			actionArgs[i], err = method.FloatArg(i+2, name)
+			if err != nil {
+				fmt.Println(err)
+				return
 			}
-		})
+		}
+
+		// Through ApplyAction, so the recorded replay reproduces the match
+		instance.ApplyAction(shipName, game.Action{Type: game.ActionType(action), Args: actionArgs})
+	})
+
+	wrappedDisplacementCb := JsFuncInOut(func(args []js.Value) any {
+		method := Method("wrappedDisplacement", args)
+
+		values := [4]float64{}
+		for i, argName := range []string{"fromX", "fromY", "toX", "toY"} {
+			value, err := method.FloatArg(i, argName)
+			if err != nil {
+				fmt.Println(err)
+				return nil
+			}
+			values[i] = value
+		}
+
+		from := physics.Vector2{X: values[0], Y: values[1]}
+		to := physics.Vector2{X: values[2], Y: values[3]}
+		displacement := physics.WrappedDisplacement(from, to, instance.Size())
+		return map[string]interface{}{
+			"x":        displacement.X,
+			"y":        displacement.Y,
+			"distance": displacement.Magnitude(),
+		}
+	})
+
+	observeCb := JsFuncInOut(func(args []js.Value) any {
+		method := Method("observe", args)
+
+		shipName, err := method.StringArg(0, "shipName")
+		if err != nil {
+			fmt.Println(err)
+			return nil
+		}
+
+		observation, err := instance.Observe(shipName)
+		if err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		return observation.Serialize()
 	})
 
 	jsGlobal.Set("spaceWars", map[string]interface{}{
@@ -156,6 +213,10 @@ func main() {
 		"fromState":    fromStateCb,
 		"addSpaceship": addSpaceshipCb,
 		"action":       spaceShipActionCb,
+		// The shortest displacement between two positions, across the battlefield edges
+		"wrappedDisplacement": wrappedDisplacementCb,
+		// What the spaceship perceives of the battlefield, limited by the radar rules
+		"observe": observeCb,
 	})
 
 	<-done
@@ -168,4 +229,6 @@ func main() {
 	fromStateCb.Release()
 	addSpaceshipCb.Release()
 	spaceShipActionCb.Release()
+	wrappedDisplacementCb.Release()
+	observeCb.Release()
 }
@@ -1,8 +1,12 @@
 import {
   Asteroid,
   Explosion,
+  Flag,
   GameObject,
+  Hill,
   Laser,
+  Mine,
+  PowerUp,
   Rocket,
   Spaceship,
 } from "./types";
@@ -19,5 +23,17 @@ export const isLaser = (gameObject: GameObject): gameObject is Laser =>
 export const isRocket = (gameObject: GameObject): gameObject is Rocket =>
   gameObject.type === "rocket";
 
+export const isMine = (gameObject: GameObject): gameObject is Mine =>
+  gameObject.type === "mine";
+
+export const isPowerUp = (gameObject: GameObject): gameObject is PowerUp =>
+  gameObject.type === "powerUp";
+
 export const isSpaceship = (gameObject: GameObject): gameObject is Spaceship =>
   gameObject.type === "spaceship";
+
+export const isHill = (gameObject: GameObject): gameObject is Hill =>
+  gameObject.type === "hill";
+
+export const isFlag = (gameObject: GameObject): gameObject is Flag =>
+  gameObject.type === "flag";
@@ -24,7 +24,11 @@ export {
   isExplosion,
   isLaser,
   isRocket,
+  isMine,
+  isPowerUp,
   isSpaceship,
+  isHill,
+  isFlag,
 } from "./gameObject";
 
 export type { SpaceshipAction } from "./spaceshipAction";
@@ -39,8 +43,17 @@ export type {
   DamageLog,
   KillLog,
   CollisionLog,
+  PowerUpLog,
   GameStateLog,
   GameState,
+  EndReason,
+  Rules,
+  Mode,
+  Hill,
+  Flag,
+  Team,
+  Observation,
+  ObservedSpaceship,
 } from "./types";
 
 const spaceshipFactories: SpaceshipManagerFactory[] = [
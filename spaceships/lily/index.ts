@@ -158,7 +158,7 @@ export const AmazingSpaceship = (): SpaceshipManager => {
           self.rotation,
           gameObject.position.x,
           gameObject.position.y,
-          (gameObject.collider.radius + self.collider.radius) * 1.5,
+          (gameObject.radius + self.collider.radius) * 1.5,
           2000,
           gameWidth,
           gameHeight
@@ -9,6 +9,10 @@ export type SetEngineThrustAction = [
   RightEngineThrust
 ];
 
+type RotationThrust = number;
+
+export type SetRotationThrustAction = ["setRotationThrust", RotationThrust];
+
 type X = number;
 type Y = number;
 type Rotation = number;
@@ -17,11 +21,20 @@ export type SetStartPositionAction = ["setStartPosition", X, Y, Rotation];
 
 export type FireLaserAction = ["fireLaser"];
 export type FireRocketAction = ["fireRocket"];
+export type DropMineAction = ["dropMine"];
+
+// 1 raises the shield, 0 lowers it
+type Shield = 0 | 1;
+
+export type SetShieldAction = ["setShield", Shield];
 
 export type SpaceshipAction =
   | SetEngineThrustAction
+  | SetRotationThrustAction
   | FireLaserAction
-  | FireRocketAction;
+  | FireRocketAction
+  | DropMineAction
+  | SetShieldAction;
 
 export const isSetEngineThrustAction = (
   action: unknown[]
@@ -29,6 +42,12 @@ export const isSetEngineThrustAction = (
   return action[0] === "setEngineThrust";
 };
 
+export const isSetRotationThrustAction = (
+  action: unknown[]
+): action is SetRotationThrustAction => {
+  return action[0] === "setRotationThrust";
+};
+
 export const isSetStartPositionAction = (
   action: unknown[]
 ): action is SetStartPositionAction => {
@@ -46,3 +65,15 @@ export const isFireRocketAction = (
 ): action is FireRocketAction => {
   return action[0] === "fireRocket";
 };
+
+export const isDropMineAction = (
+  action: unknown[]
+): action is DropMineAction => {
+  return action[0] === "dropMine";
+};
+
+export const isSetShieldAction = (
+  action: unknown[]
+): action is SetShieldAction => {
+  return action[0] === "setShield";
+};
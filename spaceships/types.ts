@@ -46,9 +46,30 @@ export type GameObject = {
 export type Asteroid = GameObject & {
   type: "asteroid";
   enabled: boolean;
+  startPosition: {
+    x: number;
+    y: number;
+  };
   radius: number;
-  lifespanSec: number;
-  collider: CircleCollider;
+  rotation: number;
+  velocity: {
+    x: number;
+    y: number;
+  };
+  // Restored on reset, the velocity changes in the physical collisions
+  startVelocity: {
+    x: number;
+    y: number;
+  };
+  angularVelocity: number;
+  // Taken from the projectiles, see Rules["asteroidHealth"]
+  damage: number;
+  // Split off another asteroid, removed on reset
+  fragment: boolean;
+  // Clockwise, relative to the position and unrotated, empty for the circles.
+  // The radius is of the circle around them.
+  vertices: { x: number; y: number }[];
+  collider: CircleCollider | PolygonCollider;
 };
 
 export type Explosion = GameObject & {
@@ -59,6 +80,27 @@ export type Explosion = GameObject & {
   lifespanSec: number;
 };
 
+// The zone of the king of the hill, see kernel/game/king_of_the_hill.go
+export type Hill = GameObject & {
+  type: "hill";
+  radius: number;
+  // The side alone on the hill, a team or a spaceship name, empty when none or contested
+  controller: string;
+};
+
+// The flag of a side in the capture the flag, see kernel/game/capture_the_flag.go
+export type Flag = GameObject & {
+  type: "flag";
+  // The team, or the name of the spaceship playing on its own
+  side: string;
+  base: {
+    x: number;
+    y: number;
+  };
+  // ID of the spaceship carrying the flag, 0 when none
+  carrier: number;
+};
+
 export type Projectile = GameObject & {
   enabled: boolean;
   rotation: number;
@@ -68,7 +110,10 @@ export type Projectile = GameObject & {
   };
   lifespanSec: number;
   damage: number;
-  owner: string;
+  // ID of the spaceship which fired the projectile
+  owner: number;
+  // ID of the spaceship the homing rocket tracks, 0 when none
+  target: number;
 };
 
 export type Laser = Projectile & {
@@ -81,11 +126,32 @@ export type Rocket = Projectile & {
   collider: CircleCollider;
 };
 
+// Stays where it was dropped, detonates when an enemy spaceship comes within the trigger radius
+export type Mine = GameObject & {
+  type: "mine";
+  // Until the mine could be triggered
+  armingTimerSec: number;
+  lifespanSec: number;
+  // ID of the spaceship which dropped the mine
+  owner: number;
+};
+
+// Collected by the first spaceship touching it, disappears after its lifespan
+export type PowerUp = GameObject & {
+  type: "powerUp";
+  enabled: boolean;
+  kind: "rockets" | "health" | "energy" | "damageBoost";
+  lifespanSec: number;
+  collider: CircleCollider;
+};
+
 export type Spaceship = GameObject & {
   type: "spaceship";
   enabled: boolean;
   destroyed: boolean;
   name: string;
+  // Empty when the spaceship is not in a team
+  team: string;
   startPosition: {
     x: number;
     y: number;
@@ -95,26 +161,49 @@ export type Spaceship = GameObject & {
     x: number;
     y: number;
   };
+  // Radians per second, positive turns clockwise
+  angularVelocity: number;
   health: number;
   energy: number;
   engine: {
     mainThrust: number;
     leftThrust: number;
     rightThrust: number;
+    rotationThrust: number;
   };
   rockets: number;
+  mines: number;
   kills: number;
   score: number;
+  // Damage dealt to the opponents, a tiebreaker
+  damageDealt: number;
+  // Seconds on the hill or captured flags, depending on the game mode
+  objective: number;
+  // Until the destroyed spaceship respawns, 0 when it does not
+  respawnTimerSec: number;
+  // Until the respawned spaceship takes damage again
+  invulnerableTimerSec: number;
+  deaths: number;
+  respawns: number;
   laserReloadTimerSec: number;
   rocketReloadTimerSec: number;
+  // Absorbs a part of the weapon damage while up, drains the energy
+  shield: boolean;
+  // Until the lowered shield can be raised again
+  shieldCooldownTimerSec: number;
+  // Until the damage boost of the power-up wears off
+  damageBoostTimerSec: number;
   collider: CircleCollider;
 };
 
 export type Log = {
   id: number;
-  logType: "damage" | "kill" | "collision" | "game_state";
+  logType: "damage" | "kill" | "collision" | "power_up" | "game_state";
   message: string;
+  // Simulation time, formatted as hh:mm:ss.mmm
   time: string;
+  tick: number;
+  elapsedMs: number;
   meta: Record<string, string>;
 };
 
@@ -125,6 +214,11 @@ export type DamageLog = Log & {
     whom: string;
     damage: string;
     damageType: string;
+    whoTeam?: string;
+    whomTeam?: string;
+    friendlyFire?: "true";
+    // Damage the shield absorbed, only when it did
+    absorbed?: string;
   };
 };
 
@@ -133,6 +227,9 @@ export type KillLog = Log & {
   meta: {
     who: string;
     whom: string;
+    whoTeam?: string;
+    whomTeam?: string;
+    friendlyFire?: "true";
   };
 };
 
@@ -141,6 +238,17 @@ export type CollisionLog = Log & {
   meta: {
     who: string;
     with: string;
+    whoTeam?: string;
+    withTeam?: string;
+  };
+};
+
+export type PowerUpLog = Log & {
+  logType: "power_up";
+  meta: {
+    who: string;
+    powerUp: PowerUp["kind"];
+    whoTeam?: string;
   };
 };
 
@@ -148,16 +256,206 @@ export type GameStateLog = Log & {
   logType: "game_state";
   meta: {
     status: "initialized" | "running" | "paused" | "ended";
+    // The end of the match, the winner is left out on a draw
+    reason?: EndReason;
+    winner?: string;
+    // The start of the sudden death
+    suddenDeath?: Rules["suddenDeath"];
   };
 };
 
+// Balance of the game, see kernel/game/rules.go
+export type Rules = {
+  // Asteroids
+  minAsteroids: number;
+  maxAsteroids: number;
+  minAsteroidSize: number;
+  maxAsteroidSize: number;
+  minAsteroidSeparation: number;
+  maxAsteroidVelocitySec: number;
+  maxAsteroidAngularVelocitySec: number;
+  asteroidSplit: boolean;
+  asteroidSplitVelocitySec: number;
+  asteroidHealth: number;
+  asteroidShape: "circle" | "polygon";
+  minAsteroidVertices: number;
+  maxAsteroidVertices: number;
+  asteroidRoughness: number;
+
+  // Ships
+  shipSize: number;
+  maxHealth: number;
+  maxEnergy: number;
+  maxVelocitySec: number;
+  accelerationCoefficient: number;
+  dragCoefficient: number;
+  sideThrustPowerCoefficient: number;
+  maxAngularVelocitySec: number;
+  angularAccelerationCoefficient: number;
+  angularDragCoefficient: number;
+  // The heading of a moving spaceship follows its velocity, false to turn only by the rotation thrust
+  headingFollowsVelocity: boolean;
+  energyConsumptionMainThrustSec: number;
+  energyConsumptionSideThrustSec: number;
+  energyConsumptionRotationSec: number;
+  energyRechargeRateSec: number;
+  shipExplosionRadius: number;
+  shipExplosionDurationSec: number;
+  scorePerKill: number;
+  scorePerDamageCoefficient: number;
+
+  // Collisions, the asteroid density is the mass of a square meter,
+  // the restitution from 0, sticking together, to 1, bouncing off without a loss
+  collisionResponse: "destroy" | "physical";
+  shipMass: number;
+  asteroidDensity: number;
+  restitution: number;
+  collisionDamageCoefficient: number;
+
+  // Lasers
+  laserReloadSec: number;
+  energyConsumptionLaser: number;
+  laserLifespanSec: number;
+  laserDamage: number;
+  laserVelocitySec: number;
+  laserWidth: number;
+  laserLength: number;
+  laserExplosionRadius: number;
+  laserExplosionDurationSec: number;
+
+  // Rockets
+  maxRockets: number;
+  rocketReloadSec: number;
+  energyConsumptionRocket: number;
+  rocketLifespanSec: number;
+  rocketDamage: number;
+  rocketSpeedSec: number;
+  rocketDetonateRadius: number;
+  rocketExplosionRadius: number;
+  rocketExplosionDurationSec: number;
+  rocketExplosionDamage: number;
+  rocketHoming: boolean;
+  rocketHomingConeAngle: number;
+  rocketHomingTurnRateSec: number;
+
+  // Mines
+  maxMines: number;
+  mineArmingSec: number;
+  mineLifespanSec: number;
+  mineTriggerRadius: number;
+  mineExplosionRadius: number;
+  mineExplosionDurationSec: number;
+  mineDamage: number;
+
+  // Power-ups, spawned every interval when it is not 0
+  powerUpIntervalSec: number;
+  maxPowerUps: number;
+  powerUpLifespanSec: number;
+  powerUpRadius: number;
+  powerUpRockets: number;
+  powerUpHealth: number;
+  powerUpEnergy: number;
+  damageBoostMultiplier: number;
+  damageBoostDurationSec: number;
+
+  // Shields
+  energyConsumptionShieldSec: number;
+  shieldAbsorption: number;
+  shieldCooldownSec: number;
+
+  // Radar, see Observation
+  radarRange: number;
+  radarPrecisionRange: number;
+  radarNoiseCoefficient: number;
+  radarLineOfSight: boolean;
+
+  // Teams
+  friendlyFire: "off" | "reduced" | "full";
+  friendlyFireCoefficient: number;
+
+  // Match, 0 is no time limit
+  timeLimitSec: number;
+  suddenDeath: "off" | "shrinkingZone" | "noRecharge";
+  suddenDeathDurationSec: number;
+  safeZoneDamageSec: number;
+
+  // Game modes, an objectiveLimit of 0 is unlimited
+  mode: Mode;
+  objectiveLimit: number;
+  hillRadius: number;
+  hillScoreSec: number;
+  flagRadius: number;
+  flagCaptureScore: number;
+
+  // Respawns, always on in the modes other than lastStanding
+  respawn: boolean;
+  respawnDelaySec: number;
+  respawnInvulnerabilitySec: number;
+  spawnClearance: number;
+};
+
+export type Mode = "lastStanding" | "deathmatch" | "kingOfTheHill" | "captureTheFlag";
+
+// Why the match ended, empty while it is not over
+export type EndReason = "" | "eliminated" | "timeLimit" | "objective";
+
+// The spaceships of a team summed up, derived from the game objects
+export type Team = {
+  name: string;
+  objective: number;
+  score: number;
+  // The average of the spaceships
+  health: number;
+  damageDealt: number;
+  kills: number;
+  deaths: number;
+  respawns: number;
+  destroyed: boolean;
+  // Names of the spaceships
+  spaceships: string[];
+};
+
+// See state.schema.json, generated from the kernel
 export type GameState = {
+  schemaVersion: number;
   status: "initialized" | "running" | "paused" | "ended";
+  endReason: EndReason;
   seed: number;
+  tick: number;
+  elapsedMs: number;
   size: {
     width: number;
     height: number;
   };
-  gameObjects: (Asteroid | Explosion | Laser | Rocket | Spaceship)[];
+  rules: Rules;
+  gameObjects: (Asteroid | Explosion | Laser | Rocket | Mine | PowerUp | Spaceship | Hill | Flag)[];
+  teams: Team[];
   logs: Log[];
 };
+
+// The public state of the other spaceships in an observation
+export type ObservedSpaceship = Pick<
+  Spaceship,
+  | "type"
+  | "id"
+  | "enabled"
+  | "destroyed"
+  | "name"
+  | "team"
+  | "position"
+  | "rotation"
+  | "velocity"
+  | "angularVelocity"
+  | "shield"
+  | "invulnerableTimerSec"
+  | "collider"
+>;
+
+// What a spaceship perceives of the battlefield, see spaceWars.observe.
+// The objects are limited by the radar rules, their positions could be noisy.
+export type Observation = {
+  tick: number;
+  elapsedMs: number;
+  spaceship: Spaceship;
+  gameObjects: (Asteroid | Explosion | Laser | Rocket | Mine | PowerUp | ObservedSpaceship | Hill | Flag)[];
+};
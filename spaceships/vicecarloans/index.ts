@@ -21,7 +21,7 @@ const isAsteroidBlockingShot = (ship: Spaceship, enemy: Spaceship, asteroids: As
         const asteroidY = asteroid.collider.position.y;
         const distanceToShotLine = Math.abs((shotLine.x2 - shotLine.x1) * (shotLine.y1 - asteroidY) - (shotLine.x1 - asteroidX) * (shotLine.y2 - shotLine.y1)) /
             Math.sqrt((shotLine.x2 - shotLine.x1) ** 2 + (shotLine.y2 - shotLine.y1) ** 2);
-        return distanceToShotLine < asteroid.collider.radius; // Check if asteroid intersects the shot path
+        return distanceToShotLine < asteroid.radius; // Check if asteroid intersects the shot path
     });
 };
 
@@ -255,17 +255,17 @@ class VicecarloansSpaceship implements SpaceshipManager {
          // Check if the computed thrust values will cause a collision with any asteroid
         const willCollideWithAsteroidMoveLeft = this.asteroidLocs.some(asteroid => {
             // Move Right?
-            return calculateDistance(futureXMoveLeft, this.spaceship.position.y, asteroid.collider.position.x + asteroid.collider.radius, asteroid.collider.position.y + asteroid.collider.radius) < this.DODGE_THRESHOLD_ASTEROID ||
-            calculateDistance(futureXMoveLeft, futureYMove, asteroid.collider.position.x + asteroid.collider.radius, asteroid.collider.position.y + asteroid.collider.radius) < this.DODGE_THRESHOLD_ASTEROID; 
+            return calculateDistance(futureXMoveLeft, this.spaceship.position.y, asteroid.collider.position.x + asteroid.radius, asteroid.collider.position.y + asteroid.radius) < this.DODGE_THRESHOLD_ASTEROID ||
+            calculateDistance(futureXMoveLeft, futureYMove, asteroid.collider.position.x + asteroid.radius, asteroid.collider.position.y + asteroid.radius) < this.DODGE_THRESHOLD_ASTEROID; 
         });
 
         const willCollideWithAsteroidMoveRight = this.asteroidLocs.some(asteroid => {
-            return calculateDistance(futureXMoveRight, this.spaceship.position.y, asteroid.collider.position.x + asteroid.collider.radius, asteroid.collider.position.y + asteroid.collider.radius) < this.DODGE_THRESHOLD_ASTEROID ||
-            calculateDistance(futureXMoveRight, futureYMove, asteroid.collider.position.x + asteroid.collider.radius, asteroid.collider.position.y + asteroid.collider.radius) < this.DODGE_THRESHOLD_ASTEROID;
+            return calculateDistance(futureXMoveRight, this.spaceship.position.y, asteroid.collider.position.x + asteroid.radius, asteroid.collider.position.y + asteroid.radius) < this.DODGE_THRESHOLD_ASTEROID ||
+            calculateDistance(futureXMoveRight, futureYMove, asteroid.collider.position.x + asteroid.radius, asteroid.collider.position.y + asteroid.radius) < this.DODGE_THRESHOLD_ASTEROID;
         });
 
         const willCollideWithAsteroidMoveY = this.asteroidLocs.some(asteroid => {
-            return calculateDistance(this.spaceship.position.x, futureYMove, asteroid.collider.position.x + asteroid.collider.radius, asteroid.collider.position.y + asteroid.collider.radius) < this.DODGE_THRESHOLD_ASTEROID;
+            return calculateDistance(this.spaceship.position.x, futureYMove, asteroid.collider.position.x + asteroid.radius, asteroid.collider.position.y + asteroid.radius) < this.DODGE_THRESHOLD_ASTEROID;
         })
 
         // Enemies